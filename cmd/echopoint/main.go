@@ -1,16 +1,49 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"echopoint-cli/internal/commands"
+	"echopoint-cli/internal/tui/floweditor"
+)
+
+// Set at build time via -ldflags (see .goreleaser.yml)
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
 )
 
 func main() {
-	root := commands.NewRootCmd()
-	if err := root.Execute(); err != nil {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first SIGINT or SIGTERM cancels the command's context so requests
+	// abort and the debug log is closed below; a second one kills the process.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		signal.Stop(signals)
+		floweditor.GetLogger().Warn("Received %s, shutting down", sig)
+		cancel()
+	}()
+
+	root := commands.NewRootCmd(commands.BuildInfo{
+		Version: version,
+		Commit:  commit,
+		Date:    date,
+	})
+	err := root.ExecuteContext(ctx)
+
+	signal.Stop(signals)
+	_ = floweditor.GetLogger().Close()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
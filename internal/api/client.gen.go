@@ -25,10 +25,10 @@ const (
 
 // Defines values for BodyExtractorConfigFormat.
 const (
-	Json BodyExtractorConfigFormat = "json"
-	Raw  BodyExtractorConfigFormat = "raw"
-	Text BodyExtractorConfigFormat = "text"
-	Xml  BodyExtractorConfigFormat = "xml"
+	BodyExtractorConfigFormatJson BodyExtractorConfigFormat = "json"
+	BodyExtractorConfigFormatRaw  BodyExtractorConfigFormat = "raw"
+	BodyExtractorConfigFormatText BodyExtractorConfigFormat = "text"
+	BodyExtractorConfigFormatXml  BodyExtractorConfigFormat = "xml"
 )
 
 // Defines values for CollectionSource.
@@ -156,6 +156,14 @@ const (
 	StartsWith         OperatorType = "startsWith"
 )
 
+// Defines values for OutputExtractorAs.
+const (
+	OutputExtractorAsBoolean OutputExtractorAs = "boolean"
+	OutputExtractorAsJson    OutputExtractorAs = "json"
+	OutputExtractorAsNumber  OutputExtractorAs = "number"
+	OutputExtractorAsString  OutputExtractorAs = "string"
+)
+
 // Defines values for RequestNodeDataMethod.
 const (
 	DELETE  RequestNodeDataMethod = "DELETE"
@@ -199,6 +207,9 @@ type BaseFlowNode struct {
 	// Assertions Validation assertions for the node
 	Assertions *[]CompositeAssertion `json:"assertions,omitempty"`
 
+	// Disabled Disabled nodes are skipped when the flow runs
+	Disabled *bool `json:"disabled,omitempty"`
+
 	// DisplayName Human-readable name for the node
 	DisplayName string `json:"display_name"`
 
@@ -208,6 +219,9 @@ type BaseFlowNode struct {
 	// Outputs Named outputs extracted from the response/data
 	Outputs *[]Output `json:"outputs,omitempty"`
 
+	// Retry Re-runs a node when its assertions fail
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
 	// Type Type of node
 	Type string `json:"type"`
 }
@@ -376,6 +390,9 @@ type CreateFlowRequest struct {
 	// Name Human-readable name for the flow.
 	Name string `json:"name"`
 
+	// Tags Labels for organizing flows.
+	Tags *[]string `json:"tags,omitempty"`
+
 	// Version Version identifier for the flow.
 	Version *string `json:"version,omitempty"`
 }
@@ -467,6 +484,9 @@ type DelayFlowNode struct {
 	Assertions *[]CompositeAssertion `json:"assertions,omitempty"`
 	Data       DelayNodeData         `json:"data"`
 
+	// Disabled Disabled nodes are skipped when the flow runs
+	Disabled *bool `json:"disabled,omitempty"`
+
 	// DisplayName Human-readable name for the node
 	DisplayName string `json:"display_name"`
 
@@ -475,7 +495,10 @@ type DelayFlowNode struct {
 
 	// Outputs Named outputs extracted from the response/data
 	Outputs *[]Output `json:"outputs,omitempty"`
-	Type    string    `json:"type"`
+
+	// Retry Re-runs a node when its assertions fail
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	Type  string       `json:"type"`
 }
 
 // DelayNodeData defines model for DelayNodeData.
@@ -574,6 +597,9 @@ type Flow struct {
 	// Name Human-readable name for the flow.
 	Name string `json:"name"`
 
+	// Tags Labels for organizing flows.
+	Tags *[]string `json:"tags,omitempty"`
+
 	// UpdatedAt Timestamp when the flow was last updated.
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -929,6 +955,10 @@ type OperatorType string
 type Output struct {
 	// Extractor Extractor configuration that defines how to extract data from the response
 	Extractor struct {
+		// As Type the extracted value is coerced to, so downstream templates receive
+		// a number, boolean, or JSON value instead of a string.
+		As *OutputExtractorAs `json:"as,omitempty"`
+
 		// HeaderName Header name to extract (used by header extractor)
 		HeaderName *string `json:"header_name,omitempty"`
 
@@ -943,6 +973,10 @@ type Output struct {
 	Name string `json:"name"`
 }
 
+// OutputExtractorAs Type the extracted value is coerced to, so downstream templates receive
+// a number, boolean, or JSON value instead of a string.
+type OutputExtractorAs string
+
 // PagedListResponse defines model for PagedListResponse.
 type PagedListResponse struct {
 	// Count The number of items returned in this response.
@@ -964,6 +998,9 @@ type RequestFlowNode struct {
 	Assertions *[]CompositeAssertion `json:"assertions,omitempty"`
 	Data       RequestNodeData       `json:"data"`
 
+	// Disabled Disabled nodes are skipped when the flow runs
+	Disabled *bool `json:"disabled,omitempty"`
+
 	// DisplayName Human-readable name for the node
 	DisplayName string `json:"display_name"`
 
@@ -972,7 +1009,10 @@ type RequestFlowNode struct {
 
 	// Outputs Named outputs extracted from the response/data
 	Outputs *[]Output `json:"outputs,omitempty"`
-	Type    string    `json:"type"`
+
+	// Retry Re-runs a node when its assertions fail
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	Type  string       `json:"type"`
 }
 
 // RequestNodeData defines model for RequestNodeData.
@@ -1020,6 +1060,15 @@ type RequestVolumesResponse struct {
 	To time.Time `json:"to"`
 }
 
+// RetryPolicy Re-runs a node when its assertions fail
+type RetryPolicy struct {
+	// Count Times the node is re-run after a failed attempt
+	Count int `json:"count"`
+
+	// Delay Milliseconds to wait before each retry
+	Delay int `json:"delay"`
+}
+
 // SearchRequest Generic search request template
 type SearchRequest struct {
 	// FullTextSearch Full-text search term to match against searchable fields.
@@ -1067,6 +1116,9 @@ type UpdateFlowRequest struct {
 	// Name Human-readable name for the flow.
 	Name *string `json:"name,omitempty"`
 
+	// Tags Labels for organizing flows.
+	Tags *[]string `json:"tags,omitempty"`
+
 	// Version Version identifier for the flow.
 	Version *string `json:"version,omitempty"`
 }
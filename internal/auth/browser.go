@@ -16,6 +16,9 @@ const (
 	callbackPath    = "/callback"
 	defaultTimeout  = 5 * time.Minute
 	localServerPort = "8765"
+
+	// opaqueTokenLifetime is assumed for tokens that don't carry an expiry
+	opaqueTokenLifetime = time.Hour
 )
 
 // BrowserLogin opens the browser for authentication and waits for the callback
@@ -88,12 +91,16 @@ func BrowserLogin(ctx context.Context, frontendURL string, debug bool) (Credenti
 	case token := <-tokenCh:
 		_ = server.Shutdown(context.Background())
 
-		// Token expires in ~1 hour
-		expiresAt := time.Now().Add(1 * time.Hour)
+		// Prefer the token's own expiry; opaque tokens last ~1 hour
+		expiresAt := TokenExpiry(token)
+		if expiresAt == nil {
+			fallback := time.Now().Add(opaqueTokenLifetime)
+			expiresAt = &fallback
+		}
 
 		return Credentials{
 			AccessToken: token,
-			ExpiresAt:   &expiresAt,
+			ExpiresAt:   expiresAt,
 		}, nil
 
 	case err := <-errCh:
@@ -199,7 +206,7 @@ func errorPage(message string) string {
 			align-items: center;
 			justify-content: center;
 			min-height: 100vh;
-			background: linear-gradient(135deg, #ef4444 0%, #dc2626 100%%);
+			background: linear-gradient(135deg, #ef4444 0%%, #dc2626 100%%);
 		}
 		.container {
 			background: white;
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// TokenClaims holds the JWT claims the CLI cares about
+type TokenClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// ParseTokenClaims decodes the payload of a JWT without verifying its
+// signature. It returns false if token is not a JWT.
+func ParseTokenClaims(token string) (TokenClaims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenClaims{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return TokenClaims{}, false
+	}
+
+	var claims TokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return TokenClaims{}, false
+	}
+	return claims, true
+}
+
+// TokenExpiry returns the expiry encoded in a JWT, or nil if token is not a
+// JWT or has no exp claim
+func TokenExpiry(token string) *time.Time {
+	claims, ok := ParseTokenClaims(token)
+	if !ok || claims.ExpiresAt == 0 {
+		return nil
+	}
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	return &expiresAt
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// makeJWT builds an unsigned token with the given claims payload
+func makeJWT(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return header + "." + body + ".signature"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  *time.Time
+	}{
+		{name: "jwt with exp", token: makeJWT(`{"sub":"u1","exp":1767225600}`), want: ptr(time.Unix(1767225600, 0))},
+		{name: "jwt without exp", token: makeJWT(`{"sub":"u1"}`)},
+		{name: "opaque token", token: "d41d8cd98f00b204e9800998ecf8427e"},
+		{name: "two segments", token: "abc.def"},
+		{name: "payload not base64", token: "abc.!!!.def"},
+		{name: "payload not json", token: "abc." + base64.RawURLEncoding.EncodeToString([]byte("plain")) + ".def"},
+		{name: "empty", token: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TokenExpiry(tt.token)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("TokenExpiry = %v, want nil", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("TokenExpiry = nil, want %v", *tt.want)
+			case tt.want != nil && !got.Equal(*tt.want):
+				t.Errorf("TokenExpiry = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTokenClaimsPadded(t *testing.T) {
+	// Some issuers keep base64 padding on the payload segment
+	payload := base64.URLEncoding.EncodeToString([]byte(`{"sub":"user-42","exp":1}`))
+	claims, ok := ParseTokenClaims("h." + payload + ".s")
+	if !ok {
+		t.Fatal("ParseTokenClaims rejected a padded payload")
+	}
+	if claims.Subject != "user-42" || claims.ExpiresAt != 1 {
+		t.Errorf("claims = %+v, want sub user-42 and exp 1", claims)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
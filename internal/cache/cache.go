@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"echopoint-cli/internal/config"
+)
+
+const dirName = "cache"
+
+// Store is a small on-disk cache for short-lived API responses.
+// Entries are scoped so that different API hosts or accounts never share results.
+type Store struct {
+	dir string
+	ttl time.Duration
+}
+
+// Dir returns the root directory holding all cached responses
+func Dir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dirName), nil
+}
+
+// New returns a store for the given scope with entries valid for ttl
+func New(scope string, ttl time.Duration) (*Store, error) {
+	root, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir: filepath.Join(root, hashKey(scope)),
+		ttl: ttl,
+	}, nil
+}
+
+// Get returns the cached value for key if it exists and has not expired
+func (s *Store) Get(key string) ([]byte, bool) {
+	path := s.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if time.Since(info.ModTime()) > s.ttl {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key, replacing any previous entry
+func (s *Store) Put(key string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, "entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Invalidate drops every entry in the store's scope
+func (s *Store) Invalidate() error {
+	return os.RemoveAll(s.dir)
+}
+
+// Clear removes cached responses for every scope
+func Clear() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	return dir, nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, hashKey(key)+".json")
+}
+
+func hashKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:16])
+}
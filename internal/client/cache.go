@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"echopoint-cli/internal/cache"
+)
+
+// cacheableCollections lists the list endpoints whose responses may be reused
+var cacheableCollections = []string{"/flows", "/collections"}
+
+// cachingTransport serves repeated list requests from the on-disk cache and
+// drops the cache whenever a request changes server state
+type cachingTransport struct {
+	base  http.RoundTripper
+	store *cache.Store
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			_ = t.store.Invalidate()
+		}
+		return resp, err
+	}
+
+	if !isCacheable(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	if data, ok := t.store.Get(key); ok {
+		return cachedResponse(req, data), nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	_ = t.store.Put(key, body)
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// isCacheable reports whether path addresses one of the cacheable list endpoints
+func isCacheable(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	for _, suffix := range cacheableCollections {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
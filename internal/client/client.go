@@ -3,31 +3,134 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/cache"
+	"echopoint-cli/internal/redact"
 )
 
 type Client struct {
 	api     *api.ClientWithResponses
+	stream  *api.Client
 	token   string
 	baseURL string
 	debug   bool
 }
 
-func New(baseURL string, token string, timeout time.Duration) (*Client, error) {
-	httpClient := &http.Client{Timeout: timeout}
+// Config contains the settings used to construct a Client
+type Config struct {
+	BaseURL string
+	Token   string
+	Timeout time.Duration
 
-	options := []api.ClientOption{
-		api.WithHTTPClient(httpClient),
+	// CacheTTL controls how long list responses are reused; zero disables
+	// the list cache
+	CacheTTL time.Duration
+
+	// NoCache turns off every on-disk cache, including ETag revalidation of
+	// single flows, which is otherwise on regardless of CacheTTL
+	NoCache bool
+
+	// APIVersion pins requests to a server API version via the X-API-Version
+	// header; empty uses the server default
+	APIVersion string
+
+	// UserAgent identifies the CLI build on every request
+	UserAgent string
+
+	// Trace, when set, receives every HTTP exchange with credentials redacted
+	Trace io.Writer
+
+	// Redact masks sensitive fields in traced bodies; nil uses the defaults
+	Redact *redact.Redactor
+
+	// MaxIdleConns, MaxIdleConnsPerHost, and IdleConnTimeout size the
+	// connection pool shared by all requests; zero keeps the standard
+	// library default
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// MockDir, when set, serves every request from canned response files in
+	// that directory instead of the network (see mockTransport)
+	MockDir string
+}
+
+// apiVersionHeader carries Config.APIVersion on every request
+const apiVersionHeader = "X-API-Version"
+
+func New(cfg Config) (*Client, error) {
+	baseURL, err := normalizeBaseURL(cfg.BaseURL)
+	if err != nil {
+		return nil, err
 	}
+	cfg.BaseURL = baseURL
+
+	var wire http.RoundTripper = newTransport(cfg)
+	if cfg.MockDir != "" {
+		u, _ := url.Parse(cfg.BaseURL)
+		wire = &mockTransport{dir: cfg.MockDir, basePath: u.Path}
+		// Canned responses must not be mixed with cached live ones
+		cfg.NoCache = true
+	}
+	if cfg.Trace != nil {
+		redactor := cfg.Redact
+		if redactor == nil {
+			redactor = redact.New()
+		}
+		wire = &traceTransport{base: wire, w: cfg.Trace, redact: redactor}
+	}
+	transport := &rateLimitTransport{base: wire}
+	httpClient := &http.Client{Timeout: cfg.Timeout, Transport: transport}
+
+	if !cfg.NoCache {
+		// Scope the cache by host and account so environments never cross-contaminate
+		scope := cfg.BaseURL + "\x00" + cfg.Token + "\x00" + cfg.APIVersion
+		etags, err := cache.New(scope+"\x00etag", etagTTL)
+		if err != nil {
+			return nil, err
+		}
+		// Revalidated flows are never stale, so this does not wait for cache.ttl
+		httpClient.Transport = &etagTransport{base: transport, store: etags}
+
+		if cfg.CacheTTL > 0 {
+			store, err := cache.New(scope, cfg.CacheTTL)
+			if err != nil {
+				return nil, err
+			}
+			httpClient.Transport = &cachingTransport{base: httpClient.Transport, store: store}
+		}
+	}
+
+	var options []api.ClientOption
 
 	// Check if debug mode is enabled
 	debug := os.Getenv("ECHOPOINT_DEBUG") != ""
 
-	if token != "" {
+	if cfg.UserAgent != "" {
+		userAgent := cfg.UserAgent
+		options = append(options, api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("User-Agent", userAgent)
+			return nil
+		}))
+	}
+
+	if cfg.APIVersion != "" {
+		version := cfg.APIVersion
+		options = append(options, api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(apiVersionHeader, version)
+			return nil
+		}))
+	}
+
+	if cfg.Token != "" {
+		token := cfg.Token
 		options = append(options, api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
@@ -41,19 +144,70 @@ func New(baseURL string, token string, timeout time.Duration) (*Client, error) {
 		}))
 	}
 
-	apiClient, err := api.NewClientWithResponses(baseURL, options...)
+	apiClient, err := api.NewClientWithResponses(cfg.BaseURL, append(options, api.WithHTTPClient(httpClient))...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Streams such as flow runs outlive the request timeout and are never cached
+	streamClient, err := api.NewClient(cfg.BaseURL, append(options, api.WithHTTPClient(&http.Client{Transport: transport}))...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
 		api:     apiClient,
-		token:   token,
-		baseURL: baseURL,
+		stream:  streamClient,
+		token:   cfg.Token,
+		baseURL: cfg.BaseURL,
 		debug:   debug,
 	}, nil
 }
 
+// newTransport returns a transport with its own connection pool sized by cfg
+func newTransport(cfg Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	return transport
+}
+
+// normalizeBaseURL checks that raw is an absolute http(s) URL and trims any
+// trailing slash, suggesting a fix for the common mistake of leaving out the
+// scheme
+func normalizeBaseURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("API base URL is empty; set api.base_url or pass --api-url")
+	}
+
+	u, err := url.Parse(raw)
+	if err == nil && u.Scheme == "" {
+		err = fmt.Errorf("missing scheme")
+	}
+	if err != nil || u.Host == "" {
+		if !strings.Contains(raw, "://") {
+			return "", fmt.Errorf("invalid API base URL %q: missing http:// or https:// (did you mean https://%s?)", raw, strings.TrimSuffix(raw, "/"))
+		}
+		return "", fmt.Errorf("invalid API base URL %q: expected a URL such as https://api.echopoint.dev", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid API base URL %q: scheme must be http or https", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid API base URL %q: must not include a query or fragment", raw)
+	}
+
+	return strings.TrimRight(raw, "/"), nil
+}
+
 func (c *Client) BaseURL() string {
 	return c.baseURL
 }
@@ -65,3 +219,10 @@ func (c *Client) Token() string {
 func (c *Client) API() *api.ClientWithResponses {
 	return c.api
 }
+
+// Stream returns a client for long-lived streaming responses. It sends the
+// same headers as API but has no overall timeout, so callers should bound
+// requests with a context.
+func (c *Client) Stream() *api.Client {
+	return c.stream
+}
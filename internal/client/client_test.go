@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordingServer answers every request with 200 and {} and keeps the
+// headers of each request it received
+type recordingServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	headers []http.Header
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	t.Helper()
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.headers = append(s.headers, r.Header.Clone())
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// lastHeader returns the headers of the most recent request
+func (s *recordingServer) lastHeader(t *testing.T) http.Header {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.headers) == 0 {
+		t.Fatal("server received no requests")
+	}
+	return s.headers[len(s.headers)-1]
+}
+
+// newTestClient builds a client for cfg with the cache directory in a
+// temporary home, so tests never touch the real one
+func newTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestAPIVersionHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "pinned", version: "2", want: "2"},
+		{name: "server default", version: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRecordingServer(t)
+			c := newTestClient(t, Config{BaseURL: server.URL, Token: "t", APIVersion: tt.version})
+
+			if _, err := c.API().HealthCheckWithResponse(context.Background()); err != nil {
+				t.Fatalf("HealthCheck: %v", err)
+			}
+			header := server.lastHeader(t)
+			if got := header.Get(apiVersionHeader); got != tt.want {
+				t.Errorf("%s = %q, want %q", apiVersionHeader, got, tt.want)
+			}
+			if sent := len(header.Values(apiVersionHeader)) > 0; sent != (tt.want != "") {
+				t.Errorf("%s sent = %v, want %v", apiVersionHeader, sent, tt.want != "")
+			}
+		})
+	}
+}
+
+func TestAPIVersionHeaderOnStreams(t *testing.T) {
+	server := newRecordingServer(t)
+	c := newTestClient(t, Config{BaseURL: server.URL, Token: "t", APIVersion: "3"})
+
+	resp, err := c.Stream().HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck: %v", err)
+	}
+	resp.Body.Close()
+	if got := server.lastHeader(t).Get(apiVersionHeader); got != "3" {
+		t.Errorf("%s on stream client = %q, want %q", apiVersionHeader, got, "3")
+	}
+}
+
+func TestUserAgentHeader(t *testing.T) {
+	server := newRecordingServer(t)
+	c := newTestClient(t, Config{BaseURL: server.URL, UserAgent: "echopoint-cli/1.2.3 (linux; amd64)"})
+
+	if _, err := c.API().HealthCheckWithResponse(context.Background()); err != nil {
+		t.Fatalf("HealthCheck: %v", err)
+	}
+	if got := server.lastHeader(t).Get("User-Agent"); got != "echopoint-cli/1.2.3 (linux; amd64)" {
+		t.Errorf("User-Agent = %q, want the configured one", got)
+	}
+
+	resp, err := c.Stream().HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck: %v", err)
+	}
+	resp.Body.Close()
+	if got := server.lastHeader(t).Get("User-Agent"); got != "echopoint-cli/1.2.3 (linux; amd64)" {
+		t.Errorf("User-Agent on stream client = %q, want the configured one", got)
+	}
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr string
+	}{
+		{raw: "https://api.echopoint.dev", want: "https://api.echopoint.dev"},
+		{raw: "https://api.echopoint.dev/", want: "https://api.echopoint.dev"},
+		{raw: "https://api.echopoint.dev//", want: "https://api.echopoint.dev"},
+		{raw: "http://localhost:8080/api/v1/", want: "http://localhost:8080/api/v1"},
+		{raw: "  https://api.echopoint.dev  ", want: "https://api.echopoint.dev"},
+
+		{raw: "", wantErr: "API base URL is empty"},
+		{raw: "apidev.echopoint.dev", wantErr: "missing http:// or https:// (did you mean https://apidev.echopoint.dev?)"},
+		{raw: "apidev.echopoint.dev/", wantErr: "did you mean https://apidev.echopoint.dev?"},
+		{raw: "localhost:8080", wantErr: "missing http:// or https:// (did you mean https://localhost:8080?)"},
+		{raw: "https://", wantErr: "expected a URL such as https://api.echopoint.dev"},
+		{raw: "ftp://files.echopoint.dev", wantErr: "scheme must be http or https"},
+		{raw: "https://api.echopoint.dev/?debug=1", wantErr: "must not include a query or fragment"},
+		{raw: "https://api.echopoint.dev/#top", wantErr: "must not include a query or fragment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := normalizeBaseURL(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("normalizeBaseURL error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeBaseURL: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeBaseURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsBadBaseURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, err := New(Config{BaseURL: "apidev.echopoint.dev"}); err == nil {
+		t.Error("New accepted a base URL without a scheme")
+	}
+}
+
+func TestNewTransportPoolSize(t *testing.T) {
+	transport := newTransport(Config{MaxIdleConns: 50, MaxIdleConnsPerHost: 20, IdleConnTimeout: time.Minute})
+	if transport.MaxIdleConns != 50 || transport.MaxIdleConnsPerHost != 20 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("transport pool = %d/%d/%v, want 50/20/1m0s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	defaults := http.DefaultTransport.(*http.Transport)
+	transport = newTransport(Config{})
+	if transport.MaxIdleConns != defaults.MaxIdleConns || transport.MaxIdleConnsPerHost != defaults.MaxIdleConnsPerHost {
+		t.Errorf("transport pool = %d/%d, want the standard library defaults", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport == defaults {
+		t.Error("newTransport returned the shared default transport")
+	}
+}
+
+// BenchmarkFetchFlows fetches 200 flows with 16 workers, as bulk commands do,
+// with the standard pool (2 idle connections per host) and a tuned one.
+// conns/op counts the connections the server accepted.
+func BenchmarkFetchFlows(b *testing.B) {
+	const flows, workers = 200, 16
+
+	for _, bm := range []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "default pool"},
+		{name: "tuned pool", cfg: Config{MaxIdleConns: 100, MaxIdleConnsPerHost: workers}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			var conns atomic.Int64
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": "6f1c1d2e-8d53-4a8e-9a3b-1d1f1b2c3d4e", "name": "bench",
+					"flow_definition": {"name": "bench", "version": "1.0", "nodes": [], "edges": []}}`))
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					conns.Add(1)
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			b.Setenv("HOME", b.TempDir())
+			cfg := bm.cfg
+			cfg.BaseURL, cfg.Token = server.URL, "t"
+			c, err := New(cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+			ids := make([]uuid.UUID, flows)
+			for i := range ids {
+				ids[i] = uuid.New()
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				jobs := make(chan uuid.UUID)
+				var wg sync.WaitGroup
+				for w := 0; w < workers; w++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for id := range jobs {
+							if _, err := c.API().GetFlowWithResponse(context.Background(), id); err != nil {
+								b.Error(err)
+							}
+						}
+					}()
+				}
+				for _, id := range ids {
+					jobs <- id
+				}
+				close(jobs)
+				wg.Wait()
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"echopoint-cli/internal/cache"
+
+	"github.com/google/uuid"
+)
+
+// etagTTL bounds how long a flow body is kept for revalidation. Entries are
+// only ever served after the server confirms them, so this just limits disk use.
+const etagTTL = 24 * time.Hour
+
+// etagEntry is a flow body together with the ETag the server sent for it
+type etagEntry struct {
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body"`
+}
+
+// etagTransport revalidates single-flow GETs against the last body seen for
+// the same URL. Requests carry If-None-Match, and a 304 answer is turned back
+// into a 200 with the cached body, so read-modify-write sequences from
+// scripts skip downloading unchanged flows. Servers that send no ETag are
+// unaffected.
+type etagTransport struct {
+	base  http.RoundTripper
+	store *cache.Store
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !isFlowPath(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	var entry etagEntry
+	cached := false
+	if data, ok := t.store.Get(key); ok && json.Unmarshal(data, &entry) == nil && entry.ETag != "" {
+		cached = true
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		revalidated := cachedResponse(req, entry.Body)
+		revalidated.Header.Set("ETag", entry.ETag)
+		return revalidated, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if json.Valid(body) {
+		if data, err := json.Marshal(etagEntry{ETag: etag, Body: body}); err == nil {
+			_ = t.store.Put(key, data)
+		}
+	}
+	return resp, nil
+}
+
+// isFlowPath reports whether path addresses a single flow, as in /flows/<id>
+func isFlowPath(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "flows" {
+		return false
+	}
+	_, err := uuid.Parse(segments[len(segments)-1])
+	return err == nil
+}
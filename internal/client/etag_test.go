@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// etagServer serves one flow tagged with an ETag, answers 304 when the
+// request carries the current one, and records the If-None-Match it saw
+type etagServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	name        string
+	etag        string
+	downloads   int
+	ifNoneMatch []string
+}
+
+func newETagServer(t *testing.T, etag string) *etagServer {
+	t.Helper()
+	s := &etagServer{name: "first", etag: etag}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.ifNoneMatch = append(s.ifNoneMatch, r.Header.Get("If-None-Match"))
+		if s.etag != "" {
+			if r.Header.Get("If-None-Match") == s.etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", s.etag)
+		}
+		s.downloads++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": %q, "name": %q, "flow_definition": {"name": "f", "version": "1.0", "nodes": [], "edges": []}}`, uuid.Nil, s.name)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// update changes the flow so the server answers with a new body and ETag
+func (s *etagServer) update(name, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name, s.etag = name, etag
+}
+
+func (s *etagServer) stats() (int, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.downloads, append([]string(nil), s.ifNoneMatch...)
+}
+
+// fetchFlowName gets the flow and returns the status and name the caller sees
+func fetchFlowName(t *testing.T, c *Client) (int, string) {
+	t.Helper()
+	resp, err := c.API().GetFlowWithResponse(context.Background(), uuid.Nil)
+	if err != nil {
+		t.Fatalf("GetFlow: %v", err)
+	}
+	if resp.JSON200 == nil {
+		return resp.StatusCode(), ""
+	}
+	return resp.StatusCode(), resp.JSON200.Name
+}
+
+func TestETagRevalidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           Config
+		etag          string
+		wantDownloads int
+		wantSent      []string
+	}{
+		{
+			name:          "list cache off",
+			etag:          `"v1"`,
+			wantDownloads: 1,
+			wantSent:      []string{"", `"v1"`, `"v1"`},
+		},
+		{
+			name:          "list cache on",
+			cfg:           Config{CacheTTL: time.Minute},
+			etag:          `"v1"`,
+			wantDownloads: 1,
+			wantSent:      []string{"", `"v1"`, `"v1"`},
+		},
+		{
+			name:          "no cache",
+			cfg:           Config{NoCache: true},
+			etag:          `"v1"`,
+			wantDownloads: 3,
+			wantSent:      []string{"", "", ""},
+		},
+		{
+			name:          "server sends no ETag",
+			wantDownloads: 3,
+			wantSent:      []string{"", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newETagServer(t, tt.etag)
+			cfg := tt.cfg
+			cfg.BaseURL, cfg.Token = server.URL, "t"
+			c := newTestClient(t, cfg)
+
+			for i := 0; i < 3; i++ {
+				status, name := fetchFlowName(t, c)
+				if status != http.StatusOK || name != "first" {
+					t.Fatalf("fetch %d = %d %q, want 200 with the flow", i+1, status, name)
+				}
+			}
+			downloads, sent := server.stats()
+			if downloads != tt.wantDownloads {
+				t.Errorf("server sent the flow %d times, want %d", downloads, tt.wantDownloads)
+			}
+			if fmt.Sprint(sent) != fmt.Sprint(tt.wantSent) {
+				t.Errorf("If-None-Match sent = %q, want %q", sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestETagRevalidationSeesChanges(t *testing.T) {
+	server := newETagServer(t, `"v1"`)
+	c := newTestClient(t, Config{BaseURL: server.URL, Token: "t"})
+
+	if _, name := fetchFlowName(t, c); name != "first" {
+		t.Fatalf("first fetch returned %q", name)
+	}
+	server.update("second", `"v2"`)
+	if _, name := fetchFlowName(t, c); name != "second" {
+		t.Fatalf("fetch after a change returned %q, want the new flow", name)
+	}
+	if _, name := fetchFlowName(t, c); name != "second" {
+		t.Fatalf("revalidated fetch returned %q, want the new flow", name)
+	}
+
+	downloads, sent := server.stats()
+	if want := []string{"", `"v1"`, `"v2"`}; fmt.Sprint(sent) != fmt.Sprint(want) {
+		t.Errorf("If-None-Match sent = %q, want %q", sent, want)
+	}
+	if downloads != 2 {
+		t.Errorf("server sent the flow %d times, want 2", downloads)
+	}
+}
+
+func TestETagTransportPassesThroughUnknown304(t *testing.T) {
+	// A 304 for a request the transport did not revalidate is not its to rewrite
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	t.Cleanup(server.Close)
+	c := newTestClient(t, Config{BaseURL: server.URL, Token: "t"})
+
+	if status, _ := fetchFlowName(t, c); status != http.StatusNotModified {
+		t.Errorf("status = %d, want the server's 304", status)
+	}
+}
+
+func TestIsFlowPath(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/flows/" + id, want: true},
+		{path: "/api/v1/flows/" + id + "/", want: true},
+		{path: "/flows"},
+		{path: "/flows/" + id + "/runs"},
+		{path: "/flows/not-a-uuid"},
+		{path: "/collections/" + id},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isFlowPath(tt.path); got != tt.want {
+				t.Errorf("isFlowPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"echopoint-cli/internal/api"
+)
+
+// mockTransport answers requests from canned files in dir instead of the
+// network. A request for METHOD /a/b is served from METHOD_a_b.json, with the
+// status taken from METHOD_a_b.status when present. Without a status file
+// POST answers 201, DELETE 204, and everything else 200. The query string is
+// ignored, and requests with no matching file get a 404.
+type mockTransport struct {
+	dir      string
+	basePath string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	name := mockFileName(req.Method, strings.TrimPrefix(req.URL.Path, t.basePath))
+	body, err := os.ReadFile(filepath.Join(t.dir, name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		message := fmt.Sprintf("no mock response for %s %s (expected %s.json)", req.Method, req.URL.Path, name)
+		body, _ = json.Marshal(api.ApiErrorResponse{
+			Errors: []api.ApiError{{Code: "mock_not_found", Message: message}},
+		})
+		return mockResponse(req, http.StatusNotFound, body), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := defaultMockStatus(req.Method)
+	if data, err := os.ReadFile(filepath.Join(t.dir, name+".status")); err == nil {
+		status, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid mock status in %s.status: %w", name, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return mockResponse(req, status, body), nil
+}
+
+// mockFileName turns a request into its file name without extension, such as
+// GET_flows_<id> for GET /flows/<id>
+func mockFileName(method, path string) string {
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	return strings.Join(append([]string{strings.ToUpper(method)}, segments...), "_")
+}
+
+func defaultMockStatus(method string) int {
+	switch method {
+	case http.MethodPost:
+		return http.StatusCreated
+	case http.MethodDelete:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+func mockResponse(req *http.Request, status int, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
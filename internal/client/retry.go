@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// rateLimitRetries is how many times a rate-limited request is retried
+	rateLimitRetries = 3
+
+	// maxRateLimitWait is the longest the CLI waits before a retry; a server
+	// asking for more gets the 429 passed through instead
+	maxRateLimitWait = 30 * time.Second
+)
+
+// rateLimitTransport retries requests answered with 429 Too Many Requests,
+// waiting as long as the Retry-After header asks, or with exponential backoff
+// when it is absent
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	for attempt := 0; attempt < rateLimitRetries; attempt++ {
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		// A consumed body can only be sent again if it can be recreated
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := RetryAfter(resp.Header)
+		if !ok {
+			wait = time.Second << attempt
+		}
+		if wait > maxRateLimitWait {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		fmt.Fprintf(os.Stderr, "Rate limited, retrying in %s\n", formatWait(wait))
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			retry.Body = body
+		}
+		resp, err = t.base.RoundTrip(retry)
+	}
+	return resp, err
+}
+
+// RetryAfter reads the Retry-After header, given either as seconds or as an
+// HTTP date
+func RetryAfter(header http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// formatWait rounds a wait up to whole seconds for messages
+func formatWait(wait time.Duration) string {
+	seconds := int((wait + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%ds", seconds)
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"echopoint-cli/internal/redact"
+)
+
+// maxTraceBody is how much of each body the trace keeps; longer bodies are
+// cut off with a marker giving their full size
+const maxTraceBody = 1 << 20
+
+// redactedHeaders carry credentials and are never written to a trace
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// traceTransport records every request and response, headers and bodies
+// included, to w. Sensitive fields in JSON bodies are masked. Exchanges are
+// written whole so concurrent requests do not interleave.
+type traceTransport struct {
+	base   http.RoundTripper
+	w      io.Writer
+	redact *redact.Redactor
+	mu     sync.Mutex
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "=== %s %s %s\n", time.Now().UTC().Format(time.RFC3339Nano), req.Method, req.URL)
+	writeTraceHeaders(&b, "> ", req.Header)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var body []byte
+		var err error
+		if req.GetBody != nil {
+			var rc io.ReadCloser
+			if rc, err = req.GetBody(); err == nil {
+				body, err = io.ReadAll(rc)
+				rc.Close()
+			}
+		} else {
+			body, err = io.ReadAll(req.Body)
+			req.Body.Close()
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		if err != nil {
+			return nil, err
+		}
+		writeTraceBody(&b, "> ", t.redact.Body(body))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&b, "< error after %s: %v\n\n", elapsed, err)
+		t.write(b.Bytes())
+		return nil, err
+	}
+
+	fmt.Fprintf(&b, "< %s %s (%s)\n", resp.Proto, resp.Status, elapsed)
+	writeTraceHeaders(&b, "< ", resp.Header)
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Reading a stream here would hold it back from the caller
+		b.WriteString("<\n< [streaming body not recorded]\n")
+	} else {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		writeTraceBody(&b, "< ", t.redact.Body(body))
+	}
+	b.WriteString("\n")
+	t.write(b.Bytes())
+
+	return resp, nil
+}
+
+func (t *traceTransport) write(data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(data)
+}
+
+// writeTraceHeaders writes header in name order, hiding credentials
+func writeTraceHeaders(b *bytes.Buffer, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			if redactedHeaders[http.CanonicalHeaderKey(name)] {
+				value = "[REDACTED]"
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, name, value)
+		}
+	}
+}
+
+// writeTraceBody writes body after a blank line, truncated to maxTraceBody
+func writeTraceBody(b *bytes.Buffer, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s\n", strings.TrimSpace(prefix))
+
+	size := len(body)
+	if size > maxTraceBody {
+		body = body[:maxTraceBody]
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(body), "\n"), "\n") {
+		fmt.Fprintf(b, "%s%s\n", prefix, line)
+	}
+	if size > maxTraceBody {
+		fmt.Fprintf(b, "%s[truncated: %d of %d bytes shown]\n", prefix, maxTraceBody, size)
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"echopoint-cli/internal/redact"
+)
+
+func TestTraceRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=server-cookie")
+		_, _ = w.Write([]byte(`{"name": "alice", "access_token": "server-token", "pin": "9876"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var trace bytes.Buffer
+	httpClient := &http.Client{Transport: &traceTransport{
+		base:   http.DefaultTransport,
+		w:      &trace,
+		redact: redact.New("pin"),
+	}}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/login", strings.NewReader(`{"user": "alice", "password": "hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer client-token")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !strings.Contains(string(body), "server-token") || !strings.Contains(string(body), "9876") {
+		t.Errorf("caller received %s, want the unmasked body", body)
+	}
+
+	out := trace.String()
+	for _, secret := range []string{"hunter2", "client-token", "server-token", "server-cookie", "9876"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("trace contains %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{
+		`> {"password":"***","user":"alice"}`,
+		`< {"access_token":"***","name":"alice","pin":"***"}`,
+		"> Authorization: [REDACTED]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace does not contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// analyticsRun is one past execution of a flow with its node outcomes
+type analyticsRun struct {
+	ID          string     `json:"id" yaml:"id"`
+	Status      string     `json:"status" yaml:"status"`
+	TriggerType string     `json:"trigger_type,omitempty" yaml:"trigger_type,omitempty"`
+	StartedAt   time.Time  `json:"started_at" yaml:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" yaml:"completed_at,omitempty"`
+	DurationMs  *int64     `json:"duration_ms,omitempty" yaml:"duration_ms,omitempty"`
+	Passed      int        `json:"passed" yaml:"passed"`
+	Failed      int        `json:"failed" yaml:"failed"`
+	Skipped     int        `json:"skipped" yaml:"skipped"`
+	Error       string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// analyticsSummary aggregates the executions of a flow within a time window
+type analyticsSummary struct {
+	FlowID        string         `json:"flow_id" yaml:"flow_id"`
+	Since         *time.Time     `json:"since,omitempty" yaml:"since,omitempty"`
+	Until         *time.Time     `json:"until,omitempty" yaml:"until,omitempty"`
+	Runs          int            `json:"runs" yaml:"runs"`
+	ByStatus      map[string]int `json:"by_status" yaml:"by_status"`
+	SuccessRate   *float64       `json:"success_rate" yaml:"success_rate"`
+	AvgDurationMs *int64         `json:"avg_duration_ms" yaml:"avg_duration_ms"`
+	P95DurationMs *int64         `json:"p95_duration_ms" yaml:"p95_duration_ms"`
+	FirstRun      *time.Time     `json:"first_run,omitempty" yaml:"first_run,omitempty"`
+	LastRun       *time.Time     `json:"last_run,omitempty" yaml:"last_run,omitempty"`
+}
+
+// newAnalyticsCmd creates the analytics command. The API has no aggregate
+// analytics endpoint for flows, so both subcommands work from the flow's
+// execution history.
+func newAnalyticsCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analytics",
+		Short: "Inspect flow run history and success rates",
+	}
+
+	cmd.AddCommand(
+		newAnalyticsRunsCmd(state),
+		newAnalyticsSummaryCmd(state),
+	)
+
+	return cmd
+}
+
+func newAnalyticsRunsCmd(state *AppState) *cobra.Command {
+	var since, until string
+	limit := 20
+
+	cmd := &cobra.Command{
+		Use:   "runs <flow-id>",
+		Short: "List past executions of a flow",
+		Long: `List past executions of a flow, newest first.
+
+Each run shows when it started, its status, how long it took, and how many
+nodes passed, failed, or were skipped. Node counts need one request per run,
+so keep --limit small for flows with long histories.`,
+		Example: `  echopoint analytics runs <flow-id>
+  echopoint analytics runs <flow-id> --since 7d
+  echopoint analytics runs <flow-id> --since 2024-06-01 --until 2024-07-01 -o json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		Annotations:       map[string]string{jsonlAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+			if limit < 1 {
+				return fmt.Errorf("--limit must be at least 1")
+			}
+
+			flowID, from, to, err := parseAnalyticsArgs(state, args[0], since, until)
+			if err != nil {
+				return err
+			}
+
+			executions, err := fetchExecutionsInWindow(state, flowID, from, to)
+			if err != nil {
+				return err
+			}
+			if len(executions) > limit {
+				executions = executions[:limit]
+			}
+
+			runs, err := analyticsRuns(state, flowID, executions)
+			if err != nil {
+				return err
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, runs)
+			case output.FormatYAML:
+				return printYAML(state, runs)
+			case output.FormatJSONL:
+				return printJSONL(state, runs)
+			default:
+				columns := output.Columns("ID", "Started", "Status", "Duration", "Passed", "Failed", "Skipped")
+				rows := make([][]string, 0, len(runs))
+				for _, run := range runs {
+					rows = append(rows, []string{
+						run.ID,
+						formatTime(state, run.StartedAt),
+						run.Status,
+						formatDurationMs(run.DurationMs),
+						strconv.Itoa(run.Passed),
+						strconv.Itoa(run.Failed),
+						strconv.Itoa(run.Skipped),
+					})
+				}
+				compactIDColumn(state, rows, 0)
+				return printTable(state, columns, rows)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only include runs started at or after this time (RFC 3339, YYYY-MM-DD, or a duration such as 24h or 7d)")
+	cmd.Flags().StringVar(&until, "until", "", "Only include runs started before this time (same formats as --since)")
+	cmd.Flags().IntVar(&limit, "limit", limit, "Maximum number of runs to show")
+	return cmd
+}
+
+func newAnalyticsSummaryCmd(state *AppState) *cobra.Command {
+	var since, until string
+
+	cmd := &cobra.Command{
+		Use:   "summary <flow-id>",
+		Short: "Summarize a flow's success rate and durations",
+		Long: `Summarize the executions of a flow within a time window.
+
+Reports the number of runs by status, the success rate, and the average and
+95th percentile duration. Runs that are still pending or running are counted
+but left out of the success rate and durations.`,
+		Example: `  echopoint analytics summary <flow-id>
+  echopoint analytics summary <flow-id> --since 30d
+  echopoint analytics summary <flow-id> --since 2024-06-01 --until 2024-07-01 -o json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, from, to, err := parseAnalyticsArgs(state, args[0], since, until)
+			if err != nil {
+				return err
+			}
+
+			executions, err := fetchExecutionsInWindow(state, flowID, from, to)
+			if err != nil {
+				return err
+			}
+
+			summary := summarizeExecutions(executions)
+			summary.FlowID = flowID.String()
+			summary.Since = from
+			summary.Until = to
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, summary)
+			case output.FormatYAML:
+				return printYAML(state, summary)
+			default:
+				printAnalyticsSummary(state, summary)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only include runs started at or after this time (RFC 3339, YYYY-MM-DD, or a duration such as 24h or 7d)")
+	cmd.Flags().StringVar(&until, "until", "", "Only include runs started before this time (same formats as --since)")
+	return cmd
+}
+
+// parseAnalyticsArgs resolves the flow argument and the --since and --until
+// bounds shared by the analytics subcommands
+func parseAnalyticsArgs(state *AppState, arg, since, until string) (uuid.UUID, *time.Time, *time.Time, error) {
+	resolved, err := resolveFlowID(state, arg)
+	if err != nil {
+		return uuid.UUID{}, nil, nil, err
+	}
+	flowID, err := uuid.Parse(resolved)
+	if err != nil {
+		return uuid.UUID{}, nil, nil, fmt.Errorf("invalid flow ID: %w", err)
+	}
+
+	now := time.Now()
+	from, err := parseTimeBound("--since", since, now)
+	if err != nil {
+		return uuid.UUID{}, nil, nil, err
+	}
+	to, err := parseTimeBound("--until", until, now)
+	if err != nil {
+		return uuid.UUID{}, nil, nil, err
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return uuid.UUID{}, nil, nil, fmt.Errorf("--since must be before --until")
+	}
+	return flowID, from, to, nil
+}
+
+// parseTimeBound parses an absolute time (RFC 3339 or a local YYYY-MM-DD
+// date) or a duration before now such as 90m, 24h, or 7d. An empty value is
+// no bound.
+func parseTimeBound(flag, value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return &t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			t := now.AddDate(0, 0, -n)
+			return &t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := now.Add(-d)
+		return &t, nil
+	}
+	return nil, fmt.Errorf("invalid %s %q: expected an RFC 3339 time, YYYY-MM-DD date, or duration such as 24h or 7d", flag, value)
+}
+
+// fetchExecutionsInWindow retrieves every execution of a flow that started
+// within [from, to), newest first. The API cannot filter by time, so the
+// whole history is fetched and filtered here.
+func fetchExecutionsInWindow(state *AppState, flowID uuid.UUID, from, to *time.Time) ([]api.FlowExecution, error) {
+	const pageSize = 100
+
+	fetch := func(offset int32) (*api.FlowExecutionListResponse, error) {
+		resp, err := state.Client.API().ListFlowExecutionsWithResponse(state.Context(), flowID, &api.ListFlowExecutionsParams{
+			Limit:  api.LimitParameter(pageSize),
+			Offset: api.OffsetParameter(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.JSON200 == nil {
+			return nil, formatAPIError(resp.HTTPResponse, resp.Body)
+		}
+		return resp.JSON200, nil
+	}
+
+	first, err := fetch(0)
+	if err != nil {
+		return nil, err
+	}
+	all, err := fetchRemainingPages(first.Items, first.Total, pageSize, defaultPageConcurrency, func(offset int32) ([]api.FlowExecution, error) {
+		page, err := fetch(offset)
+		if err != nil {
+			return nil, err
+		}
+		return page.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]api.FlowExecution, 0, len(all))
+	for _, execution := range all {
+		if from != nil && execution.StartedAt.Before(*from) {
+			continue
+		}
+		if to != nil && !execution.StartedAt.Before(*to) {
+			continue
+		}
+		executions = append(executions, execution)
+	}
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].StartedAt.After(executions[j].StartedAt)
+	})
+	return executions, nil
+}
+
+// analyticsRuns fetches the node results of each execution, a bounded number
+// at a time, and counts how many nodes passed, failed, or were skipped
+func analyticsRuns(state *AppState, flowID uuid.UUID, executions []api.FlowExecution) ([]analyticsRun, error) {
+	runs := make([]analyticsRun, len(executions))
+	errs := make([]error, len(executions))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(defaultPageConcurrency, len(executions)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i], errs[i] = analyticsRunFor(state, flowID, executions[i])
+			}
+		}()
+	}
+	for i := range executions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node results for execution %s: %w", executions[i].Id, err)
+		}
+	}
+	return runs, nil
+}
+
+func analyticsRunFor(state *AppState, flowID uuid.UUID, execution api.FlowExecution) (analyticsRun, error) {
+	run := analyticsRun{
+		ID:          execution.Id.String(),
+		Status:      string(execution.Status),
+		StartedAt:   execution.StartedAt,
+		CompletedAt: execution.CompletedAt,
+		DurationMs:  executionDurationMs(execution),
+	}
+	if execution.TriggerType != nil {
+		run.TriggerType = string(*execution.TriggerType)
+	}
+	if execution.ErrorMessage != nil {
+		run.Error = *execution.ErrorMessage
+	}
+
+	resp, err := state.Client.API().GetExecutionNodeResultsWithResponse(state.Context(), flowID, execution.Id)
+	if err != nil {
+		return run, err
+	}
+	if resp.JSON200 == nil {
+		return run, formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	for _, result := range *resp.JSON200 {
+		switch {
+		case result.Status == api.NodeExecutionStatusSkipped:
+			run.Skipped++
+		case result.Status == api.NodeExecutionStatusFailed || (result.HasErrors != nil && *result.HasErrors):
+			run.Failed++
+		case result.Status == api.NodeExecutionStatusCompleted:
+			run.Passed++
+		}
+	}
+	return run, nil
+}
+
+// executionDurationMs is how long a finished execution took, or nil while it
+// is still in progress
+func executionDurationMs(execution api.FlowExecution) *int64 {
+	if execution.CompletedAt == nil {
+		return nil
+	}
+	ms := execution.CompletedAt.Sub(execution.StartedAt).Milliseconds()
+	return &ms
+}
+
+// summarizeExecutions aggregates executions into status counts, a success
+// rate over finished runs, and duration statistics
+func summarizeExecutions(executions []api.FlowExecution) analyticsSummary {
+	summary := analyticsSummary{
+		Runs:     len(executions),
+		ByStatus: make(map[string]int),
+	}
+
+	var finished, succeeded int
+	var durations []int64
+	for _, execution := range executions {
+		summary.ByStatus[string(execution.Status)]++
+
+		started := execution.StartedAt
+		if summary.FirstRun == nil || started.Before(*summary.FirstRun) {
+			summary.FirstRun = &started
+		}
+		if summary.LastRun == nil || started.After(*summary.LastRun) {
+			summary.LastRun = &started
+		}
+
+		switch execution.Status {
+		case api.ExecutionStatusCompleted:
+			succeeded++
+			finished++
+		case api.ExecutionStatusFailed, api.ExecutionStatusCancelled:
+			finished++
+		default:
+			continue
+		}
+		if ms := executionDurationMs(execution); ms != nil {
+			durations = append(durations, *ms)
+		}
+
+	}
+
+	if finished > 0 {
+		rate := float64(succeeded) / float64(finished)
+		summary.SuccessRate = &rate
+	}
+	if len(durations) > 0 {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		var total int64
+		for _, ms := range durations {
+			total += ms
+		}
+		avg := total / int64(len(durations))
+		p95 := durations[(len(durations)*95+99)/100-1]
+		summary.AvgDurationMs = &avg
+		summary.P95DurationMs = &p95
+	}
+	return summary
+}
+
+func printAnalyticsSummary(state *AppState, summary analyticsSummary) {
+	fmt.Fprintf(os.Stdout, "Flow: %s\n", summary.FlowID)
+	if summary.Since != nil || summary.Until != nil {
+		from, to := "beginning", "now"
+		if summary.Since != nil {
+			from = formatTime(state, *summary.Since)
+		}
+		if summary.Until != nil {
+			to = formatTime(state, *summary.Until)
+		}
+		fmt.Fprintf(os.Stdout, "Window: %s to %s\n", from, to)
+	}
+	fmt.Fprintf(os.Stdout, "Runs: %d\n", summary.Runs)
+
+	statuses := make([]string, 0, len(summary.ByStatus))
+	for status := range summary.ByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(os.Stdout, "  %s: %d\n", status, summary.ByStatus[status])
+	}
+
+	if summary.SuccessRate != nil {
+		fmt.Fprintf(os.Stdout, "Success rate: %.1f%%\n", *summary.SuccessRate*100)
+	} else {
+		fmt.Fprintln(os.Stdout, "Success rate: n/a (no finished runs)")
+	}
+	if summary.AvgDurationMs != nil {
+		fmt.Fprintf(os.Stdout, "Avg duration: %s\n", formatDurationMs(summary.AvgDurationMs))
+		fmt.Fprintf(os.Stdout, "P95 duration: %s\n", formatDurationMs(summary.P95DurationMs))
+	}
+	if summary.FirstRun != nil {
+		fmt.Fprintf(os.Stdout, "First run: %s\n", formatTime(state, *summary.FirstRun))
+		fmt.Fprintf(os.Stdout, "Last run: %s\n", formatTime(state, *summary.LastRun))
+	}
+}
+
+// formatDurationMs renders a millisecond duration for a table cell, or "-"
+// when it is unknown
+func formatDurationMs(ms *int64) string {
+	if ms == nil {
+		return "-"
+	}
+	return (time.Duration(*ms) * time.Millisecond).String()
+}
@@ -2,10 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	"echopoint-cli/internal/api"
 	"echopoint-cli/internal/auth"
+	"echopoint-cli/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -19,6 +23,7 @@ func newAuthCmd(state *AppState) *cobra.Command {
 	cmd.AddCommand(
 		newAuthLoginCmd(state),
 		newAuthStatusCmd(state),
+		newAuthWhoamiCmd(state),
 		newAuthLogoutCmd(state),
 		newAuthHelpCmd(state),
 	)
@@ -29,6 +34,8 @@ func newAuthCmd(state *AppState) *cobra.Command {
 func newAuthLoginCmd(state *AppState) *cobra.Command {
 	var debug bool
 	var local bool
+	var token string
+	var verify bool
 
 	cmd := &cobra.Command{
 		Use:   "login",
@@ -37,15 +44,19 @@ func newAuthLoginCmd(state *AppState) *cobra.Command {
 
 This uses the same authentication flow as the web frontend.
 A browser window will open where you can sign in, and the CLI
-will automatically receive your session token.`,
+will automatically receive your session token.
+
+Use --token to store a token obtained elsewhere (for example a CI
+secret) without opening a browser. Pass --token - to read it from stdin.`,
+		Example: `  echopoint auth login
+  echopoint auth login --token "$ECHOPOINT_SESSION" --verify
+  printenv ECHOPOINT_SESSION | echopoint auth login --token -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Determine frontend URL based on API URL or --local flag
-			frontendURL := "https://dev.echopoint.dev"
-			if local || state.Config.API.BaseURL == "http://localhost:8080" {
-				frontendURL = "http://localhost:3001"
+			if cmd.Flags().Changed("token") {
+				return loginWithToken(state, token, verify)
 			}
 
-			creds, err := auth.BrowserLogin(cmd.Context(), frontendURL, debug)
+			creds, err := auth.BrowserLogin(cmd.Context(), loginFrontendURL(state, local), debug)
 			if err != nil {
 				return err
 			}
@@ -63,16 +74,86 @@ will automatically receive your session token.`,
 
 	cmd.Flags().BoolVar(&debug, "debug", false, "Print debug information")
 	cmd.Flags().BoolVar(&local, "local", false, "Use localhost:3001 for authentication")
+	cmd.Flags().StringVar(&token, "token", "", "Store this session token instead of signing in via browser (- reads stdin)")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Check the token against the API before saving it")
 
 	return cmd
 }
 
+// loginFrontendURL returns the web frontend that browser login goes through,
+// based on the API URL or --local
+func loginFrontendURL(state *AppState, local bool) string {
+	if local || state.Config.API.BaseURL == "http://localhost:8080" {
+		return "http://localhost:3001"
+	}
+	return "https://dev.echopoint.dev"
+}
+
+// loginWithToken stores a token supplied directly, optionally checking it first
+func loginWithToken(state *AppState, token string, verify bool) error {
+	if token == stdinPath {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		token = string(data)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return fmt.Errorf("token is empty")
+	}
+
+	if verify {
+		if err := verifyToken(state, token); err != nil {
+			return err
+		}
+	}
+
+	creds := auth.Credentials{
+		AccessToken: token,
+		ExpiresAt:   auth.TokenExpiry(token),
+	}
+	if creds.ExpiresAt != nil && creds.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("token expired at %s", creds.ExpiresAt.Format(time.RFC3339))
+	}
+
+	path, err := auth.SaveCredentials(creds)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "✓ Token saved to %s\n", path)
+	if creds.ExpiresAt != nil {
+		fmt.Fprintf(os.Stdout, "Expires: %s\n", creds.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// verifyToken makes a minimal authenticated request to confirm the API accepts token
+func verifyToken(state *AppState, token string) error {
+	cli, err := state.newClient(token, 0)
+	if err != nil {
+		return err
+	}
+
+	params := &api.ListFlowsParams{Limit: 1}
+	resp, err := cli.API().ListFlowsWithResponse(state.Context(), params)
+	if err != nil {
+		return fmt.Errorf("failed to verify token: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return fmt.Errorf("token was rejected: %w", formatAPIError(resp.HTTPResponse, resp.Body))
+	}
+	return nil
+}
+
 func newAuthHelpCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
 		Use:   "help",
 		Short: "Show authentication instructions",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Fprintln(os.Stdout, `
+			fmt.Fprint(os.Stdout, `
 ┌─────────────────────────────────────────────────────────────────┐
 │ Echopoint CLI Authentication                                   │
 └─────────────────────────────────────────────────────────────────┘
@@ -93,6 +174,7 @@ Commands:
 
   echopoint auth login         Sign in with your email
   echopoint auth login -e X    Sign in with email X
+  echopoint auth login --token T  Store token T without a browser
   echopoint auth status        Check authentication status
   echopoint auth logout        Sign out and clear credentials
 
@@ -103,33 +185,128 @@ https://dev.echopoint.dev if you don't have one.
 	}
 }
 
+// authStatus describes the stored credentials
+type authStatus struct {
+	Authenticated bool       `json:"authenticated" yaml:"authenticated"`
+	Path          string     `json:"path" yaml:"path"`
+	ExpiresAt     *time.Time `json:"expires_at" yaml:"expires_at"`
+	Expired       bool       `json:"expired" yaml:"expired"`
+}
+
 func newAuthStatusCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
 		Short: "Show authentication status",
+		Long: `Show whether stored credentials exist and when they expire.
+
+Exits with an error when there are no credentials or they have expired, so
+scripts can log in only when needed:
+
+  echopoint auth status -o json || echopoint auth login`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			creds, path, err := auth.LoadCredentials()
 			if err != nil {
 				return err
 			}
 
-			if creds == nil {
-				fmt.Fprintln(os.Stdout, "No credentials found.")
-				fmt.Fprintf(os.Stdout, "Expected path: %s\n", path)
-				return nil
+			status := authStatus{Path: path}
+			if creds != nil {
+				status.ExpiresAt = creds.ExpiresAt
+				if status.ExpiresAt == nil {
+					status.ExpiresAt = auth.TokenExpiry(creds.AccessToken)
+				}
+				status.Expired = status.ExpiresAt != nil && time.Now().After(*status.ExpiresAt)
+				status.Authenticated = !status.Expired
 			}
 
-			fmt.Fprintf(os.Stdout, "Credentials: %s\n", path)
-			if creds.ExpiresAt != nil {
-				fmt.Fprintf(os.Stdout, "Expires: %s\n", creds.ExpiresAt.Format(time.RFC3339))
-			} else {
-				fmt.Fprintln(os.Stdout, "Expires: unknown")
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, status)
+			case output.FormatYAML:
+				err = printYAML(state, status)
+			default:
+				printAuthStatus(status, creds != nil)
+			}
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			switch {
+			case creds == nil:
+				return fmt.Errorf("not logged in; run: echopoint auth login")
+			case status.Expired:
+				return fmt.Errorf("credentials expired; run: echopoint auth login")
 			}
 			return nil
 		},
 	}
 }
 
+func printAuthStatus(status authStatus, found bool) {
+	if !found {
+		fmt.Fprintln(os.Stdout, "No credentials found.")
+		fmt.Fprintf(os.Stdout, "Expected path: %s\n", status.Path)
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, "Credentials: %s\n", status.Path)
+	switch {
+	case status.ExpiresAt == nil:
+		fmt.Fprintln(os.Stdout, "Expires: unknown")
+	case status.Expired:
+		fmt.Fprintf(os.Stdout, "Expired: %s\n", status.ExpiresAt.Format(time.RFC3339))
+	default:
+		fmt.Fprintf(os.Stdout, "Expires: %s\n", status.ExpiresAt.Format(time.RFC3339))
+	}
+}
+
+// whoami identifies the user behind the session token
+type whoami struct {
+	UserID    string     `json:"user_id" yaml:"user_id"`
+	ExpiresAt *time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+func newAuthWhoamiCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the user ID of the current session",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			userID, err := currentUserID(state)
+			if err != nil {
+				return err
+			}
+			info := whoami{UserID: userID, ExpiresAt: auth.TokenExpiry(state.Token)}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, info)
+			case output.FormatYAML:
+				return printYAML(state, info)
+			default:
+				fmt.Fprintf(os.Stdout, "User: %s\n", info.UserID)
+				if info.ExpiresAt != nil {
+					fmt.Fprintf(os.Stdout, "Expires: %s\n", formatTime(state, *info.ExpiresAt))
+				}
+				return nil
+			}
+		},
+	}
+}
+
+// currentUserID returns the user ID (the JWT subject) of the session token
+func currentUserID(state *AppState) (string, error) {
+	claims, ok := auth.ParseTokenClaims(state.Token)
+	if !ok || claims.Subject == "" {
+		return "", fmt.Errorf("cannot determine the current user from the session token; run 'echopoint auth login'")
+	}
+	return claims.Subject, nil
+}
+
 func newAuthLogoutCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
 		Use:   "logout",
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"echopoint-cli/internal/cache"
+
+	"github.com/spf13/cobra"
+)
+
+func newCacheCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local response cache",
+	}
+
+	cmd.AddCommand(
+		newCacheClearCmd(state),
+	)
+
+	return cmd
+}
+
+func newCacheClearCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached responses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := cache.Clear()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Cleared cache at %s\n", dir)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newCollectionRequestsCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "requests",
+		Short: "Manage requests in a collection",
+	}
+
+	cmd.AddCommand(
+		newCollectionRequestsAddCmd(state),
+		newCollectionRequestsDeleteCmd(state),
+	)
+
+	return cmd
+}
+
+func newCollectionRequestsAddCmd(state *AppState) *cobra.Command {
+	var name, method, url, folder, description, headers, body string
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "add <collection-id>",
+		Short: "Add a request to a collection",
+		Example: `  echopoint collections requests add <collection-id> --name "List users" --method GET --url "https://api.example.com/users"
+  echopoint collections requests add <collection-id> --name "Create user" --method POST --url "https://api.example.com/users" \
+    --folder <folder-id> --headers '{"Content-Type":"application/json"}' --body '{"name":"Jane"}'`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCollectionIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			arg, err := resolveCollectionID(state, args[0])
+			if err != nil {
+				return err
+			}
+			collectionID, err := uuid.Parse(arg)
+			if err != nil {
+				return fmt.Errorf("invalid collection id")
+			}
+
+			if err := validateMethod(method); err != nil {
+				return err
+			}
+			method = strings.ToUpper(method)
+
+			req := api.CreateRequestRequest{
+				Name:   name,
+				Method: api.HTTPMethod(method),
+				Url:    url,
+			}
+			if folder != "" {
+				folderID, err := uuid.Parse(folder)
+				if err != nil {
+					return fmt.Errorf("invalid folder id")
+				}
+				req.FolderId = &folderID
+			}
+			if description != "" {
+				req.Description = &description
+			}
+			if headers != "" {
+				var parsed map[string]string
+				if err := json.Unmarshal([]byte(headers), &parsed); err != nil {
+					return fmt.Errorf("invalid --headers JSON: %w", err)
+				}
+				req.Headers = &parsed
+			}
+			if body != "" {
+				var parsed map[string]interface{}
+				if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+					return fmt.Errorf("invalid --body JSON object: %w", err)
+				}
+				req.Body = &parsed
+			}
+			if timeout > 0 {
+				req.Timeout = &timeout
+			}
+
+			resp, err := state.Client.API().AddRequestWithResponse(state.Context(), collectionID, req)
+			if err != nil {
+				return err
+			}
+			if resp.JSON201 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, resp.JSON201)
+			case output.FormatYAML:
+				return printYAML(state, resp.JSON201)
+			default:
+				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Id)
+				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON201.Name)
+				fmt.Fprintf(os.Stdout, "Request: %s %s\n", resp.JSON201.Method, resp.JSON201.Url)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Request name")
+	cmd.Flags().StringVar(&method, "method", "GET", "HTTP method")
+	cmd.Flags().StringVar(&url, "url", "", "Request URL")
+	cmd.Flags().StringVar(&folder, "folder", "", "Parent folder ID (defaults to the collection root)")
+	cmd.Flags().StringVar(&description, "description", "", "Request description")
+	cmd.Flags().StringVar(&headers, "headers", "", "HTTP headers as JSON")
+	cmd.Flags().StringVar(&body, "body", "", "Request body as a JSON object")
+	cmd.Flags().IntVar(&timeout, "timeout", 0, "Request timeout in milliseconds")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func newCollectionRequestsDeleteCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete <collection-id> <request-id>",
+		Short:             "Delete a request from a collection",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeCollectionIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			arg, err := resolveCollectionID(state, args[0])
+			if err != nil {
+				return err
+			}
+			collectionID, err := uuid.Parse(arg)
+			if err != nil {
+				return fmt.Errorf("invalid collection id")
+			}
+			requestID, err := uuid.Parse(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid request id")
+			}
+
+			resp, err := state.Client.API().DeleteRequestWithResponse(state.Context(), collectionID, requestID)
+			if err != nil {
+				return err
+			}
+			if resp.HTTPResponse.StatusCode != http.StatusNoContent {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			fmt.Fprintln(os.Stdout, "Request deleted.")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,12 +1,16 @@
 package commands
 
 import (
-	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/filter"
 	"echopoint-cli/internal/output"
 
 	"github.com/google/uuid"
@@ -26,6 +30,7 @@ func newCollectionsCmd(state *AppState) *cobra.Command {
 		newCollectionsUpdateCmd(state),
 		newCollectionsDeleteCmd(state),
 		newCollectionsImportCmd(state),
+		newCollectionRequestsCmd(state),
 	)
 
 	return cmd
@@ -34,21 +39,36 @@ func newCollectionsCmd(state *AppState) *cobra.Command {
 func newCollectionsListCmd(state *AppState) *cobra.Command {
 	var limit int32 = 20
 	var offset int32
+	var filters []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List collections",
+		Long: `List collections.
+
+--filter narrows the fetched page on the client. Each filter is FIELD OP VALUE
+with = (equals), ~ (contains), > and < (times and numbers), as in 'echopoint
+flows list --help'.
+
+Fields: id, name, description, source (text), created, updated (time),
+requests (number).`,
+		Annotations: map[string]string{jsonlAnnotation: ""},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
+			match, err := filter.Compile(filters, collectionFilterFields)
+			if err != nil {
+				return err
+			}
+
 			params := &api.ListCollectionsParams{
 				Limit:  api.LimitParameter(limit),
 				Offset: api.OffsetParameter(offset),
 			}
 
-			resp, err := state.Client.API().ListCollectionsWithResponse(context.Background(), params)
+			resp, err := state.Client.API().ListCollectionsWithResponse(state.Context(), params)
 			if err != nil {
 				return err
 			}
@@ -57,47 +77,116 @@ func newCollectionsListCmd(state *AppState) *cobra.Command {
 				return formatAPIError(resp.HTTPResponse, resp.Body)
 			}
 
+			list := resp.JSON200
+			fetched := len(list.Items)
+			if len(filters) > 0 {
+				items := make([]api.Collection, 0, len(list.Items))
+				for _, collection := range list.Items {
+					if match(collection) {
+						items = append(items, collection)
+					}
+				}
+				list = &api.CollectionListResponse{Count: len(items), Items: items, Total: list.Total}
+			}
+
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, list)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, list)
+			case output.FormatJSONL:
+				return printJSONL(state, list.Items)
 			default:
-				rows := make([][]string, 0, len(resp.JSON200.Items))
-				for _, collection := range resp.JSON200.Items {
-					rows = append(
-						rows,
-						[]string{collection.Id.String(), collection.Name, collection.UpdatedAt.String()},
+				columns := output.Columns("ID", "Name", "Updated")
+				if state.Wide {
+					columns = append(columns,
+						output.Column{Header: "Created"},
+						output.Column{Header: "Source"},
+						output.Column{Header: "Requests", Align: output.AlignRight},
+						output.Column{Header: "Description"},
 					)
 				}
-				fmt.Fprintf(os.Stdout, "Total: %d\n", resp.JSON200.Total)
-				return output.PrintTable([]string{"ID", "Name", "Updated"}, rows)
+				rows := make([][]string, 0, len(list.Items))
+				for _, collection := range list.Items {
+					row := []string{collection.Id.String(), collection.Name, formatTime(state, collection.UpdatedAt)}
+					if state.Wide {
+						row = append(row,
+							formatTime(state, collection.CreatedAt),
+							string(collection.Source),
+							strconv.Itoa(len(collection.Requests)),
+							stringValue(collection.Description),
+						)
+					}
+					rows = append(rows, row)
+				}
+				compactIDColumn(state, rows, 0)
+				printListTotal(list.Total, len(list.Items), fetched, len(filters) > 0)
+				return printTable(state, columns, rows)
 			}
 		},
 	}
 
 	cmd.Flags().Int32Var(&limit, "limit", 20, "Number of results to return")
 	cmd.Flags().Int32Var(&offset, "offset", 0, "Offset for pagination")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, filterFlagUsage)
 
 	return cmd
 }
 
+// fetchAllCollections retrieves every collection, fetching pages concurrently
+func fetchAllCollections(state *AppState) ([]api.Collection, error) {
+	const pageSize = 100
+
+	fetch := func(offset int32) (*api.CollectionListResponse, error) {
+		resp, err := state.Client.API().ListCollectionsWithResponse(state.Context(), &api.ListCollectionsParams{
+			Limit:  api.LimitParameter(pageSize),
+			Offset: api.OffsetParameter(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.JSON200 == nil {
+			return nil, formatAPIError(resp.HTTPResponse, resp.Body)
+		}
+		return resp.JSON200, nil
+	}
+
+	first, err := fetch(0)
+	if err != nil {
+		return nil, err
+	}
+	return fetchRemainingPages(first.Items, first.Total, pageSize, defaultPageConcurrency, func(offset int32) ([]api.Collection, error) {
+		page, err := fetch(offset)
+		if err != nil {
+			return nil, err
+		}
+		return page.Items, nil
+	})
+}
+
 func newCollectionsGetCmd(state *AppState) *cobra.Command {
+	var summary bool
+
 	cmd := &cobra.Command{
-		Use:   "get <id>",
-		Short: "Get collection details",
-		Args:  cobra.ExactArgs(1),
+		Use:               "get <id>",
+		Short:             "Get collection details",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCollectionIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			id, err := uuid.Parse(args[0])
+			arg, err := resolveCollectionID(state, args[0])
+			if err != nil {
+				return err
+			}
+			id, err := uuid.Parse(arg)
 			if err != nil {
 				return fmt.Errorf("invalid collection id")
 			}
 
-			resp, err := state.Client.API().GetCollectionWithResponse(context.Background(), id)
+			resp, err := state.Client.API().GetCollectionWithResponse(state.Context(), id)
 			if err != nil {
 				return err
 			}
@@ -108,22 +197,92 @@ func newCollectionsGetCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, resp.JSON200)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, resp.JSON200)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON200.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON200.Name)
-				fmt.Fprintf(os.Stdout, "Updated: %s\n", resp.JSON200.UpdatedAt)
-				fmt.Fprintf(os.Stdout, "Created: %s\n", resp.JSON200.CreatedAt)
+				fmt.Fprintf(os.Stdout, "Updated: %s\n", formatTime(state, resp.JSON200.UpdatedAt))
+				fmt.Fprintf(os.Stdout, "Created: %s\n", formatTime(state, resp.JSON200.CreatedAt))
+				if summary {
+					printCollectionSummary(os.Stdout, resp.JSON200)
+				}
 				return nil
 			}
 		},
 	}
 
+	cmd.Flags().BoolVar(&summary, "summary", false, "Show folder and request counts with a folder tree")
 	return cmd
 }
 
+// printCollectionSummary prints folder and request counts and the folder tree.
+// Folders whose parent is not in the collection are shown at the top level, so
+// the tree lists every folder the count includes.
+func printCollectionSummary(w io.Writer, collection *api.Collection) {
+	fmt.Fprintf(w, "Folders: %d\n", len(collection.Folders))
+	fmt.Fprintf(w, "Requests: %d\n", len(collection.Requests))
+
+	known := make(map[uuid.UUID]bool, len(collection.Folders))
+	for _, folder := range collection.Folders {
+		known[folder.Id] = true
+	}
+
+	children := make(map[uuid.UUID][]api.CollectionFolder)
+	var roots []api.CollectionFolder
+	for _, folder := range collection.Folders {
+		if folder.ParentId == nil || !known[*folder.ParentId] {
+			roots = append(roots, folder)
+		} else {
+			children[*folder.ParentId] = append(children[*folder.ParentId], folder)
+		}
+	}
+
+	requestCounts := make(map[uuid.UUID]int)
+	rootRequests := 0
+	for _, request := range collection.Requests {
+		if request.FolderId == nil {
+			rootRequests++
+		} else {
+			requestCounts[*request.FolderId]++
+		}
+	}
+
+	if len(collection.Folders) == 0 && rootRequests == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nTree:")
+	if rootRequests > 0 {
+		fmt.Fprintf(w, "  (root) %s\n", pluralize(rootRequests, "request"))
+	}
+
+	visited := make(map[uuid.UUID]bool)
+	var walk func(folders []api.CollectionFolder, depth int)
+	walk = func(folders []api.CollectionFolder, depth int) {
+		sort.Slice(folders, func(i, j int) bool { return folders[i].Name < folders[j].Name })
+		for _, folder := range folders {
+			if visited[folder.Id] {
+				continue
+			}
+			visited[folder.Id] = true
+			fmt.Fprintf(w, "%s%s/ (%s)\n", strings.Repeat("  ", depth+1), folder.Name, pluralize(requestCounts[folder.Id], "request"))
+			walk(children[folder.Id], depth+1)
+		}
+	}
+	walk(roots, 0)
+	// Folders that only parent each other are never reached from a root
+	walk(append([]api.CollectionFolder(nil), collection.Folders...), 0)
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 func newCollectionsCreateCmd(state *AppState) *cobra.Command {
 	var name string
 	var description string
@@ -151,7 +310,7 @@ func newCollectionsCreateCmd(state *AppState) *cobra.Command {
 				req.Source = &value
 			}
 
-			resp, err := state.Client.API().CreateCollectionWithResponse(context.Background(), req)
+			resp, err := state.Client.API().CreateCollectionWithResponse(state.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -161,9 +320,9 @@ func newCollectionsCreateCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON201)
+				return printJSON(state, resp.JSON201)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON201)
+				return printYAML(state, resp.JSON201)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON201.Name)
@@ -184,15 +343,20 @@ func newCollectionsUpdateCmd(state *AppState) *cobra.Command {
 	var description string
 
 	cmd := &cobra.Command{
-		Use:   "update <id>",
-		Short: "Update a collection",
-		Args:  cobra.ExactArgs(1),
+		Use:               "update <id>",
+		Short:             "Update a collection",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCollectionIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			id, err := uuid.Parse(args[0])
+			arg, err := resolveCollectionID(state, args[0])
+			if err != nil {
+				return err
+			}
+			id, err := uuid.Parse(arg)
 			if err != nil {
 				return fmt.Errorf("invalid collection id")
 			}
@@ -205,7 +369,7 @@ func newCollectionsUpdateCmd(state *AppState) *cobra.Command {
 				req.Description = &description
 			}
 
-			resp, err := state.Client.API().UpdateCollectionWithResponse(context.Background(), id, req)
+			resp, err := state.Client.API().UpdateCollectionWithResponse(state.Context(), id, req)
 			if err != nil {
 				return err
 			}
@@ -215,9 +379,9 @@ func newCollectionsUpdateCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, resp.JSON200)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, resp.JSON200)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON200.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON200.Name)
@@ -233,20 +397,25 @@ func newCollectionsUpdateCmd(state *AppState) *cobra.Command {
 
 func newCollectionsDeleteCmd(state *AppState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete <id>",
-		Short: "Delete a collection",
-		Args:  cobra.ExactArgs(1),
+		Use:               "delete <id>",
+		Short:             "Delete a collection",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCollectionIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			id, err := uuid.Parse(args[0])
+			arg, err := resolveCollectionID(state, args[0])
+			if err != nil {
+				return err
+			}
+			id, err := uuid.Parse(arg)
 			if err != nil {
 				return fmt.Errorf("invalid collection id")
 			}
 
-			resp, err := state.Client.API().DeleteCollectionWithResponse(context.Background(), id)
+			resp, err := state.Client.API().DeleteCollectionWithResponse(state.Context(), id)
 			if err != nil {
 				return err
 			}
@@ -296,7 +465,7 @@ func newCollectionsImportCmd(state *AppState) *cobra.Command {
 				req.Options = opts
 			}
 
-			resp, err := state.Client.API().ImportFromOpenAPIWithResponse(context.Background(), req)
+			resp, err := state.Client.API().ImportFromOpenAPIWithResponse(state.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -306,9 +475,9 @@ func newCollectionsImportCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON201)
+				return printJSON(state, resp.JSON201)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON201)
+				return printYAML(state, resp.JSON201)
 			default:
 				fmt.Fprintf(os.Stdout, "Collection imported: %s\n", resp.JSON201.Collection.Name)
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Collection.Id)
@@ -319,7 +488,7 @@ func newCollectionsImportCmd(state *AppState) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&file, "file", "", "Path to OpenAPI spec (JSON or YAML)")
+	cmd.Flags().StringVar(&file, "file", "", "Path to OpenAPI spec (JSON or YAML), or - for stdin")
 	cmd.Flags().StringVar(&name, "name", "", "Collection name (defaults to API title)")
 	cmd.Flags().BoolVar(&tagsAsFolders, "tags-as-folders", true, "Use OpenAPI tags as folder structure")
 	_ = cmd.MarkFlagRequired("file")
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/google/uuid"
+)
+
+func TestPrintCollectionSummary(t *testing.T) {
+	folder := func(name string, parent *uuid.UUID) api.CollectionFolder {
+		return api.CollectionFolder{Id: uuid.New(), Name: name, ParentId: parent}
+	}
+	users := folder("users", nil)
+	admin := folder("admin", &users.Id)
+	missing := uuid.New()
+	orphan := folder("orphan", &missing)
+	loopA := folder("loop-a", nil)
+	loopB := folder("loop-b", &loopA.Id)
+	loopA.ParentId = &loopB.Id
+
+	collection := &api.Collection{
+		Folders: []api.CollectionFolder{admin, orphan, users, loopB, loopA},
+		Requests: []api.CollectionRequest{
+			{FolderId: nil},
+			{FolderId: &admin.Id},
+			{FolderId: &orphan.Id},
+			{FolderId: &orphan.Id},
+		},
+	}
+
+	var out bytes.Buffer
+	printCollectionSummary(&out, collection)
+
+	want := `Folders: 5
+Requests: 4
+
+Tree:
+  (root) 1 request
+  orphan/ (2 requests)
+  users/ (0 requests)
+    admin/ (1 request)
+  loop-a/ (0 requests)
+    loop-b/ (0 requests)
+`
+	if out.String() != want {
+		t.Errorf("summary =\n%s\nwant\n%s", out.String(), want)
+	}
+}
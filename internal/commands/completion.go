@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"echopoint-cli/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+// completionLimit bounds how many items are offered during shell completion
+const completionLimit = 100
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeFlowIDs offers flow IDs (annotated with names) for the first argument
+func completeFlowIDs(state *AppState) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 || !prepareCompletion(state, cmd) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		params := &api.ListFlowsParams{Limit: completionLimit}
+		resp, err := state.Client.API().ListFlowsWithResponse(state.Context(), params)
+		if err != nil || resp.JSON200 == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := make([]string, 0, len(resp.JSON200.Items))
+		for _, flow := range resp.JSON200.Items {
+			completions = append(completions, flow.Id.String()+"\t"+flow.Name)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeCollectionIDs offers collection IDs (annotated with names) for the first argument
+func completeCollectionIDs(state *AppState) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 || !prepareCompletion(state, cmd) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		params := &api.ListCollectionsParams{Limit: completionLimit}
+		resp, err := state.Client.API().ListCollectionsWithResponse(state.Context(), params)
+		if err != nil || resp.JSON200 == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := make([]string, 0, len(resp.JSON200.Items))
+		for _, collection := range resp.JSON200.Items {
+			completions = append(completions, collection.Id.String()+"\t"+collection.Name)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// prepareCompletion initializes state for completion, which skips the pre-run hooks
+func prepareCompletion(state *AppState, cmd *cobra.Command) bool {
+	if err := state.prepare(cmd); err != nil {
+		return false
+	}
+	return state.Token != ""
+}
@@ -3,7 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	"echopoint-cli/internal/config"
 	"echopoint-cli/internal/output"
@@ -19,6 +19,7 @@ func newConfigCmd(state *AppState) *cobra.Command {
 
 	cmd.AddCommand(
 		newConfigShowCmd(state),
+		newConfigGetCmd(state),
 		newConfigSetCmd(state),
 		newConfigResetCmd(state),
 	)
@@ -27,31 +28,85 @@ func newConfigCmd(state *AppState) *cobra.Command {
 }
 
 func newConfigShowCmd(state *AppState) *cobra.Command {
-	return &cobra.Command{
+	var effective bool
+
+	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show current configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if effective {
+				return showEffectiveConfig(state)
+			}
+
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, state.Config)
+				return printJSON(state, state.Config)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, state.Config)
+				return printYAML(state, state.Config)
 			default:
 				fmt.Fprintf(os.Stdout, "Config path: %s\n", state.ConfigPath)
 				fmt.Fprintf(os.Stdout, "API base URL: %s\n", state.Config.API.BaseURL)
 				fmt.Fprintf(os.Stdout, "API timeout: %s\n", state.Config.API.Timeout)
 				fmt.Fprintf(os.Stdout, "Output format: %s\n", state.Config.Defaults.OutputFormat)
+				fmt.Fprintf(os.Stdout, "Cache TTL: %s\n", state.Config.Cache.TTL)
 				return nil
 			}
 		},
 	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "Show each resolved value with its source (default, file, env, flag)")
+
+	return cmd
+}
+
+type effectiveSetting struct {
+	Key    string        `json:"key" yaml:"key"`
+	Value  string        `json:"value" yaml:"value"`
+	Source config.Source `json:"source" yaml:"source"`
+}
+
+// showEffectiveConfig prints every config key with the layer that set it
+func showEffectiveConfig(state *AppState) error {
+	settings := []effectiveSetting{{
+		Key:    "config_path",
+		Value:  state.ConfigPath,
+		Source: state.configPathSource,
+	}}
+	for _, key := range config.Keys() {
+		value, err := state.Config.Get(key)
+		if err != nil {
+			return err
+		}
+		settings = append(settings, effectiveSetting{
+			Key:    key,
+			Value:  value,
+			Source: state.Config.Source(key),
+		})
+	}
+
+	switch state.OutputFormat {
+	case output.FormatJSON:
+		return printJSON(state, settings)
+	case output.FormatYAML:
+		return printYAML(state, settings)
+	default:
+		rows := make([][]string, 0, len(settings))
+		for _, setting := range settings {
+			rows = append(rows, []string{setting.Key, setting.Value, string(setting.Source)})
+		}
+		return printTable(state, output.Columns("Key", "Value", "Source"), rows)
+	}
 }
 
 func newConfigSetCmd(state *AppState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Update a configuration value",
-		Args:  cobra.ExactArgs(2),
+		Long: `Update a configuration value.
+
+Valid keys: ` + strings.Join(config.Keys(), ", "),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigKeys,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := args[0]
 			value := args[1]
@@ -61,19 +116,8 @@ func newConfigSetCmd(state *AppState) *cobra.Command {
 				return err
 			}
 
-			switch key {
-			case "api.base_url":
-				cfg.API.BaseURL = value
-			case "api.timeout":
-				timeout, err := time.ParseDuration(value)
-				if err != nil {
-					return fmt.Errorf("invalid timeout value")
-				}
-				cfg.API.Timeout = timeout
-			case "defaults.output_format":
-				cfg.Defaults.OutputFormat = value
-			default:
-				return fmt.Errorf("unknown config key: %s", key)
+			if err := cfg.Set(key, value); err != nil {
+				return err
 			}
 
 			path, err := config.Save(cfg)
@@ -89,6 +133,30 @@ func newConfigSetCmd(state *AppState) *cobra.Command {
 	return cmd
 }
 
+func newConfigGetCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print a configuration value",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := state.Config.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stdout, value)
+			return nil
+		},
+	}
+}
+
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.Keys(), cobra.ShellCompDirectiveNoFileComp
+}
+
 func newConfigResetCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
 		Use:   "reset",
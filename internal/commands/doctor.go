@@ -0,0 +1,277 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+
+	"echopoint-cli/internal/auth"
+	"echopoint-cli/internal/config"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// doctorTimeout bounds each network check
+	doctorTimeout = 10 * time.Second
+
+	// maxClockSkew is how far the local clock may drift from the server's
+	// before session tokens risk being treated as expired or not yet valid
+	maxClockSkew = time.Minute
+)
+
+// Check results, from best to worst
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorCheck is the outcome of one diagnostic
+type doctorCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+func newDoctorCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common setup problems",
+		Long: `Check the setup the CLI depends on: the config file, stored credentials,
+connectivity to the API, clock skew against the server, and whether the login
+page is reachable. Each check passes, warns, or fails with a hint on how to
+fix it. Exits with an error when any check fails.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flagToken, _ := cmd.Flags().GetString("token")
+
+			checks := []doctorCheck{checkConfig(state), checkCredentials(flagToken)}
+			checks = append(checks, checkAPI(state, flagToken)...)
+			checks = append(checks, checkLoginPage(state))
+
+			var err error
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, checks)
+			case output.FormatYAML:
+				err = printYAML(state, checks)
+			default:
+				printDoctorChecks(checks)
+			}
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, check := range checks {
+				if check.Status == checkFail {
+					failed++
+				}
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+			return nil
+		},
+	}
+}
+
+// isDoctorCommand reports whether cmd is the top-level doctor command
+func isDoctorCommand(cmd *cobra.Command) bool {
+	return cmd.Name() == "doctor" && cmd.HasParent() && !cmd.Parent().HasParent()
+}
+
+func checkConfig(state *AppState) doctorCheck {
+	check := doctorCheck{Name: "config"}
+
+	_, err := os.Stat(state.ConfigPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		check.Status = checkPass
+		check.Message = fmt.Sprintf("no config file at %s; using defaults", state.ConfigPath)
+		return check
+	case err != nil:
+		check.Status = checkFail
+		check.Message = err.Error()
+		return check
+	}
+
+	var parseErr *config.ParseError
+	if _, _, err := config.LoadFrom(state.ConfigPath); errors.As(err, &parseErr) {
+		check.Status = checkFail
+		check.Message = parseErr.Err.Error()
+		check.Hint = fmt.Sprintf("fix %s by hand or run: echopoint config reset", state.ConfigPath)
+		return check
+	} else if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = state.ConfigPath
+	return check
+}
+
+func checkCredentials(flagToken string) doctorCheck {
+	check := doctorCheck{Name: "credentials"}
+
+	switch {
+	case flagToken != "":
+		check.Status = checkPass
+		check.Message = "using --token"
+		return check
+	case os.Getenv("ECHOPOINT_TOKEN") != "":
+		check.Status = checkPass
+		check.Message = "using ECHOPOINT_TOKEN"
+		return check
+	}
+
+	creds, path, err := auth.LoadCredentials()
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		check.Hint = "run: echopoint auth login"
+		return check
+	}
+	if creds == nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("no credentials at %s", path)
+		check.Hint = "run: echopoint auth login"
+		return check
+	}
+
+	expiresAt := creds.ExpiresAt
+	if expiresAt == nil {
+		expiresAt = auth.TokenExpiry(creds.AccessToken)
+	}
+	switch {
+	case expiresAt == nil:
+		check.Status = checkWarn
+		check.Message = "stored token has no known expiry"
+	case time.Now().After(*expiresAt):
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("expired at %s", expiresAt.Format(time.RFC3339))
+		check.Hint = "run: echopoint auth login"
+	default:
+		check.Status = checkPass
+		check.Message = fmt.Sprintf("valid until %s", expiresAt.Format(time.RFC3339))
+	}
+	return check
+}
+
+// checkAPI calls the health endpoint and compares the server's clock with ours
+func checkAPI(state *AppState, flagToken string) []doctorCheck {
+	api := doctorCheck{Name: "api"}
+	clock := doctorCheck{Name: "clock"}
+
+	// Build a client directly so an invalid base URL is reported here
+	token, _ := resolveToken(flagToken)
+	cli, err := state.newClient(token, 0)
+	if err != nil {
+		api.Status = checkFail
+		api.Message = err.Error()
+		api.Hint = "set a valid URL with: echopoint config set api.base_url https://..."
+		return []doctorCheck{api}
+	}
+
+	ctx, cancel := context.WithTimeout(state.Context(), doctorTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := cli.API().HealthCheckWithResponse(ctx)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		api.Status = checkFail
+		api.Message = fmt.Sprintf("%s unreachable: %v", cli.BaseURL(), err)
+		api.Hint = "check your network connection and api.base_url (echopoint config show --effective)"
+		return []doctorCheck{api}
+	}
+	if resp.StatusCode() != http.StatusOK {
+		api.Status = checkFail
+		api.Message = fmt.Sprintf("%s answered %s", cli.BaseURL(), resp.Status())
+		api.Hint = "the server may be down, or api.base_url may not point at the Echopoint API"
+		return []doctorCheck{api}
+	}
+	api.Status = checkPass
+	api.Message = fmt.Sprintf("%s reachable in %s", cli.BaseURL(), elapsed)
+
+	serverTime, err := http.ParseTime(resp.HTTPResponse.Header.Get("Date"))
+	if err != nil {
+		clock.Status = checkWarn
+		clock.Message = "server did not report its time"
+		return []doctorCheck{api, clock}
+	}
+	// The Date header has one-second precision and was set mid-request
+	skew := time.Since(serverTime) - elapsed/2
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxClockSkew {
+		clock.Status = checkWarn
+		clock.Message = fmt.Sprintf("local clock is %s off from the server", skew.Round(time.Second))
+		clock.Hint = "sync your system clock; tokens may appear expired or not yet valid"
+	} else {
+		clock.Status = checkPass
+		clock.Message = fmt.Sprintf("within %s of the server", maxClockSkew)
+	}
+	return []doctorCheck{api, clock}
+}
+
+func checkLoginPage(state *AppState) doctorCheck {
+	check := doctorCheck{Name: "login"}
+	frontendURL := loginFrontendURL(state, false)
+
+	ctx, cancel := context.WithTimeout(state.Context(), doctorTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, frontendURL, nil)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		return check
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// Token login still works without the frontend
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("%s unreachable: %v", frontendURL, err)
+		check.Hint = "browser login will fail; use: echopoint auth login --token"
+		return check
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("%s answered %s", frontendURL, resp.Status)
+		check.Hint = "browser login may fail; use: echopoint auth login --token"
+		return check
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("%s reachable", frontendURL)
+	return check
+}
+
+func printDoctorChecks(checks []doctorCheck) {
+	for _, check := range checks {
+		symbol := "✓"
+		switch check.Status {
+		case checkWarn:
+			symbol = "!"
+		case checkFail:
+			symbol = "✗"
+		}
+		fmt.Fprintf(os.Stdout, "%s %-12s %s\n", symbol, check.Name, check.Message)
+		if check.Hint != "" {
+			fmt.Fprintf(os.Stdout, "  %-12s → %s\n", "", check.Hint)
+		}
+	}
+}
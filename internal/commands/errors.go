@@ -2,17 +2,52 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/client"
 )
 
+// exitRateLimited is the exit status for commands that gave up because the
+// API kept rate limiting them (EX_TEMPFAIL), so scripts can retry later
+const exitRateLimited = 75
+
+// RateLimitError reports a request that was still rate limited after the
+// client's retries
+type RateLimitError struct {
+	// RetryAfter is how long the server asked to wait, when it said
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limit exceeded, try again in %s", e.RetryAfter.Round(time.Second))
+	}
+	return "rate limit exceeded, try again later"
+}
+
+// ExitCode returns the process exit status for an error returned by a command
+func ExitCode(err error) int {
+	var rateLimited *RateLimitError
+	if errors.As(err, &rateLimited) {
+		return exitRateLimited
+	}
+	return 1
+}
+
 func formatAPIError(resp *http.Response, body []byte) error {
 	if resp == nil {
 		return fmt.Errorf("request failed")
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait, _ := client.RetryAfter(resp.Header)
+		return &RateLimitError{RetryAfter: wait}
+	}
+
 	var apiErr api.ApiErrorResponse
 	if err := json.Unmarshal(body, &apiErr); err == nil {
 		if len(apiErr.Errors) > 0 {
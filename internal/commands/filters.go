@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/filter"
+)
+
+// filterFlagUsage is the help text for --filter on list commands
+const filterFlagUsage = "Filter results client-side, e.g. name~checkout or updated>2024-01-01 (repeatable; all must match)"
+
+// flowFilterFields are the fields --filter accepts on flows list
+var flowFilterFields = map[string]filter.Field[api.Flow]{
+	"id":          {Kind: filter.KindString, String: func(f api.Flow) string { return f.Id.String() }},
+	"name":        {Kind: filter.KindString, String: func(f api.Flow) string { return f.Name }},
+	"description": {Kind: filter.KindString, String: func(f api.Flow) string { return stringValue(f.Description) }},
+	"created":     {Kind: filter.KindTime, Time: func(f api.Flow) time.Time { return f.CreatedAt }},
+	"updated":     {Kind: filter.KindTime, Time: func(f api.Flow) time.Time { return f.UpdatedAt }},
+	"nodes":       {Kind: filter.KindNumber, Number: func(f api.Flow) float64 { return float64(len(f.FlowDefinition.Nodes)) }},
+	"tags":        {Kind: filter.KindString, String: func(f api.Flow) string { return strings.Join(flowTags(&f), ",") }},
+}
+
+// collectionFilterFields are the fields --filter accepts on collections list
+var collectionFilterFields = map[string]filter.Field[api.Collection]{
+	"id":          {Kind: filter.KindString, String: func(c api.Collection) string { return c.Id.String() }},
+	"name":        {Kind: filter.KindString, String: func(c api.Collection) string { return c.Name }},
+	"description": {Kind: filter.KindString, String: func(c api.Collection) string { return stringValue(c.Description) }},
+	"source":      {Kind: filter.KindString, String: func(c api.Collection) string { return string(c.Source) }},
+	"created":     {Kind: filter.KindTime, Time: func(c api.Collection) time.Time { return c.CreatedAt }},
+	"updated":     {Kind: filter.KindTime, Time: func(c api.Collection) time.Time { return c.UpdatedAt }},
+	"requests":    {Kind: filter.KindNumber, Number: func(c api.Collection) float64 { return float64(len(c.Requests)) }},
+}
+
+// printListTotal prints the line above a list table. With filters applied it
+// reports how many of the fetched items matched.
+func printListTotal(total int64, shown, fetched int, filtered bool) {
+	if filtered {
+		fmt.Fprintf(os.Stdout, "Matched: %d of %d fetched (total: %d)\n", shown, fetched, total)
+		return
+	}
+	fmt.Fprintf(os.Stdout, "Total: %d\n", total)
+}
@@ -0,0 +1,397 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/flowfile"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// Kinds of change reported by flows diff
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// flowDiff is the difference between two flows, from the first to the second
+type flowDiff struct {
+	Name        *fieldChange `json:"name,omitempty" yaml:"name,omitempty"`
+	Description *fieldChange `json:"description,omitempty" yaml:"description,omitempty"`
+	Nodes       []itemChange `json:"nodes" yaml:"nodes"`
+	Edges       []itemChange `json:"edges" yaml:"edges"`
+	Variables   []itemChange `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// fieldChange is a flow attribute that differs
+type fieldChange struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// itemChange is a node, edge, or environment variable that was added,
+// removed, or changed. Variable values are only included with --reveal.
+type itemChange struct {
+	ID     string          `json:"id" yaml:"id"`
+	Change string          `json:"change" yaml:"change"`
+	From   json.RawMessage `json:"from,omitempty" yaml:"-"`
+	To     json.RawMessage `json:"to,omitempty" yaml:"-"`
+}
+
+// MarshalYAML writes From and To as the values their JSON holds, so YAML
+// output carries the same content as JSON
+func (c itemChange) MarshalYAML() (interface{}, error) {
+	out := struct {
+		ID     string      `yaml:"id"`
+		Change string      `yaml:"change"`
+		From   interface{} `yaml:"from,omitempty"`
+		To     interface{} `yaml:"to,omitempty"`
+	}{ID: c.ID, Change: c.Change}
+
+	for _, field := range []struct {
+		raw  json.RawMessage
+		dest *interface{}
+	}{{c.From, &out.From}, {c.To, &out.To}} {
+		if len(field.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(field.raw, field.dest); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// diffSide is one of the two flows being compared. Vars is nil when the
+// side has no environment to compare, as for a file.
+type diffSide struct {
+	Label       string
+	Name        string
+	Description string
+	Definition  api.FlowDefinition
+	Vars        map[string]string
+}
+
+func (d flowDiff) empty() bool {
+	return d.Name == nil && d.Description == nil && len(d.Nodes) == 0 && len(d.Edges) == 0 && len(d.Variables) == 0
+}
+
+func newFlowDiffCmd(state *AppState) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:         "diff <flow-id> [other-flow-id]",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Compare two flows, or a flow and a file",
+		Long: `Compare a flow with another flow, or with a local file.
+
+Both definitions are normalized first, so only real differences show up:
+nodes and edges are matched by ID and listed as added, removed, or changed,
+with a line diff of each changed node. Comparing two flows also compares
+their environment variables by key; values are compared but never printed.
+
+--file accepts an exported flow ("flows export"), the JSON printed by
+"flows get -o json", or a create or update request.
+
+Exits with an error when the flows differ, so it can detect drift in CI.`,
+		Example: `  echopoint flows diff <flow-id> <other-flow-id>
+  echopoint flows diff <flow-id> --file flow.json
+  echopoint flows diff <flow-id> --file flow.json -o json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if len(args) != 2 {
+				return fmt.Errorf("requires two flow IDs, or one flow ID and --file")
+			}
+			return nil
+		},
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			a, err := loadDiffFlow(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			var b diffSide
+			if file != "" {
+				b, err = loadDiffFile(file)
+			} else {
+				b, err = loadDiffFlow(state, args[1])
+			}
+			if err != nil {
+				return err
+			}
+
+			diff, err := diffFlows(a, b)
+			if err != nil {
+				return err
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, diff)
+			case output.FormatYAML:
+				err = printYAML(state, diff)
+			default:
+				err = printFlowDiff(state, a, b, diff)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !diff.empty() {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("flows differ")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Compare with this flow JSON file, or - for stdin")
+	return cmd
+}
+
+// loadDiffFlow fetches a flow and its environment variables for comparison
+func loadDiffFlow(state *AppState, arg string) (diffSide, error) {
+	flow, vars, err := fetchFlowWithVariables(state, arg)
+	if err != nil {
+		return diffSide{}, err
+	}
+	return diffSide{
+		Label:       flow.Id.String(),
+		Name:        flow.Name,
+		Description: derefString(flow.Description),
+		Definition:  flow.FlowDefinition,
+		Vars:        vars,
+	}, nil
+}
+
+// loadDiffFile reads a flow from an exported flow file, a flow as printed by
+// flows get -o json, or a create or update request
+func loadDiffFile(path string) (diffSide, error) {
+	var raw json.RawMessage
+	if err := loadJSONFile(path, &raw); err != nil {
+		return diffSide{}, err
+	}
+
+	var probe struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	_ = json.Unmarshal(raw, &probe)
+	if probe.SchemaVersion != 0 {
+		exported, err := flowfile.Read(bytes.NewReader(raw))
+		if err != nil {
+			return diffSide{}, err
+		}
+		req, err := exported.ToCreateRequest()
+		if err != nil {
+			return diffSide{}, err
+		}
+		return diffSide{
+			Label:       path,
+			Name:        req.Name,
+			Description: derefString(req.Description),
+			Definition:  req.FlowDefinition,
+		}, nil
+	}
+
+	var doc struct {
+		Name           *string             `json:"name"`
+		Description    *string             `json:"description"`
+		FlowDefinition *api.FlowDefinition `json:"flow_definition"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return diffSide{}, fmt.Errorf("invalid flow file: %w", err)
+	}
+	if doc.FlowDefinition == nil {
+		return diffSide{}, fmt.Errorf("invalid flow file: no flow_definition or schemaVersion")
+	}
+
+	side := diffSide{
+		Label:       path,
+		Description: derefString(doc.Description),
+		Definition:  *doc.FlowDefinition,
+	}
+	if doc.Name != nil {
+		side.Name = *doc.Name
+	} else {
+		side.Name = doc.FlowDefinition.Name
+	}
+	return side, nil
+}
+
+// diffFlows compares normalized definitions node by node and edge by edge
+func diffFlows(a, b diffSide) (flowDiff, error) {
+	diff := flowDiff{Nodes: []itemChange{}, Edges: []itemChange{}}
+	if a.Name != b.Name {
+		diff.Name = &fieldChange{From: a.Name, To: b.Name}
+	}
+	if a.Description != b.Description {
+		diff.Description = &fieldChange{From: a.Description, To: b.Description}
+	}
+
+	nodesA, err := normalizedNodes(a.Definition)
+	if err != nil {
+		return diff, err
+	}
+	nodesB, err := normalizedNodes(b.Definition)
+	if err != nil {
+		return diff, err
+	}
+	diff.Nodes = diffItems(nodesA, nodesB)
+
+	edgesA, err := edgesByID(a.Definition)
+	if err != nil {
+		return diff, err
+	}
+	edgesB, err := edgesByID(b.Definition)
+	if err != nil {
+		return diff, err
+	}
+	diff.Edges = diffItems(edgesA, edgesB)
+
+	if a.Vars != nil && b.Vars != nil {
+		diff.Variables = diffVariables(a.Vars, b.Vars, false)
+	}
+	return diff, nil
+}
+
+// normalizedNodes encodes each node of def after normalization, keyed by ID
+func normalizedNodes(def api.FlowDefinition) (map[string]json.RawMessage, error) {
+	normalized, err := flowbuilder.Normalize(def)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]json.RawMessage, len(normalized.Nodes))
+	for i, node := range normalized.Nodes {
+		data, err := json.Marshal(node)
+		if err != nil {
+			return nil, err
+		}
+		var id struct {
+			Id string `json:"id"`
+		}
+		if err := json.Unmarshal(data, &id); err != nil || id.Id == "" {
+			id.Id = fmt.Sprintf("#%d", i+1)
+		}
+		nodes[id.Id] = data
+	}
+	return nodes, nil
+}
+
+func edgesByID(def api.FlowDefinition) (map[string]json.RawMessage, error) {
+	edges := make(map[string]json.RawMessage, len(def.Edges))
+	for _, edge := range def.Edges {
+		data, err := json.Marshal(edge)
+		if err != nil {
+			return nil, err
+		}
+		edges[edge.Id] = data
+	}
+	return edges, nil
+}
+
+// diffItems lists the IDs added, removed, or changed from a to b, in ID order
+func diffItems(a, b map[string]json.RawMessage) []itemChange {
+	ids := make([]string, 0, len(a)+len(b))
+	for id := range a {
+		ids = append(ids, id)
+	}
+	for id := range b {
+		if _, ok := a[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	changes := []itemChange{}
+	for _, id := range ids {
+		from, inA := a[id]
+		to, inB := b[id]
+		switch {
+		case !inB:
+			changes = append(changes, itemChange{ID: id, Change: changeRemoved, From: from})
+		case !inA:
+			changes = append(changes, itemChange{ID: id, Change: changeAdded, To: to})
+		case !bytes.Equal(from, to):
+			changes = append(changes, itemChange{ID: id, Change: changeChanged, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+func printFlowDiff(state *AppState, a, b diffSide, diff flowDiff) error {
+	if diff.empty() {
+		fmt.Fprintln(os.Stdout, "✓ No differences")
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "--- %s\n+++ %s\n", a.Label, b.Label)
+	if diff.Name != nil {
+		fmt.Fprintf(os.Stdout, "Name: %q -> %q\n", diff.Name.From, diff.Name.To)
+	}
+	if diff.Description != nil {
+		fmt.Fprintf(os.Stdout, "Description: %q -> %q\n", diff.Description.From, diff.Description.To)
+	}
+
+	sections := []struct {
+		title   string
+		changes []itemChange
+	}{
+		{"Nodes", diff.Nodes},
+		{"Edges", diff.Edges},
+		{"Variables", diff.Variables},
+	}
+	for _, section := range sections {
+		if len(section.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "\n%s:\n", section.title)
+		for _, change := range section.changes {
+			if err := printItemChange(state, change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printItemChange writes one line per item, marked +, -, or ~, followed by a
+// line diff when a changed item carries both versions
+func printItemChange(state *AppState, change itemChange) error {
+	switch change.Change {
+	case changeAdded:
+		fmt.Fprintf(os.Stdout, "  + %s\n", change.ID)
+	case changeRemoved:
+		fmt.Fprintf(os.Stdout, "  - %s\n", change.ID)
+	default:
+		fmt.Fprintf(os.Stdout, "  ~ %s\n", change.ID)
+		if change.From != nil && change.To != nil {
+			if _, err := output.PrintDiff(os.Stdout, change.From, change.To, state.Color); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// derefString returns the value of s, or "" when it is nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
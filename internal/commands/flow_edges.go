@@ -1,13 +1,11 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 
 	"echopoint-cli/internal/api"
 
 	"github.com/gofrs/uuid/v5"
-	googleuuid "github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
@@ -31,9 +29,10 @@ func newFlowEdgeAddCmd(state *AppState) *cobra.Command {
 	var fromNode, toNode, edgeType string
 
 	cmd := &cobra.Command{
-		Use:   "add <flow-id>",
-		Short: "Add an edge between nodes",
-		Args:  cobra.ExactArgs(1),
+		Use:         "add <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Add an edge between nodes",
+		Args:        cobra.ExactArgs(1),
 		Long: `Add a connection (edge) between two nodes.
 
 Examples:
@@ -47,9 +46,9 @@ Examples:
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			// Validate edge type
@@ -59,7 +58,7 @@ Examples:
 			}
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -70,6 +69,13 @@ Examples:
 			flow := resp.JSON200
 			definition := flow.FlowDefinition
 
+			if fromNode, err = resolveNodeID(flow, fromNode); err != nil {
+				return err
+			}
+			if toNode, err = resolveNodeID(flow, toNode); err != nil {
+				return err
+			}
+
 			// Validate that source and target nodes exist
 			sourceExists := false
 			targetExists := false
@@ -132,7 +138,7 @@ Examples:
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -165,23 +171,24 @@ Examples:
 // newFlowEdgeRemoveCmd removes an edge from a flow
 func newFlowEdgeRemoveCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove <flow-id> <edge-id>",
-		Short: "Remove an edge from the flow",
-		Args:  cobra.ExactArgs(2),
+		Use:         "remove <flow-id> <edge-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Remove an edge from the flow",
+		Args:        cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			edgeID := args[1]
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -192,6 +199,10 @@ func newFlowEdgeRemoveCmd(state *AppState) *cobra.Command {
 			flow := resp.JSON200
 			definition := flow.FlowDefinition
 
+			if edgeID, err = resolveEdgeID(definition, edgeID); err != nil {
+				return err
+			}
+
 			// Find and remove edge
 			found := false
 			newEdges := make([]api.FlowEdge, 0, len(definition.Edges))
@@ -216,7 +227,7 @@ func newFlowEdgeRemoveCmd(state *AppState) *cobra.Command {
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
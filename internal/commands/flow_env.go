@@ -1,10 +1,10 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	"echopoint-cli/internal/api"
@@ -24,7 +24,12 @@ func newFlowEnvCmd(state *AppState) *cobra.Command {
 	cmd.AddCommand(
 		newFlowEnvGetCmd(state),
 		newFlowEnvSetCmd(state),
+		newFlowEnvRenameCmd(state),
 		newFlowEnvDeleteCmd(state),
+		newFlowEnvPreviewCmd(state),
+		newFlowEnvCheckCmd(state),
+		newFlowEnvDiffCmd(state),
+		newFlowEnvCopyCmd(state),
 	)
 
 	return cmd
@@ -33,20 +38,27 @@ func newFlowEnvCmd(state *AppState) *cobra.Command {
 // newFlowEnvGetCmd gets environment variables for a flow
 func newFlowEnvGetCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "get <flow-id>",
-		Short: "Get flow environment variables",
-		Args:  cobra.ExactArgs(1),
+		Use:         "get <flow-id> [key]",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Get flow environment variables",
+		Args:        cobra.RangeArgs(1, 2),
+		Long: `Get a flow's environment variables.
+
+With a key, print only that variable's value, with nothing around it, for use
+in scripts:
+
+  TOKEN=$(echopoint flows env get <flow-id> TOKEN)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := uuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
-			resp, err := state.Client.API().GetFlowEnvironmentWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowEnvironmentWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get environment: %w", err)
 			}
@@ -56,11 +68,20 @@ func newFlowEnvGetCmd(state *AppState) *cobra.Command {
 
 			env := resp.JSON200
 
+			if len(args) == 2 {
+				variable, ok := env.Variables[args[1]]
+				if !ok {
+					return fmt.Errorf("variable not found: %s", args[1])
+				}
+				fmt.Println(variable.Value)
+				return nil
+			}
+
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, env)
+				return printJSON(state, env)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, env)
+				return printYAML(state, env)
 			default:
 				if len(env.Variables) == 0 {
 					fmt.Println("No environment variables set")
@@ -80,13 +101,23 @@ func newFlowEnvGetCmd(state *AppState) *cobra.Command {
 // newFlowEnvSetCmd sets environment variables for a flow
 func newFlowEnvSetCmd(state *AppState) *cobra.Command {
 	var variables []string
+	var fromOS string
 
 	cmd := &cobra.Command{
-		Use:   "set <flow-id>",
-		Short: "Set flow environment variables",
-		Args:  cobra.ExactArgs(1),
+		Use:         "set <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Set flow environment variables",
+		Args:        cobra.ExactArgs(1),
 		Long: `Set environment variables for a flow.
 
+The given variables are merged into the flow's environment; variables that are
+not mentioned keep their values.
+
+--from-os PREFIX reads every variable of the CLI's own environment whose name
+starts with PREFIX and sets it under the name with PREFIX removed, so
+CI_SECRET_TOKEN becomes TOKEN with --from-os CI_SECRET_. --var wins when both
+set the same name.
+
 Examples:
   # Set single variable
   echopoint flows env set <flow-id> --var KEY=value
@@ -94,47 +125,62 @@ Examples:
   # Set multiple variables
   echopoint flows env set <flow-id> --var KEY1=value1 --var KEY2=value2
 
-  # Set from JSON file
-  echopoint flows env set <flow-id> --file env.json`,
+  # Set from the environment, stripping the prefix
+  echopoint flows env set <flow-id> --from-os ECHOPOINT_VAR_`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := uuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			vars := make(map[string]string)
-
-			// Parse --var flags
-			for _, v := range variables {
-				parts := strings.SplitN(v, "=", 2)
-				if len(parts) != 2 {
-					return fmt.Errorf("invalid variable format: %s (expected KEY=value)", v)
+			if cmd.Flags().Changed("from-os") {
+				if fromOS == "" {
+					return fmt.Errorf("--from-os needs a prefix")
+				}
+				vars = osVariables(fromOS)
+				if len(vars) == 0 {
+					return fmt.Errorf("no environment variables start with %s", fromOS)
 				}
-				vars[parts[0]] = parts[1]
 			}
 
-			if len(vars) == 0 {
-				return fmt.Errorf("no variables provided. Use --var KEY=value")
+			explicit, err := parseKeyValues(variables)
+			if err != nil {
+				return err
+			}
+			for key, value := range explicit {
+				vars[key] = value
 			}
 
-			req := api.CreateFlowEnvironmentRequest{
-				Variables: vars,
+			if len(vars) == 0 {
+				return fmt.Errorf("no variables provided. Use --var KEY=value or --from-os PREFIX")
 			}
 
-			resp, err := state.Client.API().CreateOrUpdateFlowEnvironmentWithResponse(context.Background(), flowID, req)
+			// Saving replaces the whole environment, so merge into what is there
+			merged, err := fetchFlowVariables(state, flowID)
 			if err != nil {
-				return fmt.Errorf("failed to set environment: %w", err)
+				return err
 			}
-			if resp.JSON200 == nil && resp.JSON201 == nil {
-				return formatAPIError(resp.HTTPResponse, resp.Body)
+			for key, value := range vars {
+				merged[key] = value
 			}
 
-			fmt.Printf("✓ Environment variables set (%d variables)\n", len(vars))
+			if err := saveFlowVariables(state, flowID, merged); err != nil {
+				return err
+			}
+
+			keys := make([]string, 0, len(vars))
 			for key := range vars {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fmt.Printf("✓ Environment variables set (%d variables)\n", len(vars))
+			for _, key := range keys {
 				fmt.Printf("  %s\n", key)
 			}
 
@@ -144,6 +190,71 @@ Examples:
 
 	cmd.Flags().
 		StringArrayVar(&variables, "var", []string{}, "Environment variable in KEY=value format (can be used multiple times)")
+	cmd.Flags().StringVar(&fromOS, "from-os", "", "Set every OS environment variable starting with this prefix, with the prefix removed")
+
+	return cmd
+}
+
+// newFlowEnvRenameCmd renames one environment variable of a flow
+func newFlowEnvRenameCmd(state *AppState) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:         "rename <flow-id> <old-key> <new-key>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Rename a flow environment variable",
+		Args:        cobra.ExactArgs(3),
+		Long: `Rename an environment variable, keeping its value and every other variable.
+
+The environment is saved as a whole, so the variable's created and updated
+times are reset by the server. Renaming onto an existing key fails unless
+--force is given, in which case that key's value is replaced.
+
+References such as {{old-key}} in the flow's nodes are not updated.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			oldKey, newKey := args[1], args[2]
+			if newKey == "" {
+				return fmt.Errorf("new key must not be empty")
+			}
+			if oldKey == newKey {
+				return fmt.Errorf("old and new key are the same: %s", oldKey)
+			}
+
+			vars, err := fetchFlowVariables(state, flowID)
+			if err != nil {
+				return err
+			}
+
+			value, ok := vars[oldKey]
+			if !ok {
+				return fmt.Errorf("variable not found: %s", oldKey)
+			}
+			if _, exists := vars[newKey]; exists && !force {
+				return fmt.Errorf("variable %s already exists (use --force to replace it)", newKey)
+			}
+			delete(vars, oldKey)
+			vars[newKey] = value
+
+			if err := saveFlowVariables(state, flowID, vars); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Renamed %s to %s\n", oldKey, newKey)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace the new key if it already exists")
 
 	return cmd
 }
@@ -151,20 +262,21 @@ Examples:
 // newFlowEnvDeleteCmd deletes environment variables for a flow
 func newFlowEnvDeleteCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "delete <flow-id>",
-		Short: "Delete all flow environment variables",
-		Args:  cobra.ExactArgs(1),
+		Use:         "delete <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Delete all flow environment variables",
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := uuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
-			resp, err := state.Client.API().DeleteFlowEnvironmentWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().DeleteFlowEnvironmentWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to delete environment: %w", err)
 			}
@@ -178,3 +290,54 @@ func newFlowEnvDeleteCmd(state *AppState) *cobra.Command {
 		},
 	}
 }
+
+// osVariables returns the process environment variables whose names start
+// with prefix, keyed by the rest of the name. A variable named exactly prefix
+// is skipped.
+func osVariables(prefix string) map[string]string {
+	vars := make(map[string]string)
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if key, ok := strings.CutPrefix(name, prefix); ok && key != "" {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// fetchFlowVariables returns the environment variables of a flow. A flow
+// without an environment has no variables.
+func fetchFlowVariables(state *AppState, flowID uuid.UUID) (map[string]string, error) {
+	resp, err := state.Client.API().GetFlowEnvironmentWithResponse(state.Context(), flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment: %w", err)
+	}
+
+	vars := make(map[string]string)
+	switch {
+	case resp.JSON200 != nil:
+		for key, val := range resp.JSON200.Variables {
+			vars[key] = val.Value
+		}
+	case resp.StatusCode() == http.StatusNotFound:
+	default:
+		return nil, formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return vars, nil
+}
+
+// saveFlowVariables replaces the environment of a flow with vars. Variables
+// left out of vars are removed, so callers changing a few keys must start from
+// fetchFlowVariables.
+func saveFlowVariables(state *AppState, flowID uuid.UUID, vars map[string]string) error {
+	resp, err := state.Client.API().CreateOrUpdateFlowEnvironmentWithResponse(state.Context(), flowID, api.CreateFlowEnvironmentRequest{
+		Variables: vars,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set environment: %w", err)
+	}
+	if resp.JSON200 == nil && resp.JSON201 == nil {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newFlowEnvCopyCmd(state *AppState) *cobra.Command {
+	var from, to string
+	var keys []string
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:   "copy --from <flow-id> --to <flow-id>",
+		Short: "Copy environment variables from one flow to another",
+		Long: `Copy environment variables from one flow into another, such as when
+promoting configuration from staging to production.
+
+The variables are merged into the target's environment; its other variables
+are kept. --keys limits the copy to the named variables. Replacing a target
+variable that has a different value asks for confirmation, or needs
+--overwrite when stdin is not a terminal.`,
+		Example: `  echopoint flows env copy --from <staging-flow-id> --to <prod-flow-id>
+  echopoint flows env copy --from <staging-flow-id> --to <prod-flow-id> --keys BASE_URL,TIMEOUT
+  echopoint flows env copy --from <staging-flow-id> --to <prod-flow-id> --overwrite`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			sourceID, err := parseFlowFlag(state, "--from", from)
+			if err != nil {
+				return err
+			}
+			targetID, err := parseFlowFlag(state, "--to", to)
+			if err != nil {
+				return err
+			}
+			if sourceID == targetID {
+				return fmt.Errorf("--from and --to are the same flow")
+			}
+
+			source, err := fetchFlowVariables(state, sourceID)
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				selected := make(map[string]string, len(keys))
+				for _, key := range keys {
+					value, ok := source[key]
+					if !ok {
+						return fmt.Errorf("variable not found in source flow: %s", key)
+					}
+					selected[key] = value
+				}
+				source = selected
+			}
+			if len(source) == 0 {
+				return fmt.Errorf("source flow has no environment variables")
+			}
+
+			target, err := fetchFlowVariables(state, targetID)
+			if err != nil {
+				return err
+			}
+
+			var added, replaced, unchanged []string
+			for key, value := range source {
+				current, exists := target[key]
+				switch {
+				case !exists:
+					added = append(added, key)
+				case current != value:
+					replaced = append(replaced, key)
+				default:
+					unchanged = append(unchanged, key)
+				}
+			}
+			sort.Strings(added)
+			sort.Strings(replaced)
+
+			if len(replaced) > 0 && !overwrite {
+				if !isTerminal(os.Stdin) {
+					return fmt.Errorf("%d variable(s) already set with a different value in the target: %s (use --overwrite to replace them)",
+						len(replaced), strings.Join(replaced, ", "))
+				}
+				if !confirm(fmt.Sprintf("Overwrite %d variable(s) in the target: %s?", len(replaced), strings.Join(replaced, ", "))) {
+					fmt.Fprintln(os.Stdout, "Aborted.")
+					return nil
+				}
+			}
+
+			if len(added)+len(replaced) == 0 {
+				fmt.Printf("✓ Target already has these values (%d variables)\n", len(unchanged))
+				return nil
+			}
+
+			for _, key := range append(added, replaced...) {
+				target[key] = source[key]
+			}
+			if err := saveFlowVariables(state, targetID, target); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Copied %d variable(s) from %s to %s\n", len(added)+len(replaced), sourceID, targetID)
+			for _, key := range added {
+				fmt.Printf("  + %s\n", key)
+			}
+			for _, key := range replaced {
+				fmt.Printf("  ~ %s\n", key)
+			}
+			if len(unchanged) > 0 {
+				fmt.Printf("  (%d already up to date)\n", len(unchanged))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Flow to copy variables from")
+	cmd.Flags().StringVar(&to, "to", "", "Flow to copy variables into")
+	cmd.Flags().StringSliceVar(&keys, "keys", nil, "Only copy these variables (comma-separated or repeated)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Replace target variables that have a different value without asking")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// parseFlowFlag resolves a flow ID, or an abbreviation of one, given to flag
+func parseFlowFlag(state *AppState, flag, value string) (uuid.UUID, error) {
+	resolved, err := resolveFlowID(state, value)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	id, err := uuid.Parse(resolved)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("invalid %s flow ID: %w", flag, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowEnvDiffCmd(state *AppState) *cobra.Command {
+	var reveal bool
+
+	cmd := &cobra.Command{
+		Use:         "diff <flow-id> <other-flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Compare the environment variables of two flows",
+		Long: `List the environment variables that the second flow adds, removes, or
+changes compared with the first, such as between staging and production
+copies of a flow.
+
+Values are compared but not printed unless --reveal is given. Exits with an
+error when the environments differ, so it can gate a CI step.`,
+		Example: `  echopoint flows env diff <staging-flow-id> <prod-flow-id>
+  echopoint flows env diff <staging-flow-id> <prod-flow-id> --reveal
+  echopoint flows env diff <staging-flow-id> <prod-flow-id> -o json`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			_, a, err := fetchFlowWithVariables(state, args[0])
+			if err != nil {
+				return err
+			}
+			_, b, err := fetchFlowWithVariables(state, args[1])
+			if err != nil {
+				return err
+			}
+
+			changes := diffVariables(a, b, reveal)
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, changes)
+			case output.FormatYAML:
+				err = printYAML(state, changes)
+			default:
+				err = printVariableChanges(state, changes)
+			}
+			if err != nil {
+				return err
+			}
+
+			if len(changes) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("environments differ")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Show variable values")
+	return cmd
+}
+
+// diffVariables lists the keys added, removed, or changed from a to b. The
+// values are carried along only when reveal is set.
+func diffVariables(a, b map[string]string, reveal bool) []itemChange {
+	changes := diffItems(variablesByKey(a), variablesByKey(b))
+	if !reveal {
+		for i := range changes {
+			changes[i].From, changes[i].To = nil, nil
+		}
+	}
+	return changes
+}
+
+// variablesByKey encodes each value as JSON for diffItems
+func variablesByKey(vars map[string]string) map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage, len(vars))
+	for key, value := range vars {
+		data, _ := json.Marshal(value)
+		values[key] = data
+	}
+	return values
+}
+
+func printVariableChanges(state *AppState, changes []itemChange) error {
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stdout, "✓ No differences")
+		return nil
+	}
+
+	for _, change := range changes {
+		var from, to string
+		_ = json.Unmarshal(change.From, &from)
+		_ = json.Unmarshal(change.To, &to)
+		switch {
+		case change.Change == changeAdded && change.To != nil:
+			fmt.Fprintf(os.Stdout, "  + %s=%s\n", change.ID, to)
+		case change.Change == changeRemoved && change.From != nil:
+			fmt.Fprintf(os.Stdout, "  - %s=%s\n", change.ID, from)
+		case change.Change == changeChanged && change.From != nil:
+			fmt.Fprintf(os.Stdout, "  ~ %s=%s -> %s\n", change.ID, from, to)
+		default:
+			if err := printItemChange(state, change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"echopoint-cli/internal/output"
+)
+
+func TestDiffVariablesYAML(t *testing.T) {
+	a := map[string]string{"baseUrl": "https://staging.example.com", "user": "alice", "old": "gone"}
+	b := map[string]string{"baseUrl": "https://example.com", "user": "alice", "token": ""}
+
+	tests := []struct {
+		name      string
+		reveal    bool
+		canonical bool
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name:    "hidden",
+			want:    []string{"id: baseUrl\n  change: changed\n", "id: old\n  change: removed\n", "id: token\n  change: added\n"},
+			wantNot: []string{"from:", "to:", "example.com", "gone"},
+		},
+		{
+			name:   "revealed",
+			reveal: true,
+			want: []string{
+				"id: baseUrl\n  change: changed\n  from: https://staging.example.com\n  to: https://example.com\n",
+				"id: old\n  change: removed\n  from: gone\n",
+				`id: token` + "\n  change: added\n  to: \"\"\n",
+			},
+		},
+		{
+			name:      "revealed canonical",
+			reveal:    true,
+			canonical: true,
+			want:      []string{"change: changed\n  from: https://staging.example.com\n  id: baseUrl\n  to: https://example.com\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := diffVariables(a, b, tt.reveal)
+
+			var buf bytes.Buffer
+			encode := output.PrintYAML
+			if tt.canonical {
+				encode = output.PrintCanonicalYAML
+			}
+			if err := encode(&buf, changes); err != nil {
+				t.Fatalf("print YAML: %v", err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("YAML does not contain %q:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("YAML contains %q:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
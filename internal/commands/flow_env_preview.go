@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/interpolate"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// envPreview is a flow's request nodes with environment variables substituted
+type envPreview struct {
+	Nodes []envPreviewNode `json:"nodes" yaml:"nodes"`
+
+	// Undefined maps each referenced but unset variable to the nodes using it
+	Undefined map[string][]string `json:"undefined" yaml:"undefined"`
+}
+
+type envPreviewNode struct {
+	ID          string                 `json:"id" yaml:"id"`
+	Name        string                 `json:"name" yaml:"name"`
+	Method      string                 `json:"method" yaml:"method"`
+	URL         string                 `json:"url" yaml:"url"`
+	Headers     map[string]string      `json:"headers,omitempty" yaml:"headers,omitempty"`
+	QueryParams map[string]interface{} `json:"query_params,omitempty" yaml:"query_params,omitempty"`
+	Body        interface{}            `json:"body,omitempty" yaml:"body,omitempty"`
+	Undefined   []string               `json:"undefined,omitempty" yaml:"undefined,omitempty"`
+}
+
+func newFlowEnvPreviewCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:         "preview <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Show request nodes with environment variables substituted",
+		Long: `Show each request node's URL, headers, query parameters, and body with
+{{variable}} placeholders replaced by the flow's environment variables.
+
+Placeholders for variables that are not set are left as written and listed
+at the end. Node output references such as {{node-id.token}} are only known
+while the flow runs, so they are kept as-is.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flow, vars, err := fetchFlowWithVariables(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			preview, err := buildEnvPreview(flow, vars)
+			if err != nil {
+				return err
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, preview)
+			case output.FormatYAML:
+				return printYAML(state, preview)
+			default:
+				printEnvPreview(preview)
+				return nil
+			}
+		},
+	}
+}
+
+// buildEnvPreview expands vars in every request node of flow
+func buildEnvPreview(flow *api.Flow, vars map[string]string) (envPreview, error) {
+	preview := envPreview{
+		Nodes:     []envPreviewNode{},
+		Undefined: map[string][]string{},
+	}
+
+	for _, node := range flow.FlowDefinition.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			return preview, fmt.Errorf("failed to read node: %w", err)
+		}
+		request, ok := value.(api.RequestFlowNode)
+		if !ok {
+			continue
+		}
+
+		var undefined []string
+		seen := make(map[string]bool)
+		record := func(names []string) {
+			for _, name := range names {
+				if !seen[name] {
+					seen[name] = true
+					undefined = append(undefined, name)
+				}
+			}
+		}
+		expand := func(s string) string {
+			expanded, missing := interpolate.Expand(s, vars)
+			record(missing)
+			return expanded
+		}
+
+		item := envPreviewNode{
+			ID:     request.Id,
+			Name:   request.DisplayName,
+			Method: string(request.Data.Method),
+			URL:    expand(request.Data.Url),
+		}
+		if request.Data.Headers != nil {
+			item.Headers = make(map[string]string, len(*request.Data.Headers))
+			for key, val := range *request.Data.Headers {
+				item.Headers[expand(key)] = expand(val)
+			}
+		}
+		if request.Data.QueryParams != nil {
+			item.QueryParams = make(map[string]interface{}, len(*request.Data.QueryParams))
+			for key, val := range *request.Data.QueryParams {
+				expanded, missing := interpolate.ExpandValue(val, vars)
+				item.QueryParams[expand(key)] = expanded
+				record(missing)
+			}
+		}
+		if request.Data.Body != nil {
+			expanded, missing := interpolate.ExpandValue(request.Data.Body, vars)
+			item.Body = expanded
+			record(missing)
+		}
+
+		sort.Strings(undefined)
+		item.Undefined = undefined
+		for _, name := range undefined {
+			preview.Undefined[name] = append(preview.Undefined[name], request.Id)
+		}
+		preview.Nodes = append(preview.Nodes, item)
+	}
+
+	return preview, nil
+}
+
+func printEnvPreview(preview envPreview) {
+	if len(preview.Nodes) == 0 {
+		fmt.Println("No request nodes in this flow")
+		return
+	}
+
+	for i, node := range preview.Nodes {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%s)\n", node.Name, node.ID)
+		fmt.Printf("  %s %s\n", node.Method, node.URL)
+		for _, key := range slices.Sorted(maps.Keys(node.Headers)) {
+			fmt.Printf("  Header  %s: %s\n", key, node.Headers[key])
+		}
+		for _, key := range slices.Sorted(maps.Keys(node.QueryParams)) {
+			fmt.Printf("  Query   %s=%v\n", key, node.QueryParams[key])
+		}
+		if node.Body != nil {
+			body, err := json.Marshal(node.Body)
+			if err == nil {
+				fmt.Printf("  Body    %s\n", body)
+			}
+		}
+	}
+
+	if len(preview.Undefined) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Undefined variables:")
+	for _, name := range slices.Sorted(maps.Keys(preview.Undefined)) {
+		fmt.Printf("  %s (used by %s)\n", name, strings.Join(preview.Undefined[name], ", "))
+	}
+}
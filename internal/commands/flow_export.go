@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowfile"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newFlowExportCmd(state *AppState) *cobra.Command {
+	var file string
+	var includeEnv bool
+
+	cmd := &cobra.Command{
+		Use:         "export <id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Export a flow to a versioned JSON file",
+		Long: `Export a flow in the CLI's stable flow file format.
+
+The file records a schemaVersion and does not depend on the API's wire format,
+so exports remain importable with "echopoint flows import" across releases.
+
+--include-env adds the flow's environment variables to the file. Their values
+are written in plain text, so keep such files private.`,
+		Example: `  echopoint flows export <id> > backup.json
+  echopoint flows export <id> --file backup.json
+  echopoint flows export <id> --file backup.json --include-env`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			exported, err := flowfile.FromFlow(resp.JSON200)
+			if err != nil {
+				return err
+			}
+			if includeEnv {
+				vars, err := fetchFlowVariables(state, id)
+				if err != nil {
+					return err
+				}
+				if len(vars) > 0 {
+					exported.Environment = vars
+				}
+			}
+
+			if file == "" || file == stdinPath {
+				return flowfile.Write(os.Stdout, exported)
+			}
+
+			out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			if err := flowfile.Write(out, exported); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "✓ Exported %s to %s\n", exported.Name, file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Write to this path instead of stdout")
+	cmd.Flags().BoolVar(&includeEnv, "include-env", false, "Include the flow's environment variables")
+	return cmd
+}
+
+func newFlowImportCmd(state *AppState) *cobra.Command {
+	var file, name string
+	var createEnv bool
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create a flow from an exported flow file",
+		Long: `Create a flow from a file written by "echopoint flows export".
+
+When the file includes environment variables (export --include-env), they are
+set on the new flow as well, unless --create-env=false is given. If setting
+them fails, the new flow is deleted again so the import can be retried.`,
+		Example: `  echopoint flows import --file backup.json
+  echopoint flows import --file backup.json --create-env=false
+  echopoint flows export <id> | echopoint flows import --file - --name "Copy of flow"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			var in io.Reader = os.Stdin
+			if file != stdinPath {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			imported, err := flowfile.Read(in)
+			if err != nil {
+				return err
+			}
+			if name != "" {
+				imported.Name = name
+			}
+
+			req, err := imported.ToCreateRequest()
+			if err != nil {
+				return err
+			}
+
+			resp, err := createFlow(state, req)
+			if err != nil {
+				return err
+			}
+			if resp.JSON201 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			if len(imported.Environment) > 0 {
+				if !createEnv {
+					fmt.Fprintf(os.Stderr, "Skipped %s from the file\n", pluralize(len(imported.Environment), "environment variable"))
+				} else if err := importEnvironment(state, resp.JSON201.Id, imported.Environment); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, resp.JSON201)
+			case output.FormatYAML:
+				return printYAML(state, resp.JSON201)
+			default:
+				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Id)
+				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON201.Name)
+				if createEnv && len(imported.Environment) > 0 {
+					fmt.Fprintf(os.Stdout, "Environment: %s\n", pluralize(len(imported.Environment), "variable"))
+				}
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Path to an exported flow file, or - for stdin")
+	cmd.Flags().StringVar(&name, "name", "", "Name for the new flow (defaults to the exported name)")
+	cmd.Flags().BoolVar(&createEnv, "create-env", true, "Set the environment variables included in the file on the new flow")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// importEnvironment sets vars on a newly imported flow. On failure the flow is
+// deleted so a half-imported copy is not left behind.
+func importEnvironment(state *AppState, flowID uuid.UUID, vars map[string]string) error {
+	resp, err := state.Client.API().CreateOrUpdateFlowEnvironmentWithResponse(state.Context(), flowID, api.CreateFlowEnvironmentRequest{
+		Variables: vars,
+	})
+	if err == nil && resp.JSON200 == nil && resp.JSON201 == nil {
+		err = formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if deleteErr := deleteFlow(state, flowID); deleteErr != nil {
+		return fmt.Errorf("failed to set environment: %w (flow %s was created without it and could not be removed: %v)", err, flowID, deleteErr)
+	}
+	return fmt.Errorf("failed to set environment, the imported flow was removed: %w", err)
+}
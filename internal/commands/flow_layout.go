@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowLayoutCmd(state *AppState) *cobra.Command {
+	opts := flowbuilder.DefaultGridOptions()
+	var direction string
+	var successWeight, failureWeight int
+
+	cmd := &cobra.Command{
+		Use:         "layout <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Lay out a flow's nodes locally",
+		Long: `Compute node positions with the CLI's layered layout and save them to the flow.
+
+Nodes are arranged in levels by their distance from the start of the flow,
+top to bottom by default; --direction LR lays the levels out left to right,
+and BT and RL reverse those. Positions are written to the flow metadata and
+the backend layout is skipped.
+
+Each level is ordered by where its parents sit. Raising --success-weight pulls
+nodes toward the parent of their success edge and puts success branches
+before failure branches, so the happy path stays together and error paths
+fan out to the side.`,
+		Example: `  echopoint flows layout <flow-id>
+  echopoint flows layout <flow-id> --direction LR
+  echopoint flows layout <flow-id> --success-weight 5
+  echopoint flows layout <flow-id> --grid-width 3000 --node-spacing 120`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+			dir, err := flowbuilder.ParseDirection(direction)
+			if err != nil {
+				return err
+			}
+			opts.Direction = dir
+			grid, err := flowbuilder.NewGridWithOptions(opts)
+			if err != nil {
+				return err
+			}
+			if successWeight < 1 || failureWeight < 1 {
+				return fmt.Errorf("edge weights must be at least 1")
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+			flow := resp.JSON200
+
+			positions, cycles, err := layoutFlow(flow, grid, map[api.FlowEdgeType]int{
+				api.Success: successWeight,
+				api.Failure: failureWeight,
+			})
+			if err != nil {
+				return err
+			}
+			for _, cycle := range cycles {
+				fmt.Fprintf(os.Stderr, "Warning: edge %s forms a cycle and was ignored for layout\n", cycle)
+			}
+
+			autoLayout := false
+			req := api.UpdateFlowRequest{
+				Description:    flow.Description,
+				FlowDefinition: &flow.FlowDefinition,
+				AutoLayout:     &autoLayout,
+				Metadata:       withNodePositions(flow, positions),
+			}
+
+			updateResp, err := updateFlow(state, id, req)
+			if err != nil {
+				return err
+			}
+			if updateResp.JSON200 == nil {
+				return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, updateResp.JSON200)
+			case output.FormatYAML:
+				return printYAML(state, updateResp.JSON200)
+			default:
+				rows := make([][]string, 0, len(positions))
+				for nodeID, pos := range positions {
+					rows = append(rows, []string{nodeID, flowbuilder.FormatPosition(pos)})
+				}
+				sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+				compactIDColumn(state, rows, 0)
+				fmt.Fprintf(os.Stdout, "✓ Laid out %s\n", pluralize(len(rows), "node"))
+				return printTable(state, output.Columns("Node", "Position"), rows)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Width, "grid-width", opts.Width, "Canvas width used to center each level (grows to fit the widest level)")
+	cmd.Flags().IntVar(&opts.PaddingX, "node-spacing", opts.PaddingX, "Horizontal gap between nodes (between levels with LR or RL)")
+	cmd.Flags().IntVar(&successWeight, "success-weight", 1, "Weight of success edges when ordering each level")
+	cmd.Flags().IntVar(&failureWeight, "failure-weight", 1, "Weight of failure edges when ordering each level")
+	cmd.Flags().StringVar(&direction, "direction", string(opts.Direction), "Direction levels advance in: TB, LR, BT, or RL")
+	return cmd
+}
+
+// layoutFlow places the nodes of flow on grid and returns their positions by
+// node ID, with the edges ignored because they close a cycle, as "a -> b"
+func layoutFlow(flow *api.Flow, grid *flowbuilder.Grid, weights map[api.FlowEdgeType]int) (map[string]flowbuilder.Position, []string, error) {
+	keys := flowNodeKeys(flow)
+	var nodes []flowbuilder.NodePlacement
+	for _, nodeID := range flowNodeIDs(flow) {
+		key, _ := keys.key(nodeID)
+		nodes = append(nodes, flowbuilder.NodePlacement{
+			ID:     key,
+			Width:  grid.NodeWidth,
+			Height: grid.NodeHeight,
+		})
+	}
+	if len(nodes) == 0 {
+		return nil, nil, fmt.Errorf("flow has no nodes to lay out")
+	}
+
+	placed, backEdges := grid.AutoPlacementAlgorithm(nodes, weightedFlowEdges(flow, keys, weights))
+	positions := make(map[string]flowbuilder.Position, len(placed))
+	for _, node := range placed {
+		positions[keys.id(node.ID)] = node.Position
+	}
+	cycles := make([]string, 0, len(backEdges))
+	for _, edge := range backEdges {
+		cycles = append(cycles, fmt.Sprintf("%s -> %s", keys.id(edge.From), keys.id(edge.To)))
+	}
+	return positions, cycles, nil
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+)
+
+// templateFlow returns the named template as a saved flow
+func templateFlow(t *testing.T, name string) *api.Flow {
+	t.Helper()
+	data, err := loadFlowTemplate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req api.CreateFlowRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	return &api.Flow{Name: req.Name, FlowDefinition: req.FlowDefinition}
+}
+
+func TestLayoutFlowTemplates(t *testing.T) {
+	for _, name := range flowTemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			flow := templateFlow(t, name)
+
+			positions, cycles, err := layoutFlow(flow, flowbuilder.NewGrid(), nil)
+			if err != nil {
+				t.Fatalf("layoutFlow: %v", err)
+			}
+			if len(cycles) != 0 {
+				t.Errorf("cycles = %v, want none", cycles)
+			}
+			nodeIDs := flowNodeIDs(flow)
+			if len(positions) != len(nodeIDs) {
+				t.Errorf("laid out %d nodes, want %d: %v", len(positions), len(nodeIDs), positions)
+			}
+			for _, nodeID := range nodeIDs {
+				if _, ok := positions[nodeID]; !ok {
+					t.Errorf("node %s has no position", nodeID)
+				}
+			}
+			if edges := flowEdges(flow, flowNodeKeys(flow)); len(edges) != len(flow.FlowDefinition.Edges) {
+				t.Errorf("kept %d of %d edges", len(edges), len(flow.FlowDefinition.Edges))
+			}
+			for _, edge := range flow.FlowDefinition.Edges {
+				if from, to := positions[edge.Source], positions[edge.Target]; to.Y <= from.Y {
+					t.Errorf("edge %s -> %s goes from y=%d to y=%d, want downwards", edge.Source, edge.Target, from.Y, to.Y)
+				}
+			}
+
+			again, _, _ := layoutFlow(flow, flowbuilder.NewGrid(), nil)
+			if !reflect.DeepEqual(again, positions) {
+				t.Errorf("second layout = %v, want %v", again, positions)
+			}
+		})
+	}
+}
+
+func TestLayoutFlowReportsCyclesByNodeID(t *testing.T) {
+	var flow api.Flow
+	if err := json.Unmarshal([]byte(`{"flow_definition": {
+		"name": "loop", "version": "1.0",
+		"nodes": [
+			{"id": "start", "type": "delay", "display_name": "Start", "data": {"duration": 1}},
+			{"id": "poll", "type": "delay", "display_name": "Poll", "data": {"duration": 1}}
+		],
+		"edges": [
+			{"id": "e1", "source": "start", "target": "poll", "type": "success"},
+			{"id": "e2", "source": "poll", "target": "start", "type": "failure"}
+		]
+	}}`), &flow); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cycles, err := layoutFlow(&flow, flowbuilder.NewGrid(), nil)
+	if err != nil {
+		t.Fatalf("layoutFlow: %v", err)
+	}
+	if want := []string{"poll -> start"}; !reflect.DeepEqual(cycles, want) {
+		t.Errorf("cycles = %v, want %v", cycles, want)
+	}
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowNodeDisableCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:         "disable <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Skip a node when the flow runs, without removing it",
+		Long: `Disable a node. Disabled nodes keep their configuration and edges but are
+skipped when the flow runs. Use "flows node enable" to turn them back on.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setNodeDisabled(state, args[0], args[1], true)
+		},
+	}
+}
+
+func newFlowNodeEnableCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:               "enable <flow-id> <node-id>",
+		Annotations:       map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:             "Re-enable a disabled node",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setNodeDisabled(state, args[0], args[1], false)
+		},
+	}
+}
+
+// setNodeDisabled sets the disabled flag of a node and saves the flow.
+// Enabling clears the flag rather than storing false.
+func setNodeDisabled(state *AppState, flowArg, nodeID string, disabled bool) error {
+	if err := requireToken(state); err != nil {
+		return err
+	}
+
+	flowID, err := parseFlowID(state, flowArg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+	if err != nil {
+		return fmt.Errorf("failed to get flow: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+
+	var flag *bool
+	if disabled {
+		flag = &disabled
+	}
+
+	flow := resp.JSON200
+	if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+		return err
+	}
+	definition := flow.FlowDefinition
+	definition.Nodes = append([]api.FlowNode(nil), flow.FlowDefinition.Nodes...)
+
+	found, changed := false, false
+	for i, node := range definition.Nodes {
+		nodeData, err := node.ValueByDiscriminator()
+		if err != nil {
+			return fmt.Errorf("failed to read node: %w", err)
+		}
+		switch n := nodeData.(type) {
+		case api.RequestFlowNode:
+			if n.Id != nodeID {
+				continue
+			}
+			found = true
+			changed = isDisabled(n.Disabled) != disabled
+			n.Disabled = flag
+			err = definition.Nodes[i].FromRequestFlowNode(n)
+		case api.DelayFlowNode:
+			if n.Id != nodeID {
+				continue
+			}
+			found = true
+			changed = isDisabled(n.Disabled) != disabled
+			n.Disabled = flag
+			err = definition.Nodes[i].FromDelayFlowNode(n)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update node: %w", err)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("node not found: %s", nodeID)
+	}
+
+	status := "enabled"
+	if disabled {
+		status = "disabled"
+	}
+	if !changed {
+		fmt.Printf("Node already %s: %s\n", status, nodeID)
+		return nil
+	}
+
+	if err := saveFlowDefinition(state, flowID, flow, definition); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Node %s: %s\n", status, nodeID)
+	return nil
+}
+
+// isDisabled reports whether a node's disabled flag is set
+func isDisabled(disabled *bool) bool {
+	return disabled != nil && *disabled
+}
@@ -0,0 +1,353 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/interpolate"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultNodeTimeout applies to tested nodes that set no timeout of their own
+const defaultNodeTimeout = 30 * time.Second
+
+// nodeTestResult is the outcome of running one request node on its own
+type nodeTestResult struct {
+	NodeID     string                        `json:"node_id" yaml:"node_id"`
+	Name       string                        `json:"name" yaml:"name"`
+	Method     string                        `json:"method" yaml:"method"`
+	URL        string                        `json:"url" yaml:"url"`
+	Status     int                           `json:"status" yaml:"status"`
+	DurationMs int64                         `json:"duration_ms" yaml:"duration_ms"`
+	Headers    map[string][]string           `json:"headers" yaml:"headers"`
+	Body       interface{}                   `json:"body" yaml:"body"`
+	Assertions []flowbuilder.AssertionResult `json:"assertions" yaml:"assertions"`
+	Outputs    []outputResult                `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// outputResult is the value one output extracts, or why it could not
+type outputResult struct {
+	Name  string      `json:"name" yaml:"name"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	Error string      `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func newFlowNodeTestCmd(state *AppState) *cobra.Command {
+	var envVars, envFiles []string
+
+	cmd := &cobra.Command{
+		Use:         "test <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Send a single request node and check its assertions",
+		Long: `Send one request node on its own, without running the rest of the flow,
+and show the response with the result of each assertion and output.
+
+The request is sent from this machine, not by the backend. Placeholders are
+filled from the flow's environment, overridden by --env-from files and --env
+pairs. A node that uses an earlier node's output needs that value passed as
+well, named like the placeholder: --env login.token=abc.
+
+Assertions and outputs are evaluated locally; xmlPath extractors are not
+supported. Exits with an error when an assertion fails.`,
+		Example: `  echopoint flows node test <flow-id> <node-id>
+  echopoint flows node test <flow-id> <node-id> --env baseUrl=http://localhost:8080
+  echopoint flows node test <flow-id> <node-id> --env login.token=abc -o json`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			overrides, err := loadEnvOverrides(envFiles, envVars)
+			if err != nil {
+				return err
+			}
+
+			flow, vars, err := fetchFlowWithVariables(state, args[0])
+			if err != nil {
+				return err
+			}
+			maps.Copy(vars, overrides)
+
+			nodeID, err := resolveNodeID(flow, args[1])
+			if err != nil {
+				return err
+			}
+			node, err := findRequestNode(flow, nodeID)
+			if err != nil {
+				return err
+			}
+
+			req, err := buildNodeRequest(state.Context(), node, vars)
+			if err != nil {
+				return err
+			}
+
+			timeout := defaultNodeTimeout
+			if node.Data.Timeout != nil && *node.Data.Timeout > 0 {
+				timeout = time.Duration(*node.Data.Timeout) * time.Millisecond
+			}
+			start := time.Now()
+			resp, err := (&http.Client{Timeout: timeout}).Do(req)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("request failed: %w", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to read response: %w", err)
+			}
+			elapsed := time.Since(start)
+
+			response := flowbuilder.Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+			result := nodeTestResult{
+				NodeID:     node.Id,
+				Name:       node.DisplayName,
+				Method:     req.Method,
+				URL:        req.URL.String(),
+				Status:     resp.StatusCode,
+				DurationMs: elapsed.Milliseconds(),
+				Headers:    resp.Header,
+				Body:       decodeBody(body),
+				Assertions: []flowbuilder.AssertionResult{},
+			}
+			if node.Assertions != nil {
+				for _, assertion := range *node.Assertions {
+					result.Assertions = append(result.Assertions, flowbuilder.EvaluateAssertion(response, assertion))
+				}
+			}
+			if node.Outputs != nil {
+				for _, out := range *node.Outputs {
+					value, err := flowbuilder.EvaluateOutput(response, out)
+					if err != nil {
+						result.Outputs = append(result.Outputs, outputResult{Name: out.Name, Error: err.Error()})
+					} else {
+						result.Outputs = append(result.Outputs, outputResult{Name: out.Name, Value: value})
+					}
+				}
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, result)
+			case output.FormatYAML:
+				err = printYAML(state, result)
+			default:
+				printNodeTestResult(resp.Status, result)
+			}
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, assertion := range result.Assertions {
+				if !assertion.Passed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d of %d assertions failed", failed, len(result.Assertions))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Variable or node output value in KEY=value format (can be used multiple times)")
+	cmd.Flags().StringArrayVar(&envFiles, "env-from", nil, "Load variables from a dotenv file (can be used multiple times)")
+	return cmd
+}
+
+// findRequestNode returns the request node of flow with the given ID
+func findRequestNode(flow *api.Flow, nodeID string) (api.RequestFlowNode, error) {
+	for _, node := range flow.FlowDefinition.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			return api.RequestFlowNode{}, fmt.Errorf("failed to read node: %w", err)
+		}
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			if n.Id == nodeID {
+				return n, nil
+			}
+		case api.DelayFlowNode:
+			if n.Id == nodeID {
+				return api.RequestFlowNode{}, fmt.Errorf("node %s is a delay node; only request nodes can be tested", nodeID)
+			}
+		}
+	}
+	return api.RequestFlowNode{}, fmt.Errorf("node not found: %s", nodeID)
+}
+
+// buildNodeRequest fills the placeholders of node from vars and builds its
+// HTTP request. Placeholders without a value are an error, since sending them
+// as written would only fail in a less obvious way.
+func buildNodeRequest(ctx context.Context, node api.RequestFlowNode, vars map[string]string) (*http.Request, error) {
+	var undefined []string
+	record := func(names []string) {
+		for _, name := range names {
+			if !slices.Contains(undefined, name) {
+				undefined = append(undefined, name)
+			}
+		}
+	}
+	expand := func(s string) string {
+		expanded, missing := interpolate.ExpandAll(s, vars)
+		record(missing)
+		return expanded
+	}
+
+	target := expand(node.Data.Url)
+	headers := make(map[string]string)
+	if node.Data.Headers != nil {
+		for key, value := range *node.Data.Headers {
+			headers[expand(key)] = expand(value)
+		}
+	}
+	query := make(map[string]interface{})
+	if node.Data.QueryParams != nil {
+		for key, value := range *node.Data.QueryParams {
+			expanded, missing := interpolate.ExpandValueAll(value, vars)
+			record(missing)
+			query[expand(key)] = expanded
+		}
+	}
+	var body interface{}
+	if node.Data.Body != nil {
+		var missing []string
+		body, missing = interpolate.ExpandValueAll(node.Data.Body, vars)
+		record(missing)
+	}
+
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("no value for %s (pass them with --env NAME=value)", strings.Join(undefined, ", "))
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", target, err)
+	}
+	if len(query) > 0 {
+		values := u.Query()
+		for key, value := range query {
+			if list, ok := value.([]interface{}); ok {
+				for _, item := range list {
+					values.Add(key, queryValue(item))
+				}
+				continue
+			}
+			values.Set(key, queryValue(value))
+		}
+		u.RawQuery = values.Encode()
+	}
+
+	var reader io.Reader
+	contentType := ""
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, string(node.Data.Method), u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// queryValue renders a query parameter value: strings as-is, anything else
+// JSON-encoded
+func queryValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// decodeBody returns body decoded when it is JSON, and as a string otherwise
+func decodeBody(body []byte) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		return decoded
+	}
+	return string(body)
+}
+
+func printNodeTestResult(status string, result nodeTestResult) {
+	fmt.Printf("%s %s\n", result.Method, result.URL)
+	fmt.Printf("%s (%s)\n", status, time.Duration(result.DurationMs)*time.Millisecond)
+
+	fmt.Println()
+	for _, key := range slices.Sorted(maps.Keys(result.Headers)) {
+		for _, value := range result.Headers[key] {
+			fmt.Printf("%s: %s\n", key, value)
+		}
+	}
+
+	if body, ok := result.Body.(string); ok {
+		if body != "" {
+			fmt.Printf("\n%s\n", body)
+		}
+	} else {
+		fmt.Println()
+		_ = output.PrintJSON(os.Stdout, result.Body, true)
+	}
+
+	if len(result.Assertions) > 0 {
+		fmt.Println("\nAssertions:")
+		for _, assertion := range result.Assertions {
+			if assertion.Passed {
+				fmt.Printf("  ✓ %s\n", assertion.Assertion)
+			} else {
+				fmt.Printf("  ✗ %s: %s\n", assertion.Assertion, assertion.Message)
+			}
+		}
+	}
+
+	if len(result.Outputs) > 0 {
+		fmt.Println("\nOutputs:")
+		for _, out := range result.Outputs {
+			if out.Error != "" {
+				fmt.Printf("  %s: %s\n", out.Name, out.Error)
+			} else {
+				value, _ := json.Marshal(out.Value)
+				fmt.Printf("  %s = %s\n", out.Name, value)
+			}
+		}
+	}
+}
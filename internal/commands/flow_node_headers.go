@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowNodeSetHeaderCmd(state *AppState) *cobra.Command {
+	var name, value string
+	var nodeIDs []string
+
+	cmd := &cobra.Command{
+		Use:         "set-header <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Set a header on every request node",
+		Long: `Add or update one header on every request node, or only on the nodes given
+with --node, in a single update. Other headers are left as they are. An
+existing header with the same name in different case is replaced.
+
+Examples:
+  echopoint flows node set-header <flow-id> --name Authorization --value 'Bearer {{TOKEN}}'
+  echopoint flows node set-header <flow-id> --name X-Tenant --value acme --node login --node profile`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" {
+				return fmt.Errorf("--name is required")
+			}
+			if !cmd.Flags().Changed("value") {
+				return fmt.Errorf("--value is required")
+			}
+
+			return editNodeHeaders(state, args[0], nodeIDs, func(headers map[string]string) bool {
+				key := findHeader(headers, name)
+				if key == name && headers[key] == value {
+					return false
+				}
+				if key != "" {
+					delete(headers, key)
+				}
+				headers[name] = value
+				return true
+			}, fmt.Sprintf("Set header %s", name))
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Header name")
+	cmd.Flags().StringVar(&value, "value", "", "Header value (may contain {{variables}})")
+	cmd.Flags().StringArrayVar(&nodeIDs, "node", nil, "Only change this request node (can be used multiple times)")
+
+	return cmd
+}
+
+func newFlowNodeRemoveHeaderCmd(state *AppState) *cobra.Command {
+	var name string
+	var nodeIDs []string
+
+	cmd := &cobra.Command{
+		Use:         "remove-header <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Remove a header from every request node",
+		Long: `Remove one header, matched case-insensitively, from every request node or
+only from the nodes given with --node, in a single update.
+
+Example:
+  echopoint flows node remove-header <flow-id> --name Authorization`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			return editNodeHeaders(state, args[0], nodeIDs, func(headers map[string]string) bool {
+				key := findHeader(headers, name)
+				if key == "" {
+					return false
+				}
+				delete(headers, key)
+				return true
+			}, fmt.Sprintf("Removed header %s", name))
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Header name")
+	cmd.Flags().StringArrayVar(&nodeIDs, "node", nil, "Only change this request node (can be used multiple times)")
+
+	return cmd
+}
+
+// editNodeHeaders applies edit to the headers of the selected request nodes
+// of a flow and saves the flow if any node changed. No nodeIDs selects every
+// request node.
+func editNodeHeaders(state *AppState, flowArg string, nodeIDs []string, edit func(headers map[string]string) bool, done string) error {
+	if err := requireToken(state); err != nil {
+		return err
+	}
+
+	flowID, err := parseFlowID(state, flowArg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+	if err != nil {
+		return fmt.Errorf("failed to get flow: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	flow := resp.JSON200
+
+	selected := make(map[string]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		selected[id] = true
+	}
+
+	var changed []string
+	matched := make(map[string]bool)
+	definition, err := editRequestNodes(flow, func(n *api.RequestFlowNode) bool {
+		if len(selected) > 0 && !selected[n.Id] {
+			return false
+		}
+		matched[n.Id] = true
+
+		headers := make(map[string]string)
+		if n.Data.Headers != nil {
+			for key, value := range *n.Data.Headers {
+				headers[key] = value
+			}
+		}
+		if !edit(headers) {
+			return false
+		}
+		n.Data.Headers = &headers
+		changed = append(changed, n.Id)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range nodeIDs {
+		if !matched[id] {
+			return fmt.Errorf("request node not found: %s", id)
+		}
+	}
+
+	if len(changed) == 0 {
+		fmt.Println("No request nodes changed")
+		return nil
+	}
+	if err := saveFlowDefinition(state, flowID, flow, definition); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %s on %d request node(s)\n", done, len(changed))
+	for _, id := range changed {
+		fmt.Printf("  %s\n", id)
+	}
+	return nil
+}
+
+// findHeader returns the key in headers that matches name case-insensitively,
+// or "" if there is none
+func findHeader(headers map[string]string, name string) string {
+	if _, ok := headers[name]; ok {
+		return name
+	}
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return ""
+}
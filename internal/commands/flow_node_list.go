@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowNodeListCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:               "list <flow-id>",
+		Short:             "List the nodes of a flow",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		Annotations:       map[string]string{flowArgAnnotation: "", jsonlAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+			if err != nil {
+				return fmt.Errorf("failed to get flow: %w", err)
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			nodes := resp.JSON200.FlowDefinition.Nodes
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, nodes)
+			case output.FormatYAML:
+				return printYAML(state, nodes)
+			case output.FormatJSONL:
+				return printJSONL(state, nodes)
+			}
+
+			if len(nodes) == 0 {
+				fmt.Println("No nodes")
+				return nil
+			}
+
+			rows := make([][]string, 0, len(nodes))
+			for _, node := range nodes {
+				nodeData, err := node.ValueByDiscriminator()
+				if err != nil {
+					return fmt.Errorf("failed to read node: %w", err)
+				}
+				switch n := nodeData.(type) {
+				case api.RequestFlowNode:
+					detail := fmt.Sprintf("%s %s", n.Data.Method, n.Data.Url)
+					rows = append(rows, []string{n.Id, n.DisplayName, n.Type, detail, nodeStatus(n.Disabled)})
+				case api.DelayFlowNode:
+					detail := fmt.Sprintf("%d ms", n.Data.Duration)
+					rows = append(rows, []string{n.Id, n.DisplayName, n.Type, detail, nodeStatus(n.Disabled)})
+				}
+			}
+
+			compactIDColumn(state, rows, 0)
+			return printTable(state, output.Columns("ID", "Name", "Type", "Detail", "Status"), rows)
+		},
+	}
+}
+
+// nodeStatus describes a node's disabled flag for tables
+func nodeStatus(disabled *bool) string {
+	if isDisabled(disabled) {
+		return "disabled"
+	}
+	return "enabled"
+}
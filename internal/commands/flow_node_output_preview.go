@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowNodeOutputPreviewCmd(state *AppState) *cobra.Command {
+	var extractorType, path, headerName, as, input, body string
+	var status int
+	var headers []string
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Try an output extractor on sample response data",
+		Long: `Apply an output extractor to a sample response and print the value it
+extracts, without touching any flow. Use it to check a JSONPath before adding
+it to a node with "flows node output add".
+
+The sample body comes from --input (a file, or - for stdin) or --body. The
+status code and headers of the sample response are set with --status and
+--header for the statusCode and header extractors.
+
+The value is printed as JSON, so strings are quoted. As with saved outputs,
+values are extracted as strings unless --as names another type.`,
+		Example: `  echopoint flows node output preview --extractor jsonPath --path '$.data.id' --input sample.json
+  curl -s https://api.example.com/users | echopoint flows node output preview --extractor jsonPath --path '$[0].id' --input -
+  echopoint flows node output preview --extractor jsonPath --path '$.total' --body '{"total": 3}' --as number
+  echopoint flows node output preview --extractor header --header-name Location --header 'Location: /users/7'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validExtractors := []string{"jsonPath", "statusCode", "body", "header"}
+			if !containsString(validExtractors, extractorType) {
+				return fmt.Errorf("invalid extractor type: %s (must be one of: %v)", extractorType, validExtractors)
+			}
+			validTypes := []string{"string", "number", "boolean", "json"}
+			if as != "" && !containsString(validTypes, as) {
+				return fmt.Errorf("invalid --as type: %s (must be one of: %v)", as, validTypes)
+			}
+			if input != "" && body != "" {
+				return fmt.Errorf("use either --input or --body, not both")
+			}
+
+			resp := flowbuilder.Response{StatusCode: status, Header: http.Header{}}
+			switch {
+			case input != "":
+				data, err := readInputFile(input)
+				if err != nil {
+					return fmt.Errorf("failed to read --input: %w", err)
+				}
+				resp.Body = data
+			case body != "":
+				resp.Body = []byte(body)
+			case extractorType == "jsonPath" || extractorType == "body":
+				return fmt.Errorf("--input or --body is required for the %s extractor", extractorType)
+			}
+			for _, header := range headers {
+				name, value, ok := strings.Cut(header, ":")
+				if !ok || strings.TrimSpace(name) == "" {
+					return fmt.Errorf("invalid --header %q (expected \"Name: value\")", header)
+				}
+				resp.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+			}
+
+			value, err := flowbuilder.EvaluateOutput(resp, newNodeOutput("preview", extractorType, path, headerName, as))
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			if state.OutputFormat == output.FormatYAML {
+				return printYAML(state, value)
+			}
+			return printJSON(state, value)
+		},
+	}
+
+	cmd.Flags().StringVar(&extractorType, "extractor", "", "Extractor type (jsonPath, statusCode, body, header)")
+	cmd.Flags().StringVar(&path, "path", "", "Path for jsonPath extractor")
+	cmd.Flags().StringVar(&headerName, "header-name", "", "Header name for header extractor")
+	cmd.Flags().StringVar(&as, "as", "", "Type to coerce the value to (string, number, boolean, json); default string")
+	cmd.Flags().StringVar(&input, "input", "", "File holding the sample response body, or - for stdin")
+	cmd.Flags().StringVar(&body, "body", "", "Sample response body given inline")
+	cmd.Flags().IntVar(&status, "status", http.StatusOK, "Status code of the sample response")
+	cmd.Flags().StringArrayVar(&headers, "header", nil, "Header of the sample response as \"Name: value\" (can be used multiple times)")
+	_ = cmd.MarkFlagRequired("extractor")
+	return cmd
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowNodeReorderCmd(state *AppState) *cobra.Command {
+	var order []string
+
+	cmd := &cobra.Command{
+		Use:         "reorder <flow-id> --order <node-id>,<node-id>,...",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Set the order of a flow's nodes",
+		Long: `Reorder the flow's node list to match --order, which must list every node
+ID exactly once. The order is kept by later edits and exports; execution
+order still follows the edges.
+
+Example:
+  echopoint flows node reorder <flow-id> --order login,create-user,cleanup`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+			if err != nil {
+				return fmt.Errorf("failed to get flow: %w", err)
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			flow := resp.JSON200
+			nodes, err := reorderNodes(flow.FlowDefinition.Nodes, order)
+			if err != nil {
+				return err
+			}
+
+			definition := flow.FlowDefinition
+			definition.Nodes = nodes
+			if err := saveFlowDefinition(state, flowID, flow, definition); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Reordered %d node(s)\n", len(nodes))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&order, "order", nil, "Comma-separated node IDs in the new order (every node, once)")
+	_ = cmd.MarkFlagRequired("order")
+
+	return cmd
+}
+
+// reorderNodes returns nodes arranged in the order of ids, which must name
+// every node exactly once
+func reorderNodes(nodes []api.FlowNode, ids []string) ([]api.FlowNode, error) {
+	byID := make(map[string]api.FlowNode, len(nodes))
+	for _, node := range nodes {
+		nodeData, err := node.ValueByDiscriminator()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read node: %w", err)
+		}
+		switch n := nodeData.(type) {
+		case api.RequestFlowNode:
+			byID[n.Id] = node
+		case api.DelayFlowNode:
+			byID[n.Id] = node
+		}
+	}
+
+	result := make([]api.FlowNode, 0, len(ids))
+	placed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		node, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("node not found: %s", id)
+		}
+		if placed[id] {
+			return nil, fmt.Errorf("node listed more than once: %s", id)
+		}
+		placed[id] = true
+		result = append(result, node)
+	}
+
+	if len(result) != len(nodes) {
+		var missing []string
+		for id := range byID {
+			if !placed[id] {
+				missing = append(missing, id)
+			}
+		}
+		return nil, fmt.Errorf("--order must list every node; missing: %v", missing)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// urlChange is a request node URL rewritten by replace-url
+type urlChange struct {
+	NodeID string `json:"node_id" yaml:"node_id"`
+	Name   string `json:"name" yaml:"name"`
+	From   string `json:"from" yaml:"from"`
+	To     string `json:"to" yaml:"to"`
+}
+
+func newFlowNodeReplaceURLCmd(state *AppState) *cobra.Command {
+	var from, to string
+	var useRegex, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:         "replace-url <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Rewrite the URL of every matching request node",
+		Long: `Rewrite request node URLs across a flow in a single update.
+
+By default --from is a URL prefix: every request node URL that starts with it
+has that prefix replaced by --to. With --regex, --from is a regular expression
+and every match is replaced by --to, which may reference groups as $1 or
+${name}.
+
+Examples:
+  # Move every request to a new host
+  echopoint flows node replace-url <flow-id> --from https://staging.example.com --to https://api.example.com
+
+  # Preview the changes without saving
+  echopoint flows node replace-url <flow-id> --from http://localhost:8080 --to {{baseUrl}} --dry-run
+
+  # Bump the API version in every path
+  echopoint flows node replace-url <flow-id> --regex --from '/v1/' --to '/v2/'`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			if from == "" {
+				return fmt.Errorf("--from is required")
+			}
+			replace := func(url string) string {
+				if strings.HasPrefix(url, from) {
+					return to + strings.TrimPrefix(url, from)
+				}
+				return url
+			}
+			if useRegex {
+				pattern, err := regexp.Compile(from)
+				if err != nil {
+					return fmt.Errorf("invalid --from pattern: %w", err)
+				}
+				replace = func(url string) string {
+					return pattern.ReplaceAllString(url, to)
+				}
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+			if err != nil {
+				return fmt.Errorf("failed to get flow: %w", err)
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			flow := resp.JSON200
+			changes := []urlChange{}
+			definition, err := editRequestNodes(flow, func(n *api.RequestFlowNode) bool {
+				url := replace(n.Data.Url)
+				if url == n.Data.Url {
+					return false
+				}
+				changes = append(changes, urlChange{NodeID: n.Id, Name: n.DisplayName, From: n.Data.Url, To: url})
+				n.Data.Url = url
+				return true
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(changes) > 0 && !dryRun {
+				if err := saveFlowDefinition(state, flowID, flow, definition); err != nil {
+					return err
+				}
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, changes)
+			case output.FormatYAML:
+				return printYAML(state, changes)
+			}
+
+			if len(changes) == 0 {
+				fmt.Println("No request node URLs matched")
+				return nil
+			}
+
+			rows := make([][]string, 0, len(changes))
+			for _, change := range changes {
+				rows = append(rows, []string{change.NodeID, change.Name, change.From, change.To})
+			}
+			compactIDColumn(state, rows, 0)
+			if err := printTable(state, output.Columns("Node", "Name", "Old URL", "New URL"), rows); err != nil {
+				return err
+			}
+
+			if dryRun {
+				fmt.Printf("\nDry run: %d request node(s) would be updated\n", len(changes))
+			} else {
+				fmt.Printf("\n✓ Updated %d request node(s)\n", len(changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "URL prefix to replace (a regular expression with --regex)")
+	cmd.Flags().StringVar(&to, "to", "", "Replacement text")
+	cmd.Flags().BoolVar(&useRegex, "regex", false, "Treat --from as a regular expression")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without saving them")
+
+	return cmd
+}
+
+// editRequestNodes applies edit to a copy of every request node in flow and
+// returns the resulting definition. edit reports whether it changed the node.
+func editRequestNodes(flow *api.Flow, edit func(n *api.RequestFlowNode) bool) (api.FlowDefinition, error) {
+	definition := flow.FlowDefinition
+	definition.Nodes = append([]api.FlowNode(nil), flow.FlowDefinition.Nodes...)
+
+	for i, node := range definition.Nodes {
+		nodeData, err := node.ValueByDiscriminator()
+		if err != nil {
+			return definition, fmt.Errorf("failed to read node: %w", err)
+		}
+		n, ok := nodeData.(api.RequestFlowNode)
+		if !ok || !edit(&n) {
+			continue
+		}
+		if err := definition.Nodes[i].FromRequestFlowNode(n); err != nil {
+			return definition, fmt.Errorf("failed to update node: %w", err)
+		}
+	}
+
+	return definition, nil
+}
+
+// saveFlowDefinition replaces the definition of flow, keeping its name,
+// description, and layout
+func saveFlowDefinition(state *AppState, flowID uuid.UUID, flow *api.Flow, definition api.FlowDefinition) error {
+	updateReq := api.UpdateFlowRequest{
+		Name:           &flow.Name,
+		Description:    flow.Description,
+		FlowDefinition: &definition,
+	}
+	updateResp, err := updateFlow(state, flowID, updateReq)
+	if err != nil {
+		return fmt.Errorf("failed to update flow: %w", err)
+	}
+	if updateResp.JSON200 == nil {
+		return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+	}
+	return nil
+}
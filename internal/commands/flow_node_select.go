@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// nodeChoice is a node offered by the node picker
+type nodeChoice struct {
+	ID   string
+	Name string
+	Type string
+}
+
+// nodeArgAnnotation marks commands whose second argument is a node ID of the
+// flow given first
+const nodeArgAnnotation = "echopoint/node-arg"
+
+// enableNodeSelection lets every command under cmd marked with
+// nodeArgAnnotation run without its node ID. The node is then chosen from the
+// flow's nodes, either because --select-node was given or because only the
+// flow ID was passed and stdin is a terminal. It must run before
+// enableFlowSelection so that both IDs can be picked in turn.
+func enableNodeSelection(state *AppState, cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		enableNodeSelection(state, sub)
+	}
+
+	if _, ok := cmd.Annotations[nodeArgAnnotation]; !ok || cmd.Args == nil || cmd.RunE == nil {
+		return
+	}
+
+	var selectFlag bool
+	args, run := cmd.Args, cmd.RunE
+
+	// withNode inserts a placeholder node ID after the flow ID
+	withNode := func(given []string) []string {
+		if len(given) == 0 {
+			return []string{""}
+		}
+		return append([]string{given[0], ""}, given[1:]...)
+	}
+
+	// Validate as if the node ID had been given. Without --select-node only
+	// a lone flow ID is completed, so other argument counts stay unambiguous.
+	needsNode := func(c *cobra.Command, given []string) bool {
+		if selectFlag {
+			return true
+		}
+		if !isTerminal(os.Stdin) || len(given) != 1 || args(c, given) == nil {
+			return false
+		}
+		return args(c, withNode(given)) == nil
+	}
+
+	cmd.Args = func(c *cobra.Command, given []string) error {
+		if selectFlag && !isTerminal(os.Stdin) {
+			return fmt.Errorf("--select-node needs an interactive terminal")
+		}
+		if needsNode(c, given) {
+			return args(c, withNode(given))
+		}
+		return args(c, given)
+	}
+	cmd.RunE = func(c *cobra.Command, given []string) error {
+		if !needsNode(c, given) {
+			return run(c, given)
+		}
+		if err := requireToken(state); err != nil {
+			return err
+		}
+		flowID, err := parseFlowID(state, given[0])
+		if err != nil {
+			return err
+		}
+		nodeID, err := selectNode(state, flowID)
+		if err != nil {
+			return err
+		}
+		return run(c, append([]string{flowID.String(), nodeID}, given[1:]...))
+	}
+
+	cmd.Flags().BoolVar(&selectFlag, "select-node", false, "Choose the node from a list instead of passing its ID")
+}
+
+// selectNode lists the nodes of a flow on stderr and asks for one by number.
+// Any other answer narrows the list to nodes whose name contains its letters
+// in order.
+func selectNode(state *AppState, flowID uuid.UUID) (string, error) {
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get flow: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return "", formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+
+	nodes, err := nodeChoices(resp.JSON200)
+	if err != nil {
+		return "", err
+	}
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("flow has no nodes to select from")
+	}
+
+	p := newPrompter()
+	matches := nodes
+	for {
+		ids := make([]string, len(matches))
+		nameWidth, typeWidth := 0, 0
+		for i, node := range matches {
+			ids[i] = node.ID
+			nameWidth = max(nameWidth, len(node.Name))
+			typeWidth = max(typeWidth, len(node.Type))
+		}
+		short := output.ShortIDs(ids)
+		for i, node := range matches {
+			fmt.Fprintf(p.out, "%3d. %-*s  %-*s  %s\n", i+1, nameWidth, node.Name, typeWidth, node.Type, short[i])
+		}
+
+		answer := p.ask("Select a node (number, or text to filter)", "")
+		if answer == "" {
+			return "", fmt.Errorf("no node selected")
+		}
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n >= 1 && n <= len(matches) {
+				return matches[n-1].ID, nil
+			}
+			fmt.Fprintf(p.out, "  choose a number from 1 to %d\n", len(matches))
+			continue
+		}
+
+		query := strings.ToLower(answer)
+		var filtered []nodeChoice
+		for _, node := range nodes {
+			if isSubsequence(query, strings.ToLower(node.Name)) {
+				filtered = append(filtered, node)
+			}
+		}
+		switch len(filtered) {
+		case 0:
+			fmt.Fprintf(p.out, "  no node matches %q\n", answer)
+		case 1:
+			fmt.Fprintf(p.out, "Selected: %s\n", filtered[0].Name)
+			return filtered[0].ID, nil
+		default:
+			matches = filtered
+		}
+	}
+}
+
+// nodeChoices lists the nodes of flow in their saved order
+func nodeChoices(flow *api.Flow) ([]nodeChoice, error) {
+	choices := make([]nodeChoice, 0, len(flow.FlowDefinition.Nodes))
+	for _, node := range flow.FlowDefinition.Nodes {
+		nodeData, err := node.ValueByDiscriminator()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read node: %w", err)
+		}
+		switch n := nodeData.(type) {
+		case api.RequestFlowNode:
+			choices = append(choices, nodeChoice{ID: n.Id, Name: n.DisplayName, Type: n.Type})
+		case api.DelayFlowNode:
+			choices = append(choices, nodeChoice{ID: n.Id, Name: n.DisplayName, Type: n.Type})
+		}
+	}
+	return choices, nil
+}
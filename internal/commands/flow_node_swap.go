@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// newFlowNodeSwapCmd exchanges the editor positions of two nodes
+func newFlowNodeSwapCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:         "swap <flow-id> <node-id-a> <node-id-b>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Swap the editor positions of two nodes",
+		Long: `Exchange the saved editor positions of two nodes.
+
+Edges stay attached to their nodes, so only the layout changes. Both nodes
+need a saved position; run "echopoint flows layout" first if they have none.
+The backend layout is skipped so the rest of the flow stays where it is.`,
+		Example: `  echopoint flows node swap <flow-id> <node-id> <node-id>`,
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+			if err != nil {
+				return fmt.Errorf("failed to get flow: %w", err)
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+			flow := resp.JSON200
+
+			a, err := resolveNodeID(flow, args[1])
+			if err != nil {
+				return err
+			}
+			b, err := resolveNodeID(flow, args[2])
+			if err != nil {
+				return err
+			}
+			for _, id := range []string{a, b} {
+				if !flowHasNode(flow, id) {
+					return fmt.Errorf("node not found: %s", id)
+				}
+			}
+			if a == b {
+				return fmt.Errorf("cannot swap a node with itself")
+			}
+
+			metadata, err := withSwappedPositions(flow, a, b)
+			if err != nil {
+				return err
+			}
+
+			autoLayout := false
+			updateResp, err := updateFlow(state, flowID, api.UpdateFlowRequest{
+				Description:    flow.Description,
+				FlowDefinition: &flow.FlowDefinition,
+				AutoLayout:     &autoLayout,
+				Metadata:       metadata,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update flow: %w", err)
+			}
+			if updateResp.JSON200 == nil {
+				return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+			}
+
+			positions := *metadata.NodePositions
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, map[string]flowNodePosition{a: positions[a], b: positions[b]})
+			case output.FormatYAML:
+				return printYAML(state, map[string]flowNodePosition{a: positions[a], b: positions[b]})
+			default:
+				fmt.Fprintf(os.Stdout, "✓ Swapped %s and %s\n", a, b)
+				fmt.Fprintf(os.Stdout, "  %s: (%g, %g)\n", a, *positions[a].X, *positions[a].Y)
+				fmt.Fprintf(os.Stdout, "  %s: (%g, %g)\n", b, *positions[b].X, *positions[b].Y)
+				return nil
+			}
+		},
+	}
+}
+
+// withSwappedPositions returns the flow's metadata for an update request with
+// the saved positions of nodes a and b exchanged
+func withSwappedPositions(flow *api.Flow, a, b string) (*api.UpdateFlowRequest_Metadata, error) {
+	positions := make(map[string]flowNodePosition)
+	if flow.Metadata.NodePositions != nil {
+		for key, value := range *flow.Metadata.NodePositions {
+			positions[key] = value
+		}
+	}
+
+	for _, id := range []string{a, b} {
+		if pos, ok := positions[id]; !ok || pos.X == nil || pos.Y == nil {
+			return nil, fmt.Errorf("node %s has no saved position; run: echopoint flows layout %s", id, flow.Id)
+		}
+	}
+	positions[a], positions[b] = positions[b], positions[a]
+
+	return &api.UpdateFlowRequest_Metadata{
+		NodePositions:        &positions,
+		AdditionalProperties: flow.Metadata.AdditionalProperties,
+	}, nil
+}
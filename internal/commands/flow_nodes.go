@@ -1,17 +1,18 @@
 package commands
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	neturl "net/url"
 	"os"
 	"strconv"
 	"strings"
 
 	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/interpolate"
 
 	"github.com/gofrs/uuid/v5"
-	googleuuid "github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
@@ -23,11 +24,20 @@ func newFlowNodeCmd(state *AppState) *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		newFlowNodeListCmd(state),
 		newFlowNodeAddCmd(state),
 		newFlowNodeRemoveCmd(state),
 		newFlowNodeUpdateCmd(state),
+		newFlowNodeReorderCmd(state),
+		newFlowNodeSwapCmd(state),
+		newFlowNodeDisableCmd(state),
+		newFlowNodeEnableCmd(state),
+		newFlowNodeReplaceURLCmd(state),
+		newFlowNodeSetHeaderCmd(state),
+		newFlowNodeRemoveHeaderCmd(state),
 		newFlowNodeOutputCmd(state),
 		newFlowNodeAssertionCmd(state),
+		newFlowNodeTestCmd(state),
 	)
 
 	return cmd
@@ -35,33 +45,59 @@ func newFlowNodeCmd(state *AppState) *cobra.Command {
 
 // newFlowNodeAddCmd adds a new node to a flow
 func newFlowNodeAddCmd(state *AppState) *cobra.Command {
-	var nodeType, name, method, url, headers, body string
-	var duration int
+	var nodeType, name, method, url, headers, body, contentType, after string
+	var duration, x, y, retryCount, retryDelay int
+	var dependsOn []string
 
 	cmd := &cobra.Command{
-		Use:   "add <flow-id>",
-		Short: "Add a node to the flow",
-		Args:  cobra.ExactArgs(1),
+		Use:         "add <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Add a node to the flow",
+		Args:        cobra.ExactArgs(1),
 		Long: `Add a new node to the flow.
 
 Examples:
   # Add a request node
   echopoint flows node add <flow-id> --type request --name "API Call" --method POST --url "https://api.example.com"
 
+  # A JSON body gets "Content-Type: application/json" unless a content type is set
+  echopoint flows node add <flow-id> --type request --name "Create" --method POST --url "https://api.example.com/users" --body '{"name": "Ada"}'
+
   # Add a delay node
-  echopoint flows node add <flow-id> --type delay --name "Wait" --duration 5000`,
+  echopoint flows node add <flow-id> --type delay --name "Wait" --duration 5000
+
+  # Place the node next to an existing one instead of re-laying out the flow
+  echopoint flows node add <flow-id> --type delay --name "Wait" --duration 5000 --after <node-id>
+
+  # Place the node at explicit editor coordinates
+  echopoint flows node add <flow-id> --type delay --name "Wait" --duration 5000 --x 400 --y 100
+
+  # Connect the node to the ones it runs after in the same update
+  echopoint flows node add <flow-id> --type delay --name "Wait" --duration 5000 --depends-on <node-id> --depends-on <node-id>:failure
+
+  # Re-run the node up to 3 times, 2 seconds apart, while its assertions fail
+  echopoint flows node add <flow-id> --type request --name "Poll" --method GET --url "https://api.example.com/jobs/1" --retry-count 3 --retry-delay 2000
+
+A node with a retry policy only counts as failed, and only follows its failure
+edges, once its last retry has failed too. Retries re-run the node when its
+assertions fail; they are separate from retries of rate-limited API calls.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
+			}
+
+			retry, err := retryPolicy(cmd, retryCount, retryDelay)
+			if err != nil {
+				return err
 			}
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -86,6 +122,13 @@ Examples:
 				if method == "" || url == "" {
 					return fmt.Errorf("--method and --url are required for request nodes")
 				}
+				if err := validateMethod(method); err != nil {
+					return err
+				}
+				method = strings.ToUpper(method)
+				if err := validateRequestURL(url); err != nil {
+					return err
+				}
 
 				reqNode := api.RequestFlowNode{
 					Id:          nodeID,
@@ -96,11 +139,13 @@ Examples:
 						Url:     url,
 						Headers: parseHeaders(headers),
 					},
+					Retry: retry,
 				}
 
 				if body != "" {
 					reqNode.Data.Body = &body
 				}
+				reqNode.Data.Headers = withContentType(reqNode.Data.Headers, body, contentType)
 
 				newNode.FromRequestFlowNode(reqNode)
 
@@ -116,21 +161,62 @@ Examples:
 					Data: api.DelayNodeData{
 						Duration: duration,
 					},
+					Retry: retry,
 				}
 				newNode.FromDelayFlowNode(delayNode)
 
+			case "start", "end":
+				// The API has no terminal node types; a flow starts at its
+				// nodes without incoming edges and ends at those without
+				// outgoing ones
+				return fmt.Errorf("%s nodes are not stored in flows: a flow starts at nodes with no incoming edges and ends at nodes with no outgoing edges", nodeType)
+
+			case "webhook", "trigger":
+				// Webhooks only appear as the trigger type recorded on a run;
+				// the API rejects any node type besides request and delay
+				return fmt.Errorf("%s nodes are not supported: the API only has request and delay nodes, and a webhook is how a run is started (its trigger type), not a step in the flow", nodeType)
+
 			default:
 				return fmt.Errorf("invalid node type: %s (must be 'request' or 'delay')", nodeType)
 			}
 
-			// Add node to definition
+			if after != "" {
+				if after, err = resolveNodeID(flow, after); err != nil {
+					return err
+				}
+			}
+
+			// Place the node ourselves when asked to; otherwise let the
+			// backend lay out the whole flow
+			place := after != "" || cmd.Flags().Changed("x") || cmd.Flags().Changed("y")
+			var metadata *api.UpdateFlowRequest_Metadata
+			var position flowbuilder.Position
+			if place {
+				if cmd.Flags().Changed("x") != cmd.Flags().Changed("y") {
+					return fmt.Errorf("--x and --y must be used together")
+				}
+				if cmd.Flags().Changed("x") {
+					position = flowbuilder.Position{X: x, Y: y}
+				} else if position, err = placeNewNode(flow, after); err != nil {
+					return err
+				}
+				metadata = withNodePosition(flow, nodeID, position)
+			}
+
+			edges, err := dependencyEdges(definition, nodeID, dependsOn)
+			if err != nil {
+				return err
+			}
+
+			// Add node and its incoming edges to definition
 			definition.Nodes = append(definition.Nodes, newNode)
+			definition.Edges = append(definition.Edges, edges...)
 
-			// Update flow with auto-layout enabled
-			autoLayout := true
+			autoLayout := !place
 			updateReq := api.UpdateFlowRequest{
 				FlowDefinition: &definition,
 				AutoLayout:     &autoLayout,
+				Metadata:       metadata,
 			}
 
 			// Debug: Print the request being sent
@@ -139,7 +225,7 @@ Examples:
 				fmt.Fprintf(os.Stderr, "[DEBUG] UpdateFlowRequest: %s\n", string(reqJSON))
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -150,6 +236,15 @@ Examples:
 			fmt.Printf("✓ Node added: %s\n", nodeID)
 			fmt.Printf("  Type: %s\n", nodeType)
 			fmt.Printf("  Name: %s\n", name)
+			if place {
+				fmt.Printf("  Position: %s\n", flowbuilder.FormatPosition(position))
+			}
+			if retry != nil {
+				fmt.Printf("  Retry: %d time(s), %dms apart\n", retry.Count, retry.Delay)
+			}
+			for _, edge := range edges {
+				fmt.Printf("  After: %s (%s)\n", edge.Source, edge.Type)
+			}
 
 			return nil
 		},
@@ -157,11 +252,18 @@ Examples:
 
 	cmd.Flags().StringVar(&nodeType, "type", "", "Node type (request or delay)")
 	cmd.Flags().StringVar(&name, "name", "", "Node display name")
-	cmd.Flags().StringVar(&method, "method", "", "HTTP method (for request nodes)")
+	cmd.Flags().StringVar(&method, "method", "", "HTTP method (for request nodes): GET, POST, PUT, PATCH, DELETE, HEAD, or OPTIONS")
 	cmd.Flags().StringVar(&url, "url", "", "Request URL (for request nodes)")
 	cmd.Flags().StringVar(&headers, "headers", "", "HTTP headers as JSON (for request nodes)")
 	cmd.Flags().StringVar(&body, "body", "", "Request body (for request nodes)")
+	cmd.Flags().StringVar(&contentType, "content-type", "", contentTypeFlagUsage)
 	cmd.Flags().IntVar(&duration, "duration", 0, "Delay duration in milliseconds (for delay nodes)")
+	cmd.Flags().StringVar(&after, "after", "", "Place the node one level below this node ID")
+	cmd.Flags().IntVar(&x, "x", 0, "Horizontal editor position (use with --y)")
+	cmd.Flags().IntVar(&y, "y", 0, "Vertical editor position (use with --x)")
+	cmd.Flags().StringArrayVar(&dependsOn, "depends-on", nil, "Add an edge from this node ID, as <node-id>[:success|failure] (repeatable; default success)")
+	cmd.Flags().IntVar(&retryCount, "retry-count", 0, "Re-run the node up to this many times when its assertions fail")
+	cmd.Flags().IntVar(&retryDelay, "retry-delay", 0, "Milliseconds to wait before each retry (use with --retry-count)")
 
 	_ = cmd.MarkFlagRequired("type")
 	_ = cmd.MarkFlagRequired("name")
@@ -169,26 +271,48 @@ Examples:
 	return cmd
 }
 
+// retryPolicy builds a node's retry policy from --retry-count and
+// --retry-delay, or returns nil when neither is set
+func retryPolicy(cmd *cobra.Command, count, delay int) (*api.RetryPolicy, error) {
+	if !cmd.Flags().Changed("retry-count") && !cmd.Flags().Changed("retry-delay") {
+		return nil, nil
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("--retry-count must not be negative")
+	}
+	if delay < 0 {
+		return nil, fmt.Errorf("--retry-delay must not be negative")
+	}
+	if count == 0 && delay > 0 {
+		return nil, fmt.Errorf("--retry-delay requires --retry-count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return &api.RetryPolicy{Count: count, Delay: delay}, nil
+}
+
 // newFlowNodeRemoveCmd removes a node from a flow
 func newFlowNodeRemoveCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove <flow-id> <node-id>",
-		Short: "Remove a node from the flow",
-		Args:  cobra.ExactArgs(2),
+		Use:         "remove <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Remove a node from the flow",
+		Args:        cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -197,6 +321,9 @@ func newFlowNodeRemoveCmd(state *AppState) *cobra.Command {
 			}
 
 			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
 			definition := flow.FlowDefinition
 
 			// Find and remove node
@@ -242,7 +369,7 @@ func newFlowNodeRemoveCmd(state *AppState) *cobra.Command {
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -259,26 +386,44 @@ func newFlowNodeRemoveCmd(state *AppState) *cobra.Command {
 
 // newFlowNodeUpdateCmd updates a node's properties
 func newFlowNodeUpdateCmd(state *AppState) *cobra.Command {
-	var name, method, url string
+	var name, method, url, body, contentType string
 
 	cmd := &cobra.Command{
-		Use:   "update <flow-id> <node-id>",
-		Short: "Update a node's properties",
-		Args:  cobra.ExactArgs(2),
+		Use:         "update <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Update a node's properties",
+		Long: `Update a node's properties. Only the given flags are changed.
+
+Setting --body to JSON also sets "Content-Type: application/json" when the
+node has no Content-Type header; pass --content-type to choose another type
+or --content-type none to leave the headers alone.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
 
+			if method != "" {
+				if err := validateMethod(method); err != nil {
+					return err
+				}
+				method = strings.ToUpper(method)
+			}
+			if url != "" {
+				if err := validateRequestURL(url); err != nil {
+					return err
+				}
+			}
+
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -287,6 +432,9 @@ func newFlowNodeUpdateCmd(state *AppState) *cobra.Command {
 			}
 
 			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
 			definition := flow.FlowDefinition
 
 			// Find and update node
@@ -305,6 +453,12 @@ func newFlowNodeUpdateCmd(state *AppState) *cobra.Command {
 						if url != "" {
 							n.Data.Url = url
 						}
+						if cmd.Flags().Changed("body") {
+							n.Data.Body = &body
+						}
+						if cmd.Flags().Changed("body") || contentType != "" {
+							n.Data.Headers = withContentType(n.Data.Headers, body, contentType)
+						}
 						definition.Nodes[i].FromRequestFlowNode(n)
 						found = true
 					}
@@ -330,7 +484,7 @@ func newFlowNodeUpdateCmd(state *AppState) *cobra.Command {
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -347,6 +501,8 @@ func newFlowNodeUpdateCmd(state *AppState) *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "New display name")
 	cmd.Flags().StringVar(&method, "method", "", "New HTTP method (request nodes only)")
 	cmd.Flags().StringVar(&url, "url", "", "New URL (request nodes only)")
+	cmd.Flags().StringVar(&body, "body", "", "New request body (request nodes only)")
+	cmd.Flags().StringVar(&contentType, "content-type", "", contentTypeFlagUsage)
 
 	return cmd
 }
@@ -361,6 +517,7 @@ func newFlowNodeOutputCmd(state *AppState) *cobra.Command {
 	cmd.AddCommand(
 		newFlowNodeOutputAddCmd(state),
 		newFlowNodeOutputRemoveCmd(state),
+		newFlowNodeOutputPreviewCmd(state),
 	)
 
 	return cmd
@@ -368,12 +525,13 @@ func newFlowNodeOutputCmd(state *AppState) *cobra.Command {
 
 // newFlowNodeOutputAddCmd adds an output to a node
 func newFlowNodeOutputAddCmd(state *AppState) *cobra.Command {
-	var name, extractorType, path, headerName string
+	var name, extractorType, path, headerName, as string
 
 	cmd := &cobra.Command{
-		Use:   "add <flow-id> <node-id>",
-		Short: "Add an output to a node",
-		Args:  cobra.ExactArgs(2),
+		Use:         "add <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Add an output to a node",
+		Args:        cobra.ExactArgs(2),
 		Long: `Add an output extractor to a node.
 
 Examples:
@@ -387,15 +545,20 @@ Examples:
   echopoint flows node output add <flow-id> <node-id> --name "response" --extractor body
 
   # Add a header extractor
-  echopoint flows node output add <flow-id> <node-id> --name "contentType" --extractor header --header-name "Content-Type"`,
+  echopoint flows node output add <flow-id> <node-id> --name "contentType" --extractor header --header-name "Content-Type"
+
+  # Extract a number so {{node.count}} is not quoted when used in a JSON body
+  echopoint flows node output add <flow-id> <node-id> --name "count" --extractor jsonPath --path "$.total" --as number
+
+Values are extracted as strings unless --as names another type.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
@@ -405,9 +568,13 @@ Examples:
 			if !containsString(validExtractors, extractorType) {
 				return fmt.Errorf("invalid extractor type: %s (must be one of: %v)", extractorType, validExtractors)
 			}
+			validTypes := []string{"string", "number", "boolean", "json"}
+			if as != "" && !containsString(validTypes, as) {
+				return fmt.Errorf("invalid --as type: %s (must be one of: %v)", as, validTypes)
+			}
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -416,6 +583,9 @@ Examples:
 			}
 
 			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
 			definition := flow.FlowDefinition
 
 			// Find node and add output
@@ -425,24 +595,7 @@ Examples:
 				switch n := nodeData.(type) {
 				case api.RequestFlowNode:
 					if n.Id == nodeID {
-						newOutput := api.Output{
-							Name: name,
-							Extractor: struct {
-								HeaderName *string           `json:"header_name,omitempty"`
-								Path       *string           `json:"path,omitempty"`
-								Type       api.ExtractorType `json:"type"`
-							}{
-								Type: api.ExtractorType(extractorType),
-							},
-						}
-
-						if path != "" {
-							newOutput.Extractor.Path = &path
-						}
-						if headerName != "" {
-							newOutput.Extractor.HeaderName = &headerName
-						}
-
+						newOutput := newNodeOutput(name, extractorType, path, headerName, as)
 						if n.Outputs == nil {
 							outputs := []api.Output{newOutput}
 							n.Outputs = &outputs
@@ -455,24 +608,7 @@ Examples:
 					}
 				case api.DelayFlowNode:
 					if n.Id == nodeID {
-						newOutput := api.Output{
-							Name: name,
-							Extractor: struct {
-								HeaderName *string           `json:"header_name,omitempty"`
-								Path       *string           `json:"path,omitempty"`
-								Type       api.ExtractorType `json:"type"`
-							}{
-								Type: api.ExtractorType(extractorType),
-							},
-						}
-
-						if path != "" {
-							newOutput.Extractor.Path = &path
-						}
-						if headerName != "" {
-							newOutput.Extractor.HeaderName = &headerName
-						}
-
+						newOutput := newNodeOutput(name, extractorType, path, headerName, as)
 						if n.Outputs == nil {
 							outputs := []api.Output{newOutput}
 							n.Outputs = &outputs
@@ -497,7 +633,7 @@ Examples:
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -507,6 +643,9 @@ Examples:
 
 			fmt.Printf("✓ Output added: %s\n", name)
 			fmt.Printf("  Extractor: %s\n", extractorType)
+			if as != "" {
+				fmt.Printf("  Type: %s\n", as)
+			}
 
 			return nil
 		},
@@ -516,6 +655,7 @@ Examples:
 	cmd.Flags().StringVar(&extractorType, "extractor", "", "Extractor type (jsonPath, statusCode, body, header)")
 	cmd.Flags().StringVar(&path, "path", "", "Path for jsonPath extractor")
 	cmd.Flags().StringVar(&headerName, "header-name", "", "Header name for header extractor")
+	cmd.Flags().StringVar(&as, "as", "", "Type to coerce the value to (string, number, boolean, json); default string")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("extractor")
@@ -523,27 +663,88 @@ Examples:
 	return cmd
 }
 
+// dependencyEdges builds the edges into nodeID described by --depends-on
+// specs of the form <node-id>[:success|failure]. Every source must already be
+// in definition; sources may be abbreviated.
+func dependencyEdges(definition api.FlowDefinition, nodeID string, specs []string) ([]api.FlowEdge, error) {
+	existing := flowNodeIDs(&api.Flow{FlowDefinition: definition})
+
+	edges := make([]api.FlowEdge, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		source, edgeType, found := strings.Cut(spec, ":")
+		if !found {
+			edgeType = "success"
+		}
+		if !containsString([]string{"success", "failure"}, edgeType) {
+			return nil, fmt.Errorf("invalid edge type in --depends-on %q: %s (must be 'success' or 'failure')", spec, edgeType)
+		}
+		source, err := matchIDPrefix("--depends-on node", source, existing)
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(existing, source) {
+			return nil, fmt.Errorf("--depends-on node not found: %s", source)
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("--depends-on lists %s more than once", source)
+		}
+		seen[source] = true
+
+		edgeUUID, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate edge ID: %w", err)
+		}
+		edges = append(edges, api.FlowEdge{
+			Id:     edgeUUID.String(),
+			Source: source,
+			Target: nodeID,
+			Type:   api.FlowEdgeType(edgeType),
+		})
+	}
+	return edges, nil
+}
+
+// newNodeOutput builds an output from the output add flags. An empty as
+// leaves the type unset, which the backend treats as string.
+func newNodeOutput(name, extractorType, path, headerName, as string) api.Output {
+	output := api.Output{Name: name}
+	output.Extractor.Type = api.ExtractorType(extractorType)
+	if path != "" {
+		output.Extractor.Path = &path
+	}
+	if headerName != "" {
+		output.Extractor.HeaderName = &headerName
+	}
+	if as != "" {
+		outputType := api.OutputExtractorAs(as)
+		output.Extractor.As = &outputType
+	}
+	return output
+}
+
 // newFlowNodeOutputRemoveCmd removes an output from a node
 func newFlowNodeOutputRemoveCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove <flow-id> <node-id> <output-name>",
-		Short: "Remove an output from a node",
-		Args:  cobra.ExactArgs(3),
+		Use:         "remove <flow-id> <node-id> <output-name>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Remove an output from a node",
+		Args:        cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
 			outputName := args[2]
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -552,6 +753,9 @@ func newFlowNodeOutputRemoveCmd(state *AppState) *cobra.Command {
 			}
 
 			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
 			definition := flow.FlowDefinition
 
 			// Find node and remove output
@@ -603,7 +807,7 @@ func newFlowNodeOutputRemoveCmd(state *AppState) *cobra.Command {
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -636,11 +840,14 @@ func newFlowNodeAssertionCmd(state *AppState) *cobra.Command {
 // newFlowNodeAssertionAddCmd adds an assertion to a node
 func newFlowNodeAssertionAddCmd(state *AppState) *cobra.Command {
 	var extractorType, path, operatorType, value string
+	var minValue, maxValue string
+	var extractorDataFile, operatorDataFile string
 
 	cmd := &cobra.Command{
-		Use:   "add <flow-id> <node-id>",
-		Short: "Add an assertion to a node",
-		Args:  cobra.ExactArgs(2),
+		Use:         "add <flow-id> <node-id>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Add an assertion to a node",
+		Args:        cobra.ExactArgs(2),
 		Long: `Add an assertion to validate node execution.
 
 Examples:
@@ -653,15 +860,28 @@ Examples:
   # Assert response contains string
   echopoint flows node assertion add <flow-id> <node-id> --extractor body --operator contains --value "success"
 
-Available operators: equals, notEquals, contains, notContains, greaterThan, lessThan, empty, notEmpty`,
+  # Assert a JSONPath number lies within a range, bounds included
+  echopoint flows node assertion add <flow-id> <node-id> --extractor jsonPath --path "$.total" --operator between --min 1 --max 100
+
+  # Compare against a nested object kept in a file
+  echopoint flows node assertion add <flow-id> <node-id> --extractor jsonPath --path "$.user" --operator equals --operator-data-file expected.json
+
+--extractor-data-file and --operator-data-file read a JSON object (or "-" for
+stdin) that becomes the assertion's extractorData or operatorData, for
+payloads that --path and --value cannot express. --path and --value are
+applied on top and win over the same keys in the files.
+
+Available operators: equals, notEquals, contains, notContains, startsWith,
+endsWith, empty, notEmpty, regex, greaterThan, greaterThanOrEqual, lessThan,
+lessThanOrEqual, between`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
@@ -687,34 +907,55 @@ Available operators: equals, notEquals, contains, notContains, greaterThan, less
 				"startsWith",
 				"endsWith",
 				"regex",
+				"between",
 			}
 			if !containsString(validOperators, operatorType) {
 				return fmt.Errorf("invalid operator type: %s (must be one of: %v)", operatorType, validOperators)
 			}
 
-			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
-			if err != nil {
-				return fmt.Errorf("failed to get flow: %w", err)
-			}
-			if resp.JSON200 == nil {
-				return formatAPIError(resp.HTTPResponse, resp.Body)
+			if extractorDataFile == stdinPath && operatorDataFile == stdinPath {
+				return fmt.Errorf("only one of --extractor-data-file and --operator-data-file can read stdin")
 			}
 
-			flow := resp.JSON200
-			definition := flow.FlowDefinition
-
 			// Build extractor data
 			extractorData := make(map[string]interface{})
+			if extractorDataFile != "" {
+				if extractorData, err = loadJSONObjectFile(extractorDataFile); err != nil {
+					return fmt.Errorf("invalid --extractor-data-file: %w", err)
+				}
+			}
 			if path != "" {
 				extractorData["path"] = path
 			}
 
 			// Build operator data
 			operatorData := make(map[string]interface{})
+			if operatorDataFile != "" {
+				if operatorData, err = loadJSONObjectFile(operatorDataFile); err != nil {
+					return fmt.Errorf("invalid --operator-data-file: %w", err)
+				}
+			}
 			if value != "" {
 				operatorData["value"] = value
 			}
+			if err := setRange(operatorData, operatorType, minValue, maxValue); err != nil {
+				return err
+			}
+
+			// Get current flow
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+			if err != nil {
+				return fmt.Errorf("failed to get flow: %w", err)
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
+			definition := flow.FlowDefinition
 
 			// Find node and add assertion
 			found := false
@@ -754,7 +995,7 @@ Available operators: equals, notEquals, contains, notContains, greaterThan, less
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -768,6 +1009,9 @@ Available operators: equals, notEquals, contains, notContains, greaterThan, less
 			if value != "" {
 				fmt.Printf("  Value: %s\n", value)
 			}
+			if strings.EqualFold(operatorType, string(api.Between)) {
+				fmt.Printf("  Range: %v to %v\n", operatorData["min"], operatorData["max"])
+			}
 
 			return nil
 		},
@@ -778,9 +1022,17 @@ Available operators: equals, notEquals, contains, notContains, greaterThan, less
 	cmd.Flags().StringVar(
 		&path, "path", "", "Path for jsonPath extractor")
 	cmd.Flags().StringVar(
-		&operatorType, "operator", "", "Operator type (equals, notEquals, contains, etc.)")
+		&operatorType, "operator", "", "Operator type (equals, contains, regex, greaterThan, between, etc.)")
 	cmd.Flags().StringVar(
 		&value, "value", "", "Expected value for comparison")
+	cmd.Flags().StringVar(
+		&minValue, "min", "", "Lower bound for the between operator (inclusive)")
+	cmd.Flags().StringVar(
+		&maxValue, "max", "", "Upper bound for the between operator (inclusive)")
+	cmd.Flags().StringVar(
+		&extractorDataFile, "extractor-data-file", "", "JSON object file used as extractorData (- for stdin)")
+	cmd.Flags().StringVar(
+		&operatorDataFile, "operator-data-file", "", "JSON object file used as operatorData (- for stdin)")
 
 	_ = cmd.MarkFlagRequired("extractor")
 	_ = cmd.MarkFlagRequired("operator")
@@ -788,20 +1040,57 @@ Available operators: equals, notEquals, contains, notContains, greaterThan, less
 	return cmd
 }
 
+// setRange stores --min and --max as numbers in operatorData. Only between
+// takes a range, and it needs both bounds, from the flags or the data file.
+func setRange(operatorData map[string]interface{}, operatorType, minValue, maxValue string) error {
+	between := strings.EqualFold(operatorType, string(api.Between))
+	if !between {
+		if minValue != "" || maxValue != "" {
+			return fmt.Errorf("--min and --max only apply to --operator between")
+		}
+		return nil
+	}
+
+	for _, bound := range []struct{ flag, key, value string }{
+		{"--min", "min", minValue},
+		{"--max", "max", maxValue},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(bound.value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %q is not a number", bound.flag, bound.value)
+		}
+		operatorData[bound.key] = n
+	}
+
+	low, lowOK := operatorData["min"].(float64)
+	high, highOK := operatorData["max"].(float64)
+	if !lowOK || !highOK {
+		return fmt.Errorf("--operator between needs numeric --min and --max")
+	}
+	if low > high {
+		return fmt.Errorf("--min %v is greater than --max %v", low, high)
+	}
+	return nil
+}
+
 // newFlowNodeAssertionRemoveCmd removes an assertion from a node
 func newFlowNodeAssertionRemoveCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove <flow-id> <node-id> <index>",
-		Short: "Remove an assertion from a node by index",
-		Args:  cobra.ExactArgs(3),
+		Use:         "remove <flow-id> <node-id> <index>",
+		Annotations: map[string]string{flowArgAnnotation: "", nodeArgAnnotation: ""},
+		Short:       "Remove an assertion from a node by index",
+		Args:        cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := googleuuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
 			nodeID := args[1]
@@ -812,7 +1101,7 @@ func newFlowNodeAssertionRemoveCmd(state *AppState) *cobra.Command {
 			}
 
 			// Get current flow
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -821,6 +1110,9 @@ func newFlowNodeAssertionRemoveCmd(state *AppState) *cobra.Command {
 			}
 
 			flow := resp.JSON200
+			if nodeID, err = resolveNodeID(flow, nodeID); err != nil {
+				return err
+			}
 			definition := flow.FlowDefinition
 
 			// Find node and remove assertion
@@ -858,7 +1150,7 @@ func newFlowNodeAssertionRemoveCmd(state *AppState) *cobra.Command {
 				AutoLayout:     &autoLayout,
 			}
 
-			updateResp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), flowID, updateReq)
+			updateResp, err := updateFlow(state, flowID, updateReq)
 			if err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
@@ -888,6 +1180,87 @@ func parseHeaders(headers string) *map[string]string {
 	return &result
 }
 
+// validHTTPMethods are the methods requests, request nodes and webhooks accept
+var validHTTPMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// validateMethod checks method case-insensitively against validHTTPMethods
+func validateMethod(method string) error {
+	if containsString(validHTTPMethods, strings.ToUpper(method)) {
+		return nil
+	}
+	return fmt.Errorf("invalid method %q (%s)", method, strings.Join(validHTTPMethods, ", "))
+}
+
+// validateRequestURL checks that raw is an absolute http or https URL. A
+// URL that starts with a {{variable}} is accepted as is, since the variable
+// supplies the scheme and host at run time; placeholders elsewhere are
+// checked as "0" so they can fill a host, port, or path segment.
+func validateRequestURL(raw string) error {
+	refs := interpolate.Scan(raw)
+	if len(refs) > 0 && refs[0].Start == 0 {
+		return nil
+	}
+
+	expanded := raw
+	for i := len(refs) - 1; i >= 0; i-- {
+		expanded = expanded[:refs[i].Start] + "0" + expanded[refs[i].End:]
+	}
+
+	parsed, err := neturl.Parse(expanded)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", raw, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("invalid URL %q: must start with http:// or https:// (or a {{variable}})", raw)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", raw)
+	}
+	return nil
+}
+
+// contentTypeFlagUsage is the help text for --content-type on node commands
+const contentTypeFlagUsage = "Content-Type header to set (default: application/json for JSON bodies; \"none\" to skip)"
+
+// withContentType returns headers with a Content-Type set. An explicit
+// contentType always wins, "none" leaves headers untouched, and otherwise
+// application/json is added when body is JSON and no Content-Type is present.
+func withContentType(headers *map[string]string, body, contentType string) *map[string]string {
+	if strings.EqualFold(contentType, "none") {
+		return headers
+	}
+
+	result := make(map[string]string)
+	if headers != nil {
+		for key, value := range *headers {
+			result[key] = value
+		}
+	}
+
+	key := findHeader(result, "Content-Type")
+	switch {
+	case contentType != "":
+		if key != "" {
+			delete(result, key)
+		}
+		result["Content-Type"] = contentType
+	case key == "" && looksLikeJSON(body):
+		result["Content-Type"] = "application/json"
+	default:
+		return headers
+	}
+	return &result
+}
+
+// looksLikeJSON reports whether body is a JSON object or array
+func looksLikeJSON(body string) bool {
+	trimmed := strings.TrimSpace(body)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+	return json.Valid([]byte(trimmed))
+}
+
 // Helper function to check if string is in slice
 func containsString(slice []string, s string) bool {
 	for _, item := range slice {
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateRequestURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{url: "https://api.example.com/users"},
+		{url: "http://localhost:8080"},
+		{url: "{{baseUrl}}/users"},
+		{url: "{{ baseUrl }}"},
+		{url: "https://{{host}}/users/{{id}}"},
+		{url: "http://localhost:{{port}}/health"},
+		{url: "https://api.example.com/orders/{{order.id}}?page={{page}}"},
+
+		{url: "htps://typo.example.com", wantErr: "must start with http:// or https://"},
+		{url: "api.example.com/users", wantErr: "must start with http:// or https://"},
+		{url: "/users", wantErr: "must start with http:// or https://"},
+		{url: "ftp://files.example.com", wantErr: "must start with http:// or https://"},
+		{url: "users/{{id}}", wantErr: "must start with http:// or https://"},
+		{url: "https:///users", wantErr: "missing host"},
+		{url: "http://exa mple.com", wantErr: "invalid URL"},
+		{url: "https://api.example.com/%zz", wantErr: "invalid URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := validateRequestURL(tt.url)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateRequestURL: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("validateRequestURL error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMethod(t *testing.T) {
+	for _, method := range []string{"GET", "post", "Patch", "OPTIONS"} {
+		if err := validateMethod(method); err != nil {
+			t.Errorf("validateMethod(%q): %v", method, err)
+		}
+	}
+	for _, method := range []string{"", "FETCH", "CONNECT"} {
+		if err := validateMethod(method); err == nil {
+			t.Errorf("validateMethod(%q) accepted an unsupported method", method)
+		}
+	}
+}
+
+func TestSetRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator string
+		min, max string
+		data     map[string]interface{}
+		want     map[string]interface{}
+		wantErr  string
+	}{
+		{name: "between", operator: "between", min: "200", max: "299.5", want: map[string]interface{}{"min": 200.0, "max": 299.5}},
+		{name: "any case", operator: "Between", min: "-1", max: "1", want: map[string]interface{}{"min": -1.0, "max": 1.0}},
+		{name: "equal bounds", operator: "between", min: "5", max: "5", want: map[string]interface{}{"min": 5.0, "max": 5.0}},
+		{
+			name: "flag overrides data file", operator: "between", max: "10",
+			data: map[string]interface{}{"min": 1.0, "max": 99.0},
+			want: map[string]interface{}{"min": 1.0, "max": 10.0},
+		},
+		{name: "other operator", operator: "equals", data: map[string]interface{}{"value": "x"}, want: map[string]interface{}{"value": "x"}},
+
+		{name: "missing max", operator: "between", min: "1", wantErr: "needs numeric --min and --max"},
+		{name: "non-numeric data file bound", operator: "between", min: "1", data: map[string]interface{}{"max": "ten"}, wantErr: "needs numeric --min and --max"},
+		{name: "not a number", operator: "between", min: "low", max: "5", wantErr: `invalid --min: "low" is not a number`},
+		{name: "reversed", operator: "between", min: "10", max: "1", wantErr: "--min 10 is greater than --max 1"},
+		{name: "range on another operator", operator: "greaterThan", min: "1", wantErr: "only apply to --operator between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			if data == nil {
+				data = map[string]interface{}{}
+			}
+			err := setRange(data, tt.operator, tt.min, tt.max)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("setRange error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setRange: %v", err)
+			}
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("operator data = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
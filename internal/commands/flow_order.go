@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// orderedNode is a node in the execution order
+type orderedNode struct {
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// flowOrder is the execution order of a flow, one entry per level
+type flowOrder struct {
+	Levels      [][]orderedNode `json:"levels" yaml:"levels"`
+	CycleEdges  []string        `json:"cycle_edges" yaml:"cycle_edges"`
+	Unreachable []string        `json:"unreachable" yaml:"unreachable"`
+}
+
+func newFlowOrderCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "order <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Show the order in which a flow's nodes run",
+		Long: `Show the execution order of a flow's nodes.
+
+Nodes are grouped by level: level 1 holds the nodes with no incoming edges,
+and every other node runs one level after its last predecessor. Nodes on the
+same level can run in parallel.
+
+Edges that close a cycle are ignored for ordering and listed, as are nodes
+that cannot be reached from any node without incoming edges.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			order := computeFlowOrder(resp.JSON200)
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, order)
+			case output.FormatYAML:
+				return printYAML(state, order)
+			default:
+				printFlowOrder(order)
+				return nil
+			}
+		},
+	}
+
+	return cmd
+}
+
+// computeFlowOrder groups the nodes of flow by execution level
+func computeFlowOrder(flow *api.Flow) flowOrder {
+	order := flowOrder{
+		Levels:      [][]orderedNode{},
+		CycleEdges:  []string{},
+		Unreachable: []string{},
+	}
+
+	placements := make([]flowbuilder.NodePlacement, 0, len(flow.FlowDefinition.Nodes))
+	keys := newNodeKeys()
+	nodes := make(map[uuid.UUID]orderedNode)
+	for _, node := range flow.FlowDefinition.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+
+		var entry orderedNode
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			entry = orderedNode{ID: n.Id, Name: n.DisplayName, Type: n.Type, Disabled: isDisabled(n.Disabled)}
+		case api.DelayFlowNode:
+			entry = orderedNode{ID: n.Id, Name: n.DisplayName, Type: n.Type, Disabled: isDisabled(n.Disabled)}
+		default:
+			continue
+		}
+
+		key := keys.add(entry.ID)
+		nodes[key] = entry
+		placements = append(placements, flowbuilder.NodePlacement{ID: key})
+	}
+
+	edges := make([]flowbuilder.Edge, 0, len(flow.FlowDefinition.Edges))
+	incoming := make(map[string]int)
+	outgoing := make(map[string][]string)
+	for _, edge := range flow.FlowDefinition.Edges {
+		from, fromOK := keys.key(edge.Source)
+		to, toOK := keys.key(edge.Target)
+		if !fromOK || !toOK {
+			continue
+		}
+		edges = append(edges, flowbuilder.Edge{From: from, To: to})
+		incoming[edge.Target]++
+		outgoing[edge.Source] = append(outgoing[edge.Source], edge.Target)
+	}
+
+	groups, backEdges := flowbuilder.LevelGroups(placements, edges)
+	for _, group := range groups {
+		level := make([]orderedNode, 0, len(group))
+		for _, key := range group {
+			level = append(level, nodes[key])
+		}
+		order.Levels = append(order.Levels, level)
+	}
+	for _, edge := range backEdges {
+		order.CycleEdges = append(order.CycleEdges, fmt.Sprintf("%s -> %s", nodes[edge.From].ID, nodes[edge.To].ID))
+	}
+
+	// A run starts at the nodes without incoming edges; anything those do
+	// not lead to, such as a cycle with no way in, never runs
+	reached := make(map[string]bool)
+	var queue []string
+	for _, placement := range placements {
+		if nodeID := nodes[placement.ID].ID; incoming[nodeID] == 0 {
+			reached[nodeID] = true
+			queue = append(queue, nodeID)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range outgoing[current] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	for _, placement := range placements {
+		if nodeID := nodes[placement.ID].ID; !reached[nodeID] {
+			order.Unreachable = append(order.Unreachable, nodeID)
+		}
+	}
+
+	return order
+}
+
+func printFlowOrder(order flowOrder) {
+	if len(order.Levels) == 0 {
+		fmt.Fprintln(os.Stdout, "No nodes")
+		return
+	}
+
+	for i, level := range order.Levels {
+		label := fmt.Sprintf("Level %d", i+1)
+		if len(level) > 1 {
+			label += " (parallel)"
+		}
+		fmt.Fprintf(os.Stdout, "%s:\n", label)
+		for _, node := range level {
+			suffix := ""
+			if node.Disabled {
+				suffix = " [disabled]"
+			}
+			fmt.Fprintf(os.Stdout, "  %s  %s (%s)%s\n", node.ID, node.Name, node.Type, suffix)
+		}
+	}
+
+	printIDList("Cycle edges", order.CycleEdges)
+	printIDList("Unreachable nodes", order.Unreachable)
+}
@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+
+	"github.com/google/uuid"
+)
+
+// flowNodePosition matches the element type of the metadata node_positions map
+type flowNodePosition = struct {
+	X *float32 `json:"x,omitempty"`
+	Y *float32 `json:"y,omitempty"`
+}
+
+// flowPlacements converts the saved node positions of flow into placements for
+// the flowbuilder grid, identified by the returned keys. Nodes without a saved
+// position are skipped.
+func flowPlacements(flow *api.Flow, grid *flowbuilder.Grid) ([]flowbuilder.NodePlacement, []flowbuilder.Edge, *nodeKeys) {
+	keys := flowNodeKeys(flow)
+	var positions map[string]flowNodePosition
+	if flow.Metadata.NodePositions != nil {
+		positions = *flow.Metadata.NodePositions
+	}
+
+	nodeIDs := make([]string, 0, len(positions))
+	for nodeID := range positions {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	var placements []flowbuilder.NodePlacement
+	for _, nodeID := range nodeIDs {
+		pos := positions[nodeID]
+		if pos.X == nil || pos.Y == nil {
+			continue
+		}
+		placements = append(placements, flowbuilder.NodePlacement{
+			ID:       keys.add(nodeID),
+			Position: flowbuilder.Position{X: int(*pos.X), Y: int(*pos.Y)},
+			Width:    grid.NodeWidth,
+			Height:   grid.NodeHeight,
+		})
+	}
+
+	return placements, flowEdges(flow, keys), keys
+}
+
+// placeNewNode picks a position for a node about to be added to flow, one
+// level below after when it is set and beside the top level otherwise
+func placeNewNode(flow *api.Flow, after string) (flowbuilder.Position, error) {
+	grid := flowbuilder.NewGrid()
+	placements, edges, keys := flowPlacements(flow, grid)
+
+	var connectedFrom []uuid.UUID
+	if after != "" {
+		if !flowHasNode(flow, after) {
+			return flowbuilder.Position{}, fmt.Errorf("node not found: %s", after)
+		}
+		afterKey, _ := keys.key(after)
+		connectedFrom = []uuid.UUID{afterKey}
+	}
+
+	return grid.CalculateNewNodePosition(placements, edges, connectedFrom), nil
+}
+
+// flowHasNode reports whether the flow definition contains a node with id
+func flowHasNode(flow *api.Flow, id string) bool {
+	return containsString(flowNodeIDs(flow), id)
+}
+
+// flowNodeIDs lists the IDs of the request and delay nodes in flow
+func flowNodeIDs(flow *api.Flow) []string {
+	ids := make([]string, 0, len(flow.FlowDefinition.Nodes))
+	for _, node := range flow.FlowDefinition.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			ids = append(ids, n.Id)
+		case api.DelayFlowNode:
+			ids = append(ids, n.Id)
+		}
+	}
+	return ids
+}
+
+// nodeKeys gives flow nodes the UUIDs the flowbuilder algorithms identify
+// them by, since node IDs are free-form strings such as "login", and maps
+// them back. An ID that is a UUID keeps it; any other gets one derived from
+// the ID, so a flow always maps, and lays out, the same way.
+type nodeKeys struct {
+	keys map[string]uuid.UUID
+	ids  map[uuid.UUID]string
+}
+
+func newNodeKeys() *nodeKeys {
+	return &nodeKeys{keys: make(map[string]uuid.UUID), ids: make(map[uuid.UUID]string)}
+}
+
+// flowNodeKeys returns keys for the request and delay nodes of flow
+func flowNodeKeys(flow *api.Flow) *nodeKeys {
+	keys := newNodeKeys()
+	for _, nodeID := range flowNodeIDs(flow) {
+		keys.add(nodeID)
+	}
+	return keys
+}
+
+// add returns the key for nodeID, assigning one on first use
+func (k *nodeKeys) add(nodeID string) uuid.UUID {
+	if key, ok := k.keys[nodeID]; ok {
+		return key
+	}
+	key, err := uuid.Parse(nodeID)
+	if err != nil {
+		key = uuid.NewSHA1(uuid.NameSpaceOID, []byte(nodeID))
+	}
+	k.keys[nodeID] = key
+	k.ids[key] = nodeID
+	return key
+}
+
+// key returns the key of nodeID, if it has one
+func (k *nodeKeys) key(nodeID string) (uuid.UUID, bool) {
+	key, ok := k.keys[nodeID]
+	return key, ok
+}
+
+// id returns the node ID behind key
+func (k *nodeKeys) id(key uuid.UUID) string {
+	return k.ids[key]
+}
+
+// flowEdges converts the edges of flow for the flowbuilder grid, skipping any
+// whose endpoints have no key
+func flowEdges(flow *api.Flow, keys *nodeKeys) []flowbuilder.Edge {
+	return weightedFlowEdges(flow, keys, nil)
+}
+
+// weightedFlowEdges is flowEdges with each edge weighted by its type; types
+// missing from weights keep the default weight
+func weightedFlowEdges(flow *api.Flow, keys *nodeKeys, weights map[api.FlowEdgeType]int) []flowbuilder.Edge {
+	var edges []flowbuilder.Edge
+	for _, edge := range flow.FlowDefinition.Edges {
+		from, fromOK := keys.key(edge.Source)
+		to, toOK := keys.key(edge.Target)
+		if !fromOK || !toOK {
+			continue
+		}
+		edges = append(edges, flowbuilder.Edge{From: from, To: to, Weight: weights[edge.Type]})
+	}
+	return edges
+}
+
+// withNodePosition returns the flow's metadata for an update request with
+// the position of nodeID set to pos
+func withNodePosition(flow *api.Flow, nodeID string, pos flowbuilder.Position) *api.UpdateFlowRequest_Metadata {
+	return withNodePositions(flow, map[string]flowbuilder.Position{nodeID: pos})
+}
+
+// withNodePositions returns the flow's metadata for an update request with
+// the given node positions replacing any saved ones
+func withNodePositions(flow *api.Flow, updates map[string]flowbuilder.Position) *api.UpdateFlowRequest_Metadata {
+	positions := make(map[string]flowNodePosition)
+	if flow.Metadata.NodePositions != nil {
+		for key, value := range *flow.Metadata.NodePositions {
+			positions[key] = value
+		}
+	}
+
+	for nodeID, pos := range updates {
+		x, y := float32(pos.X), float32(pos.Y)
+		positions[nodeID] = flowNodePosition{X: &x, Y: &y}
+	}
+
+	return &api.UpdateFlowRequest_Metadata{
+		NodePositions:        &positions,
+		AdditionalProperties: flow.Metadata.AdditionalProperties,
+	}
+}
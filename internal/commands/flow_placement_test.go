@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"echopoint-cli/internal/flowbuilder"
+)
+
+func TestPlaceNewNodeWithStringIDs(t *testing.T) {
+	flow := templateFlow(t, "auth")
+	grid := flowbuilder.NewGrid()
+	positions, _, err := layoutFlow(flow, grid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow.Metadata.NodePositions = withNodePositions(flow, positions).NodePositions
+
+	tests := []struct {
+		name    string
+		after   string
+		wantErr string
+	}{
+		{name: "after a node", after: "login"},
+		{name: "unconnected"},
+		{name: "unknown node", after: "logout", wantErr: "node not found: logout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, err := placeNewNode(flow, tt.after)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("placeNewNode error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("placeNewNode: %v", err)
+			}
+			for nodeID, saved := range positions {
+				if pos.X < saved.X+grid.NodeWidth && saved.X < pos.X+grid.NodeWidth &&
+					pos.Y < saved.Y+grid.NodeHeight && saved.Y < pos.Y+grid.NodeHeight {
+					t.Errorf("new node at %s overlaps %s at %s", flowbuilder.FormatPosition(pos), nodeID, flowbuilder.FormatPosition(saved))
+				}
+			}
+			if tt.after != "" && pos.Y <= positions[tt.after].Y {
+				t.Errorf("new node at %s, want it below %s at %s", flowbuilder.FormatPosition(pos), tt.after, flowbuilder.FormatPosition(positions[tt.after]))
+			}
+		})
+	}
+}
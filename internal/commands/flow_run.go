@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"echopoint-cli/internal/dotenv"
+	"echopoint-cli/internal/output"
+	"echopoint-cli/internal/sse"
+
+	"github.com/spf13/cobra"
+)
+
+// runEvent is one execution event from a flow run stream
+type runEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// runEventData holds the fields shared by the run event payloads
+type runEventData struct {
+	FlowName      string   `json:"flowName"`
+	NodeID        string   `json:"nodeId"`
+	NodeType      string   `json:"nodeType"`
+	Success       *bool    `json:"success"`
+	Duration      *int64   `json:"duration"`
+	Error         string   `json:"error"`
+	ExecutedNodes []string `json:"executedNodes"`
+}
+
+func newFlowRunCmd(state *AppState) *cobra.Command {
+	var (
+		envVars    []string
+		envFiles   []string
+		reportKind string
+		reportFile string
+		quiet      bool
+		file       string
+		maxRuns    int
+	)
+
+	cmd := &cobra.Command{
+		Use:         "run <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Run a flow and stream its execution events",
+		Long: `Run a flow and stream node and flow events as they happen.
+
+Variables passed with --env or loaded with --env-from override the flow's
+stored environment for this run only; nothing is saved. Files are applied in
+order, and --env flags win over every file.
+
+Examples:
+  echopoint flows run <flow-id>
+  echopoint flows run <flow-id> --env-from .env --env-from .env.local
+  echopoint flows run <flow-id> --env-from .env --env API_KEY=override
+  echopoint flows run --file ids.txt --max-concurrent-runs 4
+
+With --output json each event is printed as one JSON object per line.
+
+--report junit --report-file results.xml also writes a JUnit XML report for
+CI, with one test case per executed node. Failed nodes, including assertion
+failures, are reported as <failure> elements. --report tap writes a Test
+Anything Protocol stream instead, to --report-file or, without one, to stdout
+in place of the event output. --quiet hides the event output so only the
+report is produced.
+
+--file runs every flow listed in a file (or stdin when --file is -), one ID
+per line, with the same overrides. At most --max-concurrent-runs flows run at
+once and the rest wait their turn; progress goes to stderr and one result
+line per flow to stdout. Events are not printed in this mode.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			if maxRuns < 1 {
+				return fmt.Errorf("--max-concurrent-runs must be at least 1")
+			}
+			if file != "" {
+				if reportKind != "" {
+					return fmt.Errorf("--report is not supported with --file")
+				}
+				overrides, err := loadEnvOverrides(envFiles, envVars)
+				if err != nil {
+					return err
+				}
+				ctx, stop := signal.NotifyContext(state.Context(), os.Interrupt)
+				defer stop()
+
+				if err := runFlowsFromFile(ctx, state, file, overrides, maxRuns, quiet); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+				return nil
+			}
+
+			flowID, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			switch reportKind {
+			case "":
+				if reportFile != "" {
+					return fmt.Errorf("--report-file requires --report")
+				}
+			case "junit":
+				if reportFile == "" {
+					return fmt.Errorf("--report junit requires --report-file")
+				}
+			case "tap":
+				// Without a file the TAP stream is the only thing on stdout
+				if reportFile == "" {
+					quiet = true
+				}
+			default:
+				return fmt.Errorf("unsupported report format %q (supported: junit, tap)", reportKind)
+			}
+
+			overrides, err := loadEnvOverrides(envFiles, envVars)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(state.Context(), os.Interrupt)
+			defer stop()
+
+			resp, err := state.Client.Stream().LaunchFlow(ctx, flowID, withRunInputs(overrides))
+			if err != nil {
+				return fmt.Errorf("failed to run flow: %w", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				return formatAPIError(resp, body)
+			}
+
+			recorder := newRunRecorder(flowID.String())
+			failed, streamErr := streamRunEvents(state, resp.Body, recorder, quiet)
+
+			// Write the report even for an interrupted run so CI sees what finished
+			if reportKind != "" {
+				if err := writeRunReport(reportKind, reportFile, recorder); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+			}
+
+			if streamErr != nil {
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("run stopped: --deadline exceeded")
+				}
+				if ctx.Err() != nil {
+					return fmt.Errorf("run interrupted")
+				}
+				return fmt.Errorf("failed to read run events: %w", streamErr)
+			}
+			if failed {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("flow run failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Variable override in KEY=value format (can be used multiple times)")
+	cmd.Flags().StringArrayVar(&envFiles, "env-from", nil, "Load variable overrides from a dotenv file (can be used multiple times)")
+	cmd.Flags().StringVar(&reportKind, "report", "", "Write a run report in this format: junit, tap")
+	cmd.Flags().StringVar(&reportFile, "report-file", "", "Path of the run report (tap defaults to stdout)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Do not print run events")
+	cmd.Flags().StringVar(&file, "file", "", "Run every flow listed in this file (one ID per line), or - for stdin")
+	cmd.Flags().IntVar(&maxRuns, "max-concurrent-runs", defaultMaxConcurrentRuns, "Maximum number of flows run at once with --file")
+
+	return cmd
+}
+
+// withRunInputs sends vars as the run's initial inputs. Without overrides the
+// launch request has no body and the stored environment is used as-is.
+func withRunInputs(vars map[string]string) func(ctx context.Context, req *http.Request) error {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", "text/event-stream")
+		if len(vars) == 0 {
+			return nil
+		}
+
+		body, err := json.Marshal(map[string]interface{}{"initialInputs": vars})
+		if err != nil {
+			return err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	}
+}
+
+// streamRunEvents prints events from a run stream until it ends and reports
+// whether the run failed. Every event is passed to recorder; quiet skips
+// printing.
+func streamRunEvents(state *AppState, r io.Reader, recorder *runRecorder, quiet bool) (bool, error) {
+	reader := sse.NewReader(r)
+	failed := false
+
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return failed, nil
+		}
+		if err != nil {
+			return failed, err
+		}
+
+		if event.Type == "node.failed" || event.Type == "flow.failed" {
+			failed = true
+		}
+		recorder.record(event)
+		if quiet {
+			continue
+		}
+
+		if state.OutputFormat == output.FormatJSON {
+			data := json.RawMessage(event.Data)
+			if !json.Valid(data) {
+				data, _ = json.Marshal(event.Data)
+			}
+			line, err := json.Marshal(runEvent{Event: event.Type, Data: data})
+			if err != nil {
+				return failed, err
+			}
+			fmt.Println(string(line))
+			continue
+		}
+
+		printRunEvent(event)
+	}
+}
+
+func printRunEvent(event sse.Event) {
+	var data runEventData
+	_ = json.Unmarshal([]byte(event.Data), &data)
+
+	switch event.Type {
+	case "flow.started":
+		fmt.Printf("▶ Running %s\n", data.FlowName)
+	case "node.started":
+		fmt.Printf("  … %s (%s)\n", data.NodeID, data.NodeType)
+	case "node.completed":
+		fmt.Printf("  ✓ %s%s\n", data.NodeID, formatRunDuration(data.Duration))
+	case "node.failed":
+		fmt.Printf("  ✗ %s%s%s\n", data.NodeID, formatRunDuration(data.Duration), formatRunError(data.Error))
+	case "flow.completed":
+		fmt.Printf("✓ Flow completed: %d node(s)%s\n", len(data.ExecutedNodes), formatRunDuration(data.Duration))
+	case "flow.failed":
+		fmt.Printf("✗ Flow failed%s%s\n", formatRunDuration(data.Duration), formatRunError(data.Error))
+	default:
+		fmt.Printf("  %s %s\n", event.Type, event.Data)
+	}
+}
+
+// formatRunDuration renders a millisecond duration such as " (125ms)"
+func formatRunDuration(ms *int64) string {
+	if ms == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", time.Duration(*ms)*time.Millisecond)
+}
+
+func formatRunError(message string) string {
+	if message == "" {
+		return ""
+	}
+	return ": " + message
+}
+
+// loadEnvOverrides merges the variables of --env-from files, in order, with
+// --env KEY=value pairs, which win over every file
+func loadEnvOverrides(files, pairs []string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, path := range files {
+		vars, err := dotenv.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file: %w", err)
+		}
+		for key, value := range vars {
+			overrides[key] = value
+		}
+	}
+	vars, err := parseKeyValues(pairs)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range vars {
+		overrides[key] = value
+	}
+	return overrides, nil
+}
+
+// parseKeyValues parses KEY=value pairs; later pairs win
+func parseKeyValues(values []string) (map[string]string, error) {
+	vars := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid variable format: %s (expected KEY=value)", v)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
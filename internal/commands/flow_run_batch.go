@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+)
+
+// defaultMaxConcurrentRuns bounds how many flows a batch run executes at once
+const defaultMaxConcurrentRuns = 2
+
+// batchRunResult is the outcome of one flow in a batch run
+type batchRunResult struct {
+	FlowID     string `json:"flow_id"`
+	FlowName   string `json:"flow_name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// runQueue tracks how many runs of a batch are waiting, running, and done,
+// and serializes the progress lines reported as they change
+type runQueue struct {
+	mu      sync.Mutex
+	total   int
+	queued  int
+	running int
+	done    int
+	quiet   bool
+}
+
+func (q *runQueue) start(flowID uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queued--
+	q.running++
+	if !q.quiet {
+		fmt.Fprintf(os.Stderr, "▶ Starting %s (%d running, %d queued)\n", flowID, q.running, q.queued)
+	}
+}
+
+// finish records a finished run and prints its result with the batch position
+func (q *runQueue) finish(state *AppState, result batchRunResult) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.running--
+	q.done++
+
+	if state.OutputFormat == output.FormatJSON {
+		line, _ := json.Marshal(result)
+		fmt.Println(string(line))
+		return
+	}
+	duration := formatRunDuration(&result.DurationMs)
+	switch result.Status {
+	case "passed":
+		fmt.Printf("✓ [%d/%d] %s%s\n", q.done, q.total, result.FlowName, duration)
+	default:
+		fmt.Printf("✗ [%d/%d] %s%s%s\n", q.done, q.total, result.FlowName, duration, formatRunError(result.Error))
+	}
+}
+
+// runFlowsFromFile runs every flow listed in path, at most maxRuns at a time,
+// with the same variable overrides. Runs that have not started when ctx is
+// cancelled are skipped. It returns an error when any run did not pass.
+func runFlowsFromFile(ctx context.Context, state *AppState, path string, overrides map[string]string, maxRuns int, quiet bool) error {
+	var r io.Reader = os.Stdin
+	if path != stdinPath {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ids, err := readFlowIDs(r)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(os.Stdout, "No flow IDs to run.")
+		return nil
+	}
+
+	queue := &runQueue{total: len(ids), queued: len(ids), quiet: quiet}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Running %s, up to %d at a time\n", pluralize(len(ids), "flow"), min(maxRuns, len(ids)))
+	}
+
+	results := make([]batchRunResult, len(ids))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(maxRuns, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// A flow handed out as the batch was cancelled stays unstarted
+				if ctx.Err() != nil {
+					continue
+				}
+				queue.start(ids[i])
+				results[i] = runFlowQuietly(ctx, state, ids[i], overrides)
+				queue.finish(state, results[i])
+			}
+		}()
+	}
+	// select picks at random between ready cases, so a flow could still be
+	// sent after cancellation; checking here and in the worker prevents that
+dispatch:
+	for i := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	passed, failed := 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case "passed":
+			passed++
+		case "":
+			// never started
+		default:
+			failed++
+		}
+	}
+	if state.OutputFormat != output.FormatJSON {
+		fmt.Printf("\n%d of %d flows passed.\n", passed, len(ids))
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("batch stopped: --deadline exceeded after %d of %d flows", passed+failed, len(ids))
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("batch interrupted after %d of %d flows", passed+failed, len(ids))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d flow runs failed", failed, len(ids))
+	}
+	return nil
+}
+
+// runFlowQuietly launches one flow and waits for its run to end without
+// printing its events
+func runFlowQuietly(ctx context.Context, state *AppState, flowID uuid.UUID, overrides map[string]string) (result batchRunResult) {
+	result = batchRunResult{FlowID: flowID.String(), FlowName: flowID.String()}
+	start := time.Now()
+	defer func() {
+		result.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	resp, err := state.Client.Stream().LaunchFlow(ctx, flowID, withRunInputs(overrides))
+	if err != nil {
+		result.Status, result.Error = "error", err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		result.Status, result.Error = "error", formatAPIError(resp, body).Error()
+		return result
+	}
+
+	recorder := newRunRecorder(flowID.String())
+	failed, streamErr := streamRunEvents(state, resp.Body, recorder, true)
+	result.FlowName = recorder.flowName
+	switch {
+	case streamErr != nil:
+		result.Status, result.Error = "error", fmt.Sprintf("failed to read run events: %v", streamErr)
+	case failed:
+		result.Status, result.Error = "failed", recorder.failureMessage()
+	default:
+		result.Status = "passed"
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// launchServer answers flow launches with a passing run and tracks how many
+// runs are in flight at once. Until the first time peak runs overlap, each run
+// is held open, so a batch that respects its limit always reaches it; after
+// that runs still last runHold, long enough for any extra run to overlap.
+type launchServer struct {
+	mu       sync.Mutex
+	inFlight int
+	maxSeen  int
+	launched int
+	peak     int
+	reached  chan struct{}
+}
+
+const runHold = 20 * time.Millisecond
+
+func newLaunchServer(peak int) *launchServer {
+	return &launchServer{peak: peak, reached: make(chan struct{})}
+}
+
+func (s *launchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/launch") {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	s.launched++
+	s.inFlight++
+	s.maxSeen = max(s.maxSeen, s.inFlight)
+	if s.inFlight == s.peak && s.maxSeen == s.peak {
+		select {
+		case <-s.reached:
+		default:
+			close(s.reached)
+		}
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-s.reached:
+	case <-r.Context().Done():
+		return
+	case <-time.After(5 * time.Second):
+	}
+	time.Sleep(runHold)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprintf(w, "event: flow.started\ndata: {\"flowName\": %q}\n\n", launchedFlowName(r))
+	fmt.Fprint(w, "event: flow.completed\ndata: {}\n\n")
+}
+
+// launchedFlowName is the name launchServer reports for the flow launched by r
+func launchedFlowName(r *http.Request) string {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	return "Flow " + segments[len(segments)-2]
+}
+
+func (s *launchServer) stats() (maxSeen, launched int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxSeen, s.launched
+}
+
+// writeFlowIDs writes n random flow IDs to a file, one per line
+func writeFlowIDs(t *testing.T, n int) string {
+	t.Helper()
+	var b strings.Builder
+	for range n {
+		fmt.Fprintln(&b, uuid.New())
+	}
+	path := filepath.Join(t.TempDir(), "flows.txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunFlowsFromFileMaxConcurrentRuns(t *testing.T) {
+	tests := []struct {
+		name    string
+		flows   int
+		maxRuns int
+		want    int
+	}{
+		{name: "one at a time", flows: 5, maxRuns: 1, want: 1},
+		{name: "default", flows: 8, maxRuns: defaultMaxConcurrentRuns, want: defaultMaxConcurrentRuns},
+		{name: "four", flows: 12, maxRuns: 4, want: 4},
+		{name: "more workers than flows", flows: 3, maxRuns: 10, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newLaunchServer(tt.want)
+			state := newTestState(t, server)
+
+			err := runFlowsFromFile(context.Background(), state, writeFlowIDs(t, tt.flows), nil, tt.maxRuns, true)
+			if err != nil {
+				t.Fatalf("runFlowsFromFile: %v", err)
+			}
+			maxSeen, launched := server.stats()
+			if launched != tt.flows {
+				t.Errorf("launched %d flows, want %d", launched, tt.flows)
+			}
+			if maxSeen != tt.want {
+				t.Errorf("at most %d runs were in flight, want %d", maxSeen, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFlowsFromFileStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The only worker stays busy with the first run until the batch is
+	// cancelled, so the remaining flows are never handed out
+	server := newLaunchServer(0)
+	started := make(chan struct{}, 10)
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		server.ServeHTTP(w, r)
+	}))
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err := runFlowsFromFile(ctx, state, writeFlowIDs(t, 4), nil, 1, true)
+	if err == nil || !strings.Contains(err.Error(), "batch interrupted after 1 of 4 flows") {
+		t.Fatalf("runFlowsFromFile error = %v, want the batch interrupted after the first flow", err)
+	}
+	if _, launched := server.stats(); launched != 1 {
+		t.Errorf("server received %d launches, want only the first", launched)
+	}
+}
+
+func TestRunFlowQuietly(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.Handler
+		want    batchRunResult
+	}{
+		{
+			name:    "passed",
+			handler: newLaunchServer(1),
+			want:    batchRunResult{Status: "passed", FlowName: "Flow {id}"},
+		},
+		{
+			name: "failed",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprintf(w, "event: flow.started\ndata: {\"flowName\": %q}\n\n", launchedFlowName(r))
+				fmt.Fprint(w, "event: node.failed\ndata: {\"nodeId\": \"login\", \"error\": \"status 500\"}\n\n")
+				fmt.Fprint(w, "event: flow.failed\ndata: {}\n\n")
+			}),
+			want: batchRunResult{Status: "failed", FlowName: "Flow {id}"},
+		},
+		{
+			name: "launch rejected",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"error": "flow not found"}`, http.StatusNotFound)
+			}),
+			want: batchRunResult{Status: "error", FlowName: "{id}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(t, tt.handler)
+			id := uuid.New()
+
+			got := runFlowQuietly(context.Background(), state, id, nil)
+			if got.FlowID != id.String() || got.Status != tt.want.Status {
+				t.Errorf("result = %+v, want flow %s with status %s", got, id, tt.want.Status)
+			}
+			if want := strings.ReplaceAll(tt.want.FlowName, "{id}", id.String()); got.FlowName != want {
+				t.Errorf("flow name = %q, want %q", got.FlowName, want)
+			}
+			if (got.Error != "") != (tt.want.Status != "passed") {
+				t.Errorf("error = %q for status %s", got.Error, got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"echopoint-cli/internal/junit"
+	"echopoint-cli/internal/sse"
+	"echopoint-cli/internal/tap"
+)
+
+// runRecorder collects node results from a run stream for reporting
+type runRecorder struct {
+	flowName string
+	started  time.Time
+	duration time.Duration
+	nodes    []*runNodeResult
+	byID     map[string]*runNodeResult
+}
+
+type runNodeResult struct {
+	id       string
+	nodeType string
+	started  time.Time
+	duration time.Duration
+	done     bool
+	failed   bool
+	message  string
+}
+
+func newRunRecorder(flowName string) *runRecorder {
+	return &runRecorder{
+		flowName: flowName,
+		byID:     make(map[string]*runNodeResult),
+	}
+}
+
+func (r *runRecorder) record(event sse.Event) {
+	var data runEventData
+	_ = json.Unmarshal([]byte(event.Data), &data)
+	now := time.Now()
+
+	switch event.Type {
+	case "flow.started":
+		r.started = now
+		if data.FlowName != "" {
+			r.flowName = data.FlowName
+		}
+	case "node.started":
+		node := r.node(data.NodeID)
+		node.nodeType = data.NodeType
+		node.started = now
+	case "node.completed", "node.failed":
+		node := r.node(data.NodeID)
+		node.done = true
+		node.failed = event.Type == "node.failed" || (data.Success != nil && !*data.Success)
+		node.message = data.Error
+		node.duration = eventDuration(data.Duration, node.started, now)
+	case "flow.completed", "flow.failed":
+		r.duration = eventDuration(data.Duration, r.started, now)
+	}
+}
+
+// node returns the result for id, adding it in first-seen order
+func (r *runRecorder) node(id string) *runNodeResult {
+	if node, ok := r.byID[id]; ok {
+		return node
+	}
+	node := &runNodeResult{id: id}
+	r.byID[id] = node
+	r.nodes = append(r.nodes, node)
+	return node
+}
+
+// failureMessage describes the first node that failed or did not complete
+func (r *runRecorder) failureMessage() string {
+	for _, node := range r.nodes {
+		switch {
+		case !node.done:
+			return node.id + ": node did not complete"
+		case node.failed && node.message != "":
+			return node.id + ": " + node.message
+		case node.failed:
+			return node.id + ": node failed"
+		}
+	}
+	return "flow run failed"
+}
+
+// eventDuration prefers the server-reported milliseconds and falls back to
+// the time observed locally
+func eventDuration(ms *int64, started, now time.Time) time.Duration {
+	if ms != nil {
+		return time.Duration(*ms) * time.Millisecond
+	}
+	if started.IsZero() {
+		return 0
+	}
+	return now.Sub(started)
+}
+
+// junitSuite converts the recorded results to a JUnit suite
+func (r *runRecorder) junitSuite() junit.Suite {
+	cases := make([]junit.Case, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		c := junit.Case{
+			Name:      node.id,
+			ClassName: r.flowName,
+			Time:      junit.Seconds(node.duration),
+		}
+		switch {
+		case !node.done:
+			c.Failure = &junit.Failure{Message: "node did not complete", Type: "incomplete"}
+		case node.failed:
+			message := node.message
+			if message == "" {
+				message = "node failed"
+			}
+			c.Failure = &junit.Failure{Message: message, Type: node.nodeType, Text: message}
+		}
+		cases = append(cases, c)
+	}
+	return junit.NewSuite(r.flowName, r.started, r.duration, cases)
+}
+
+// tapResults converts the recorded results to TAP test points
+func (r *runRecorder) tapResults() []tap.Result {
+	results := make([]tap.Result, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		result := tap.Result{Name: node.id, OK: node.done && !node.failed}
+		if !result.OK {
+			message := node.message
+			switch {
+			case !node.done:
+				message = "node did not complete"
+			case message == "":
+				message = "node failed"
+			}
+			result.Diagnostics = map[string]string{
+				"message":  message,
+				"duration": node.duration.String(),
+			}
+			if node.nodeType != "" {
+				result.Diagnostics["type"] = node.nodeType
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// writeRunReport writes the recorded run as kind to path, or to stdout when
+// path is empty
+func writeRunReport(kind, path string, recorder *runRecorder) error {
+	w := os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var err error
+	switch kind {
+	case "tap":
+		err = tap.Write(w, recorder.tapResults())
+	default:
+		err = junit.Write(w, "echopoint", recorder.junitSuite())
+	}
+	if err != nil {
+		return err
+	}
+	if path != "" {
+		return w.Close()
+	}
+	return nil
+}
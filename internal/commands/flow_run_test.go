@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeEnvFile writes a dotenv file into a temporary directory
+func writeEnvFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	base := writeEnvFile(t, "BASE_URL=https://base.example.com\nUSER=alice\nTIER=free\n")
+	local := writeEnvFile(t, "# local overrides\nUSER=bob\nREGION='eu west'\n")
+
+	tests := []struct {
+		name  string
+		files []string
+		pairs []string
+		want  map[string]string
+	}{
+		{name: "nothing", want: map[string]string{}},
+		{
+			name:  "later files win",
+			files: []string{base, local},
+			want:  map[string]string{"BASE_URL": "https://base.example.com", "USER": "bob", "TIER": "free", "REGION": "eu west"},
+		},
+		{
+			name:  "file order matters",
+			files: []string{local, base},
+			want:  map[string]string{"BASE_URL": "https://base.example.com", "USER": "alice", "TIER": "free", "REGION": "eu west"},
+		},
+		{
+			name:  "env flags win over files",
+			files: []string{base, local},
+			pairs: []string{"USER=carol", "TIER=pro=plus", "EMPTY="},
+			want:  map[string]string{"BASE_URL": "https://base.example.com", "USER": "carol", "TIER": "pro=plus", "REGION": "eu west", "EMPTY": ""},
+		},
+		{
+			name:  "later env flags win",
+			pairs: []string{"USER=carol", "USER=dave"},
+			want:  map[string]string{"USER": "dave"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadEnvOverrides(tt.files, tt.pairs)
+			if err != nil {
+				t.Fatalf("loadEnvOverrides: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadEnvOverrides = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvOverridesErrors(t *testing.T) {
+	bad := writeEnvFile(t, "GOOD=1\nnot a variable\n")
+
+	tests := []struct {
+		name    string
+		files   []string
+		pairs   []string
+		wantErr string
+	}{
+		{name: "bad line", files: []string{bad}, wantErr: "failed to read env file: " + bad + ": line 2: expected KEY=VALUE"},
+		{name: "missing file", files: []string{filepath.Join(t.TempDir(), "missing.env")}, wantErr: "failed to read env file"},
+		{name: "bad env flag", pairs: []string{"USER"}, wantErr: "invalid variable format: USER (expected KEY=value)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadEnvOverrides(tt.files, tt.pairs)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("loadEnvOverrides error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
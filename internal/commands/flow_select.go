@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// selectLimit bounds how many flows the picker offers
+const selectLimit = 100
+
+// flowArgAnnotation marks commands whose first argument is a flow ID
+const flowArgAnnotation = "echopoint/flow-arg"
+
+// enableFlowSelection lets every command under cmd marked with
+// flowArgAnnotation run without its flow ID. The flow is then chosen from a
+// numbered list, either because --select was given or because the ID is
+// missing and stdin is a terminal. Scripts that omit the ID still get the
+// usual argument error. Commands resolve the IDs they are given themselves,
+// with parseFlowID.
+func enableFlowSelection(state *AppState, cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		enableFlowSelection(state, sub)
+	}
+
+	if _, ok := cmd.Annotations[flowArgAnnotation]; !ok || cmd.Args == nil || cmd.RunE == nil {
+		return
+	}
+
+	var selectFlag bool
+	args, run := cmd.Args, cmd.RunE
+
+	// Validate as if the flow ID had been given
+	needsFlow := func(c *cobra.Command, given []string) bool {
+		if selectFlag {
+			return true
+		}
+		if !isTerminal(os.Stdin) || args(c, given) == nil {
+			return false
+		}
+		return args(c, append([]string{""}, given...)) == nil
+	}
+
+	cmd.Args = func(c *cobra.Command, given []string) error {
+		if selectFlag && !isTerminal(os.Stdin) {
+			return fmt.Errorf("--select needs an interactive terminal")
+		}
+		if needsFlow(c, given) {
+			return args(c, append([]string{""}, given...))
+		}
+		return args(c, given)
+	}
+	cmd.RunE = func(c *cobra.Command, given []string) error {
+		if !needsFlow(c, given) {
+			return run(c, given)
+		}
+		if err := requireToken(state); err != nil {
+			return err
+		}
+		id, err := selectFlow(state)
+		if err != nil {
+			return err
+		}
+		return run(c, append([]string{id.String()}, given...))
+	}
+
+	cmd.Flags().BoolVar(&selectFlag, "select", false, "Choose the flow from a list instead of passing its ID")
+}
+
+// selectFlow lists flows on stderr and asks for one by number. Any other
+// answer narrows the list to flows whose name contains its letters in order.
+func selectFlow(state *AppState) (uuid.UUID, error) {
+	flows, err := fetchFlowsPage(state, selectLimit, 0)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(flows) == 0 {
+		return uuid.Nil, fmt.Errorf("no flows to select from")
+	}
+
+	p := newPrompter()
+	matches := flows
+	for {
+		width := 0
+		for _, flow := range matches {
+			width = max(width, len(flow.Name))
+		}
+		for i, flow := range matches {
+			fmt.Fprintf(p.out, "%3d. %-*s  %s\n", i+1, width, flow.Name, flow.Id)
+		}
+
+		answer := p.ask("Select a flow (number, or text to filter)", "")
+		if answer == "" {
+			return uuid.Nil, fmt.Errorf("no flow selected")
+		}
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n >= 1 && n <= len(matches) {
+				return matches[n-1].Id, nil
+			}
+			fmt.Fprintf(p.out, "  choose a number from 1 to %d\n", len(matches))
+			continue
+		}
+
+		filtered := filterFlowsByName(flows, answer)
+		switch len(filtered) {
+		case 0:
+			fmt.Fprintf(p.out, "  no flow matches %q\n", answer)
+		case 1:
+			fmt.Fprintf(p.out, "Selected: %s\n", filtered[0].Name)
+			return filtered[0].Id, nil
+		default:
+			matches = filtered
+		}
+	}
+}
+
+// filterFlowsByName keeps the flows whose name contains the letters of query
+// in order, ignoring case, so "chk" matches "Checkout"
+func filterFlowsByName(flows []api.Flow, query string) []api.Flow {
+	query = strings.ToLower(query)
+	var matches []api.Flow
+	for _, flow := range flows {
+		if isSubsequence(query, strings.ToLower(flow.Name)) {
+			matches = append(matches, flow)
+		}
+	}
+	return matches
+}
+
+func isSubsequence(query, s string) bool {
+	rest := []rune(query)
+	for _, r := range s {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
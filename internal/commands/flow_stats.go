@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// flowStats summarizes the composition of a flow
+type flowStats struct {
+	Nodes               int            `json:"nodes" yaml:"nodes"`
+	NodesByType         map[string]int `json:"nodes_by_type" yaml:"nodes_by_type"`
+	Edges               int            `json:"edges" yaml:"edges"`
+	EdgesByType         map[string]int `json:"edges_by_type" yaml:"edges_by_type"`
+	NodesWithAssertions int            `json:"nodes_with_assertions" yaml:"nodes_with_assertions"`
+	NodesWithOutputs    int            `json:"nodes_with_outputs" yaml:"nodes_with_outputs"`
+	MaxDepth            int            `json:"max_depth" yaml:"max_depth"`
+	EntryNodes          []string       `json:"entry_nodes" yaml:"entry_nodes"`
+	DeadEnds            []string       `json:"dead_ends" yaml:"dead_ends"`
+	IsolatedNodes       []string       `json:"isolated_nodes" yaml:"isolated_nodes"`
+	CycleEdges          []string       `json:"cycle_edges" yaml:"cycle_edges"`
+	DanglingEdges       []string       `json:"dangling_edges" yaml:"dangling_edges"`
+}
+
+func newFlowStatsCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "stats <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Summarize a flow's nodes, edges, and structure",
+		Long: `Summarize a flow's composition.
+
+Reports node and edge counts by type, how many nodes have assertions or
+outputs, the depth of the longest path, and structural details: entry nodes
+(no incoming edges), dead ends (no outgoing edges), isolated nodes, edges that
+close a cycle, and edges that reference missing nodes.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			stats := computeFlowStats(resp.JSON200)
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, stats)
+			case output.FormatYAML:
+				return printYAML(state, stats)
+			default:
+				printFlowStats(stats)
+				return nil
+			}
+		},
+	}
+
+	return cmd
+}
+
+// computeFlowStats derives the composition and structure of flow
+func computeFlowStats(flow *api.Flow) flowStats {
+	stats := flowStats{
+		NodesByType:   make(map[string]int),
+		EdgesByType:   make(map[string]int),
+		EntryNodes:    []string{},
+		DeadEnds:      []string{},
+		IsolatedNodes: []string{},
+		CycleEdges:    []string{},
+		DanglingEdges: []string{},
+	}
+
+	placements := make([]flowbuilder.NodePlacement, 0, len(flow.FlowDefinition.Nodes))
+	keys := newNodeKeys()
+	for _, node := range flow.FlowDefinition.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+
+		var nodeID string
+		var outputs *[]api.Output
+		var assertions *[]api.CompositeAssertion
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			nodeID, outputs, assertions = n.Id, n.Outputs, n.Assertions
+		case api.DelayFlowNode:
+			nodeID, outputs, assertions = n.Id, n.Outputs, n.Assertions
+		default:
+			continue
+		}
+
+		discriminator, _ := node.Discriminator()
+		stats.Nodes++
+		stats.NodesByType[discriminator]++
+		if outputs != nil && len(*outputs) > 0 {
+			stats.NodesWithOutputs++
+		}
+		if assertions != nil && len(*assertions) > 0 {
+			stats.NodesWithAssertions++
+		}
+
+		placements = append(placements, flowbuilder.NodePlacement{ID: keys.add(nodeID)})
+	}
+
+	incoming := make(map[string]int)
+	outgoing := make(map[string]int)
+	edges := make([]flowbuilder.Edge, 0, len(flow.FlowDefinition.Edges))
+	for _, edge := range flow.FlowDefinition.Edges {
+		stats.Edges++
+		stats.EdgesByType[string(edge.Type)]++
+
+		from, fromOK := keys.key(edge.Source)
+		to, toOK := keys.key(edge.Target)
+		if !fromOK || !toOK {
+			stats.DanglingEdges = append(stats.DanglingEdges, edge.Id)
+			continue
+		}
+		outgoing[edge.Source]++
+		incoming[edge.Target]++
+		edges = append(edges, flowbuilder.Edge{From: from, To: to})
+	}
+
+	levels, backEdges := flowbuilder.Levels(placements, edges)
+	for _, level := range levels {
+		stats.MaxDepth = max(stats.MaxDepth, level+1)
+	}
+	for _, edge := range backEdges {
+		stats.CycleEdges = append(stats.CycleEdges, fmt.Sprintf("%s -> %s", keys.id(edge.From), keys.id(edge.To)))
+	}
+
+	for _, placement := range placements {
+		nodeID := keys.id(placement.ID)
+		switch {
+		case incoming[nodeID] == 0 && outgoing[nodeID] == 0 && stats.Nodes > 1:
+			stats.IsolatedNodes = append(stats.IsolatedNodes, nodeID)
+		case incoming[nodeID] == 0:
+			stats.EntryNodes = append(stats.EntryNodes, nodeID)
+		case outgoing[nodeID] == 0:
+			stats.DeadEnds = append(stats.DeadEnds, nodeID)
+		}
+	}
+
+	return stats
+}
+
+func printFlowStats(stats flowStats) {
+	fmt.Fprintf(os.Stdout, "Nodes: %d%s\n", stats.Nodes, formatCounts(stats.NodesByType))
+	fmt.Fprintf(os.Stdout, "Edges: %d%s\n", stats.Edges, formatCounts(stats.EdgesByType))
+	fmt.Fprintf(os.Stdout, "Nodes with assertions: %d\n", stats.NodesWithAssertions)
+	fmt.Fprintf(os.Stdout, "Nodes with outputs: %d\n", stats.NodesWithOutputs)
+	fmt.Fprintf(os.Stdout, "Max depth: %d\n", stats.MaxDepth)
+
+	printIDList("Entry nodes", stats.EntryNodes)
+	printIDList("Dead ends", stats.DeadEnds)
+	printIDList("Isolated nodes", stats.IsolatedNodes)
+	printIDList("Cycle edges", stats.CycleEdges)
+	printIDList("Dangling edges", stats.DanglingEdges)
+}
+
+// formatCounts renders a breakdown such as " (delay: 1, request: 3)"
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := " ("
+	for i, key := range keys {
+		if i > 0 {
+			result += ", "
+		}
+		result += fmt.Sprintf("%s: %d", key, counts[key])
+	}
+	return result + ")"
+}
+
+func printIDList(label string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stdout, "\n%s (%d):\n", label, len(ids))
+	for _, id := range ids {
+		fmt.Fprintf(os.Stdout, "  %s\n", id)
+	}
+}
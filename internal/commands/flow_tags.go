@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlowTagCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage flow tags",
+		Long: `Manage the tags used to organize flows.
+
+Tags are matched without regard to case. List flows with a tag using
+"echopoint flows list --tag <tag>".`,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:               "add <flow-id> <tag>...",
+			Annotations:       map[string]string{flowArgAnnotation: ""},
+			Short:             "Add tags to a flow",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeFlowIDs(state),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return editFlowTags(state, cmd, args[0], func(tags []string) []string {
+					for _, tag := range args[1:] {
+						if tag = strings.TrimSpace(tag); tag != "" && !hasTag(tags, tag) {
+							tags = append(tags, tag)
+						}
+					}
+					return tags
+				})
+			},
+		},
+		&cobra.Command{
+			Use:               "remove <flow-id> <tag>...",
+			Annotations:       map[string]string{flowArgAnnotation: ""},
+			Short:             "Remove tags from a flow",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeFlowIDs(state),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return editFlowTags(state, cmd, args[0], func(tags []string) []string {
+					return slices.DeleteFunc(tags, func(tag string) bool {
+						return hasTag(args[1:], tag)
+					})
+				})
+			},
+		},
+	)
+
+	return cmd
+}
+
+// editFlowTags applies edit to a copy of the flow's tags and saves the result
+func editFlowTags(state *AppState, cmd *cobra.Command, flowArg string, edit func(tags []string) []string) error {
+	if err := requireToken(state); err != nil {
+		return err
+	}
+
+	id, err := parseFlowID(state, flowArg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	flow := resp.JSON200
+
+	tags := edit(slices.Clone(flowTags(flow)))
+	if tags == nil {
+		// A nil slice is sent as null; an empty list clears the tags
+		tags = []string{}
+	}
+
+	// Resend the name, description, and definition so the update leaves them intact
+	req := api.UpdateFlowRequest{
+		Name:           &flow.Name,
+		Description:    flow.Description,
+		FlowDefinition: &flow.FlowDefinition,
+		Tags:           &tags,
+	}
+	updateResp, err := updateFlow(state, id, req)
+	if err != nil {
+		return err
+	}
+	if updateResp.JSON200 == nil {
+		return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+	}
+
+	switch state.OutputFormat {
+	case output.FormatJSON:
+		return printJSON(state, tags)
+	case output.FormatYAML:
+		return printYAML(state, tags)
+	default:
+		fmt.Fprintf(os.Stdout, "✓ Tags: %s\n", formatTags(tags))
+		return nil
+	}
+}
+
+// flowTags returns the tags of flow, or nil when it has none
+func flowTags(flow *api.Flow) []string {
+	if flow.Tags == nil {
+		return nil
+	}
+	return *flow.Tags
+}
+
+// hasTag reports whether tags contains tag, ignoring case
+func hasTag(tags []string, tag string) bool {
+	return slices.ContainsFunc(tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// formatTags renders tags for tables, or "-" when there are none
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ", ")
+}
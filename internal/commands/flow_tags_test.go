@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestEditFlowTagsSendsEmptyList(t *testing.T) {
+	const flowID = "6f1c1d2e-8d53-4a8e-9a3b-1d1f1b2c3d4e"
+
+	tests := []struct {
+		name    string
+		current string
+		remove  []string
+		want    []string
+	}{
+		{name: "remove the last tag", current: `["smoke"]`, remove: []string{"SMOKE"}, want: []string{}},
+		{name: "remove from no tags", current: `null`, remove: []string{"smoke"}, want: []string{}},
+		{name: "remove one of two", current: `["smoke", "nightly"]`, remove: []string{"smoke"}, want: []string{"nightly"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]json.RawMessage
+			state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPut {
+					body, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &sent); err != nil {
+						t.Errorf("update body %s: %v", body, err)
+					}
+				}
+				_, _ = io.WriteString(w, `{"id": "`+flowID+`", "name": "checkout", "tags": `+tt.current+`,
+					"flow_definition": {"name": "checkout", "version": "1.0", "nodes": [], "edges": []}}`)
+			}))
+
+			err := editFlowTags(state, nil, flowID, func(tags []string) []string {
+				return slices.DeleteFunc(tags, func(tag string) bool { return hasTag(tt.remove, tag) })
+			})
+			if err != nil {
+				t.Fatalf("editFlowTags: %v", err)
+			}
+
+			var tags []string
+			if err := json.Unmarshal(sent["tags"], &tags); err != nil {
+				t.Fatalf("tags %s: %v", sent["tags"], err)
+			}
+			if tags == nil || !slices.Equal(tags, tt.want) {
+				t.Errorf("sent tags %s, want %q", sent["tags"], tt.want)
+			}
+		})
+	}
+}
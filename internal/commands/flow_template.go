@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed templates/*.json
+var flowTemplates embed.FS
+
+const defaultFlowTemplate = "request"
+
+// newFlowTemplateCmd prints a starter CreateFlowRequest
+func newFlowTemplateCmd(state *AppState) *cobra.Command {
+	var templateType string
+
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Print a starter flow definition",
+		Long: `Print an example CreateFlowRequest that can be edited and passed to
+flows create.
+
+Available templates:
+  request  A single request with outputs and assertions
+  crud     Create, read, update and delete a resource, chaining outputs
+  auth     Log in and call a protected endpoint, with a failure branch`,
+		Example: `  echopoint flows template --type crud > flow.json
+  echopoint flows template --type auth | echopoint flows create --file -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := loadFlowTemplate(templateType)
+			if err != nil {
+				return err
+			}
+
+			_, err = os.Stdout.Write(data)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&templateType, "type", defaultFlowTemplate, "Template to print: "+strings.Join(flowTemplateNames(), ", "))
+	_ = cmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return flowTemplateNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// loadFlowTemplate returns the named template after checking it decodes as a CreateFlowRequest
+func loadFlowTemplate(name string) ([]byte, error) {
+	data, err := flowTemplates.ReadFile("templates/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown template: %s (must be one of: %v)", name, flowTemplateNames())
+	}
+
+	var req api.CreateFlowRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("template %s is invalid: %w", name, err)
+	}
+
+	return data, nil
+}
+
+func flowTemplateNames() []string {
+	entries, _ := flowTemplates.ReadDir("templates")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"fmt"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/interpolate"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// validationIssue is a problem found in a flow definition
+type validationIssue struct {
+	Check   string `json:"check" yaml:"check"`
+	NodeID  string `json:"node_id,omitempty" yaml:"node_id,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func newFlowValidateCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:         "validate <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Check a flow for problems that would fail at run time",
+		Long: `Check a flow for problems that would fail at run time.
+
+Checks:
+  dangling-edge       an edge references a node that does not exist
+  undefined-variable  a request references a {{variable}} that is not set
+                      in the flow environment
+
+Exits with an error when any problem is found.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flow, vars, err := fetchFlowWithVariables(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			issues, err := validateFlow(flow, vars)
+			if err != nil {
+				return err
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, issues)
+			case output.FormatYAML:
+				err = printYAML(state, issues)
+			default:
+				if len(issues) == 0 {
+					fmt.Println("✓ No problems found")
+					return nil
+				}
+				rows := make([][]string, 0, len(issues))
+				for _, issue := range issues {
+					rows = append(rows, []string{issue.Check, issue.NodeID, issue.Message})
+				}
+				compactIDColumn(state, rows, 1)
+				err = printTable(state, output.Columns("Check", "Node", "Message"), rows)
+			}
+			if err != nil {
+				return err
+			}
+
+			if len(issues) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("flow has %d problem(s)", len(issues))
+			}
+			return nil
+		},
+	}
+}
+
+func newFlowEnvCheckCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:         "check <flow-id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "List variable references that are not set in the flow environment",
+		Long: `List {{variable}} references in request URLs, headers, query parameters,
+and bodies that are not set in the flow environment, with the node and field
+that use them. Node output references such as {{node-id.token}} are skipped.
+
+Exits with an error when any variable is undefined. The same check runs as
+part of 'echopoint flows validate'.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			flow, vars, err := fetchFlowWithVariables(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			usages, err := interpolate.UndefinedVariables(flow.FlowDefinition, knownKeys(vars))
+			if err != nil {
+				return err
+			}
+			if usages == nil {
+				usages = []interpolate.Usage{}
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				err = printJSON(state, usages)
+			case output.FormatYAML:
+				err = printYAML(state, usages)
+			default:
+				if len(usages) == 0 {
+					fmt.Println("✓ All referenced variables are defined")
+					return nil
+				}
+				rows := make([][]string, 0, len(usages))
+				for _, usage := range usages {
+					rows = append(rows, []string{usage.Variable, usage.NodeID, usage.Field})
+				}
+				compactIDColumn(state, rows, 1)
+				err = printTable(state, output.Columns("Variable", "Node", "Field"), rows)
+			}
+			if err != nil {
+				return err
+			}
+
+			if len(usages) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d undefined variable reference(s)", len(usages))
+			}
+			return nil
+		},
+	}
+}
+
+// validateFlow runs every validation check against flow
+func validateFlow(flow *api.Flow, vars map[string]string) ([]validationIssue, error) {
+	issues := []validationIssue{}
+
+	for _, edgeID := range computeFlowStats(flow).DanglingEdges {
+		issues = append(issues, validationIssue{
+			Check:   "dangling-edge",
+			Message: fmt.Sprintf("edge %s references a missing node", edgeID),
+		})
+	}
+
+	usages, err := interpolate.UndefinedVariables(flow.FlowDefinition, knownKeys(vars))
+	if err != nil {
+		return nil, err
+	}
+	for _, usage := range usages {
+		issues = append(issues, validationIssue{
+			Check:   "undefined-variable",
+			NodeID:  usage.NodeID,
+			Message: fmt.Sprintf("{{%s}} in %s is not set in the flow environment", usage.Variable, usage.Field),
+		})
+	}
+
+	return issues, nil
+}
+
+// fetchFlowWithVariables loads a flow and its environment variables
+func fetchFlowWithVariables(state *AppState, arg string) (*api.Flow, map[string]string, error) {
+	flowID, err := parseFlowID(state, arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, nil, formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+
+	vars, err := fetchFlowVariables(state, flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.JSON200, vars, nil
+}
+
+func knownKeys(vars map[string]string) map[string]bool {
+	known := make(map[string]bool, len(vars))
+	for key := range vars {
+		known[key] = true
+	}
+	return known
+}
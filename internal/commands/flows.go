@@ -1,12 +1,18 @@
 package commands
 
 import (
-	"context"
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/filter"
+	"echopoint-cli/internal/flowbuilder"
 	"echopoint-cli/internal/output"
 
 	"github.com/google/uuid"
@@ -25,34 +31,124 @@ func newFlowsCmd(state *AppState) *cobra.Command {
 		newFlowsCreateCmd(state),
 		newFlowsUpdateCmd(state),
 		newFlowsDeleteCmd(state),
+		newFlowsRenameCmd(state),
+		newFlowsClearCmd(state),
 		newFlowInteractiveCmd(state),
 		newFlowShowCmd(state),
 		newFlowNodeCmd(state),
 		newFlowEdgeCmd(state),
 		newFlowEnvCmd(state),
+		newFlowTemplateCmd(state),
+		newFlowLayoutCmd(state),
+		newFlowExportCmd(state),
+		newFlowImportCmd(state),
+		newFlowStatsCmd(state),
+		newFlowOrderCmd(state),
+		newFlowValidateCmd(state),
+		newFlowDiffCmd(state),
+		newFlowRunCmd(state),
+		newFlowTagCmd(state),
 	)
 
+	enableNodeSelection(state, cmd)
+	enableFlowSelection(state, cmd)
+
 	return cmd
 }
 
 func newFlowsListCmd(state *AppState) *cobra.Command {
 	var limit int32 = 20
 	var offset int32
+	var all bool
+	var concurrency int
+	var filters []string
+	var mine, shared bool
+	var tags []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List flows",
+		Long: `List flows.
+
+--filter narrows the results on the client after they are fetched, so combine
+it with --all to search every flow. Each filter is FIELD OP VALUE:
+
+  =  equals (case-insensitive; a YYYY-MM-DD date matches the whole day)
+  ~  contains (text fields)
+  >  after / greater than (times and numbers)
+  <  before / less than (times and numbers)
+
+Fields: id, name, description, tags (text), created, updated (RFC 3339 time
+or YYYY-MM-DD date, local time), nodes (number).
+
+--mine keeps the flows you created, --shared the flows created by others in
+your organization, and --tag the flows carrying every given tag; like --filter
+they apply to the fetched results.
+
+Examples:
+  echopoint flows list --all --filter 'name~checkout'
+  echopoint flows list --all --filter 'updated>2024-01-01' --filter 'nodes>3'
+  echopoint flows list --all --mine
+  echopoint flows list --all -o jsonl | while read -r flow; do ...; done`,
+		Annotations: map[string]string{jsonlAnnotation: ""},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
+			match, err := filter.Compile(filters, flowFilterFields)
+			if err != nil {
+				return err
+			}
+
+			if mine && shared {
+				return fmt.Errorf("--mine and --shared cannot be used together")
+			}
+			if mine || shared {
+				userID, err := currentUserID(state)
+				if err != nil {
+					return err
+				}
+				matchFilters := match
+				match = func(flow api.Flow) bool {
+					return (flow.AuthorId == userID) == mine && matchFilters(flow)
+				}
+			}
+			if len(tags) > 0 {
+				matchOthers := match
+				match = func(flow api.Flow) bool {
+					for _, tag := range tags {
+						if !hasTag(flowTags(&flow), tag) {
+							return false
+						}
+					}
+					return matchOthers(flow)
+				}
+			}
+			filtered := len(filters) > 0 || mine || shared || len(tags) > 0
+			keep := func(flows []api.Flow) []api.Flow {
+				if !filtered {
+					return flows
+				}
+				items := make([]api.Flow, 0, len(flows))
+				for _, flow := range flows {
+					if match(flow) {
+						items = append(items, flow)
+					}
+				}
+				return items
+			}
+
+			if all {
+				offset = 0
+			}
+
 			params := &api.ListFlowsParams{
 				Limit:  api.LimitParameter(limit),
 				Offset: api.OffsetParameter(offset),
 			}
 
-			resp, err := state.Client.API().ListFlowsWithResponse(context.Background(), params)
+			resp, err := state.Client.API().ListFlowsWithResponse(state.Context(), params)
 			if err != nil {
 				return err
 			}
@@ -61,44 +157,132 @@ func newFlowsListCmd(state *AppState) *cobra.Command {
 				return formatAPIError(resp.HTTPResponse, resp.Body)
 			}
 
+			list := resp.JSON200
+			if all && state.OutputFormat == output.FormatJSONL {
+				// Print each page as it arrives rather than after the last one
+				return streamPages(list.Items, list.Total, limit, func(offset int32) ([]api.Flow, error) {
+					return fetchFlowsPage(state, limit, offset)
+				}, func(flows []api.Flow) error {
+					return printJSONL(state, keep(flows))
+				})
+			}
+			if all {
+				items, err := fetchRemainingPages(list.Items, list.Total, limit, concurrency, func(offset int32) ([]api.Flow, error) {
+					return fetchFlowsPage(state, limit, offset)
+				})
+				if err != nil {
+					return err
+				}
+				list = &api.FlowListResponse{Count: len(items), Items: items, Total: list.Total}
+			}
+
+			fetched := len(list.Items)
+			if filtered {
+				items := keep(list.Items)
+				list = &api.FlowListResponse{Count: len(items), Items: items, Total: list.Total}
+			}
+
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, list)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, list)
+			case output.FormatJSONL:
+				return printJSONL(state, list.Items)
 			default:
-				rows := make([][]string, 0, len(resp.JSON200.Items))
-				for _, flow := range resp.JSON200.Items {
-					rows = append(rows, []string{flow.Id.String(), flow.Name, flow.UpdatedAt.String()})
+				columns := output.Columns("ID", "Name", "Tags", "Updated")
+				if state.Wide {
+					columns = append(columns,
+						output.Column{Header: "Created"},
+						output.Column{Header: "Nodes", Align: output.AlignRight},
+						output.Column{Header: "Description"},
+					)
 				}
-				fmt.Fprintf(os.Stdout, "Total: %d\n", resp.JSON200.Total)
-				return output.PrintTable([]string{"ID", "Name", "Updated"}, rows)
+				rows := make([][]string, 0, len(list.Items))
+				for _, flow := range list.Items {
+					row := []string{flow.Id.String(), flow.Name, formatTags(flowTags(&flow)), formatTime(state, flow.UpdatedAt)}
+					if state.Wide {
+						row = append(row,
+							formatTime(state, flow.CreatedAt),
+							strconv.Itoa(len(flow.FlowDefinition.Nodes)),
+							stringValue(flow.Description),
+						)
+					}
+					rows = append(rows, row)
+				}
+				compactIDColumn(state, rows, 0)
+				printListTotal(list.Total, len(list.Items), fetched, filtered)
+				return printTable(state, columns, rows)
 			}
 		},
 	}
 
-	cmd.Flags().Int32Var(&limit, "limit", 20, "Number of results to return")
+	cmd.Flags().Int32Var(&limit, "limit", 20, "Number of results to return (page size with --all)")
 	cmd.Flags().Int32Var(&offset, "offset", 0, "Offset for pagination")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch every page of results")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultPageConcurrency, "Maximum number of pages fetched in parallel with --all (pages stream one at a time with -o jsonl)")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, filterFlagUsage)
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only show flows you created")
+	cmd.Flags().BoolVar(&shared, "shared", false, "Only show flows created by others")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Only show flows with this tag (repeatable; all must match)")
 
 	return cmd
 }
 
+// fetchAllFlows retrieves every flow, fetching pages concurrently
+func fetchAllFlows(state *AppState) ([]api.Flow, error) {
+	const pageSize = 100
+
+	resp, err := state.Client.API().ListFlowsWithResponse(state.Context(), &api.ListFlowsParams{
+		Limit: api.LimitParameter(pageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+
+	return fetchRemainingPages(resp.JSON200.Items, resp.JSON200.Total, pageSize, defaultPageConcurrency, func(offset int32) ([]api.Flow, error) {
+		return fetchFlowsPage(state, pageSize, offset)
+	})
+}
+
+// fetchFlowsPage retrieves a single page of flows
+func fetchFlowsPage(state *AppState, limit, offset int32) ([]api.Flow, error) {
+	params := &api.ListFlowsParams{
+		Limit:  api.LimitParameter(limit),
+		Offset: api.OffsetParameter(offset),
+	}
+
+	resp, err := state.Client.API().ListFlowsWithResponse(state.Context(), params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Items, nil
+}
+
 func newFlowsGetCmd(state *AppState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get <id>",
-		Short: "Get flow details",
-		Args:  cobra.ExactArgs(1),
+		Use:               "get <id>",
+		Annotations:       map[string]string{flowArgAnnotation: ""},
+		Short:             "Get flow details",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			id, err := uuid.Parse(args[0])
+			id, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow id")
+				return err
 			}
 
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), id)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
 			if err != nil {
 				return err
 			}
@@ -109,14 +293,17 @@ func newFlowsGetCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, resp.JSON200)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, resp.JSON200)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON200.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON200.Name)
-				fmt.Fprintf(os.Stdout, "Updated: %s\n", resp.JSON200.UpdatedAt)
-				fmt.Fprintf(os.Stdout, "Created: %s\n", resp.JSON200.CreatedAt)
+				if tags := flowTags(resp.JSON200); len(tags) > 0 {
+					fmt.Fprintf(os.Stdout, "Tags: %s\n", formatTags(tags))
+				}
+				fmt.Fprintf(os.Stdout, "Updated: %s\n", formatTime(state, resp.JSON200.UpdatedAt))
+				fmt.Fprintf(os.Stdout, "Created: %s\n", formatTime(state, resp.JSON200.CreatedAt))
 				return nil
 			}
 		},
@@ -144,7 +331,7 @@ func newFlowsCreateCmd(state *AppState) *cobra.Command {
 				return err
 			}
 
-			resp, err := state.Client.API().CreateFlowWithResponse(context.Background(), req)
+			resp, err := createFlow(state, req)
 			if err != nil {
 				return fmt.Errorf("request failed: %w", err)
 			}
@@ -161,9 +348,9 @@ func newFlowsCreateCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON201)
+				return printJSON(state, resp.JSON201)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON201)
+				return printYAML(state, resp.JSON201)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON201.Name)
@@ -172,18 +359,29 @@ func newFlowsCreateCmd(state *AppState) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&file, "file", "", "Path to CreateFlowRequest JSON")
+	cmd.Flags().StringVar(&file, "file", "", "Path to CreateFlowRequest JSON, or - for stdin")
 	_ = cmd.MarkFlagRequired("file")
 	return cmd
 }
 
 func newFlowsUpdateCmd(state *AppState) *cobra.Command {
 	var file string
+	var dryRun bool
 
 	cmd := &cobra.Command{
-		Use:   "update <id>",
-		Short: "Update a flow from JSON",
-		Args:  cobra.ExactArgs(1),
+		Use:         "update <id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Update a flow from JSON",
+		Long: `Update a flow from an UpdateFlowRequest JSON file.
+
+With --dry-run nothing is saved; instead the name, description, and
+definition the update would produce are diffed against the current flow.
+Removed lines are shown in red and added lines in green unless --no-color
+or NO_COLOR is set.`,
+		Example: `  echopoint flows update <id> --file flow.json
+  echopoint flows update <id> --file flow.json --dry-run`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
@@ -192,9 +390,9 @@ func newFlowsUpdateCmd(state *AppState) *cobra.Command {
 				return fmt.Errorf("--file is required")
 			}
 
-			id, err := uuid.Parse(args[0])
+			id, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow id")
+				return err
 			}
 
 			var req api.UpdateFlowRequest
@@ -202,7 +400,11 @@ func newFlowsUpdateCmd(state *AppState) *cobra.Command {
 				return err
 			}
 
-			resp, err := state.Client.API().UpdateFlowWithResponse(context.Background(), id, req)
+			if dryRun {
+				return previewFlowUpdate(state, id, req)
+			}
+
+			resp, err := updateFlow(state, id, req)
 			if err != nil {
 				return err
 			}
@@ -212,9 +414,9 @@ func newFlowsUpdateCmd(state *AppState) *cobra.Command {
 
 			switch state.OutputFormat {
 			case output.FormatJSON:
-				return output.PrintJSON(os.Stdout, resp.JSON200)
+				return printJSON(state, resp.JSON200)
 			case output.FormatYAML:
-				return output.PrintYAML(os.Stdout, resp.JSON200)
+				return printYAML(state, resp.JSON200)
 			default:
 				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON200.Id)
 				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON200.Name)
@@ -223,38 +425,373 @@ func newFlowsUpdateCmd(state *AppState) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&file, "file", "", "Path to UpdateFlowRequest JSON")
+	cmd.Flags().StringVar(&file, "file", "", "Path to UpdateFlowRequest JSON, or - for stdin")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show a diff of the changes without saving them")
 	_ = cmd.MarkFlagRequired("file")
 	return cmd
 }
 
-func newFlowsDeleteCmd(state *AppState) *cobra.Command {
+// flowSnapshot is the part of a flow an update can change, as diffed by
+// flows update --dry-run
+type flowSnapshot struct {
+	Name           string             `json:"name"`
+	Description    *string            `json:"description"`
+	FlowDefinition api.FlowDefinition `json:"flow_definition"`
+}
+
+// previewFlowUpdate prints a diff between the flow as stored and the flow as
+// req would leave it, without saving anything
+func previewFlowUpdate(state *AppState, id uuid.UUID, req api.UpdateFlowRequest) error {
+	resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	flow := resp.JSON200
+
+	// Normalize both sides so only changes the update makes show up
+	current, err := flowbuilder.Normalize(flow.FlowDefinition)
+	if err != nil {
+		return err
+	}
+	before := flowSnapshot{Name: flow.Name, Description: flow.Description, FlowDefinition: current}
+
+	// The description is always sent, so leaving it out clears it
+	after := flowSnapshot{Name: flow.Name, Description: req.Description, FlowDefinition: current}
+	if req.Name != nil {
+		after.Name = *req.Name
+	}
+	if req.FlowDefinition != nil {
+		after.FlowDefinition, err = flowbuilder.Normalize(*req.FlowDefinition)
+		if err != nil {
+			return err
+		}
+	}
+
+	oldJSON, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+	newJSON, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	changed, err := output.PrintDiff(os.Stdout, oldJSON, newJSON, state.Color)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Fprintln(os.Stdout, "No changes")
+		return nil
+	}
+	fmt.Fprintln(os.Stdout, "\nDry run: no changes saved")
+	return nil
+}
+
+func newFlowsRenameCmd(state *AppState) *cobra.Command {
+	var description string
+
+	cmd := &cobra.Command{
+		Use:               "rename <id> <new-name>",
+		Annotations:       map[string]string{flowArgAnnotation: ""},
+		Short:             "Rename a flow",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+			name := args[1]
+
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+			flow := resp.JSON200
+
+			// Resend the description and definition so the update leaves them intact
+			req := api.UpdateFlowRequest{
+				Name:           &name,
+				Description:    flow.Description,
+				FlowDefinition: &flow.FlowDefinition,
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &description
+			}
+
+			updateResp, err := updateFlow(state, id, req)
+			if err != nil {
+				return err
+			}
+			if updateResp.JSON200 == nil {
+				return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, updateResp.JSON200)
+			case output.FormatYAML:
+				return printYAML(state, updateResp.JSON200)
+			default:
+				fmt.Fprintf(os.Stdout, "ID: %s\n", updateResp.JSON200.Id)
+				fmt.Fprintf(os.Stdout, "Name: %s\n", updateResp.JSON200.Name)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&description, "description", "", "Also set the flow description")
+	return cmd
+}
+
+func newFlowsClearCmd(state *AppState) *cobra.Command {
+	var yes bool
+
 	cmd := &cobra.Command{
-		Use:   "delete <id>",
-		Short: "Delete a flow",
-		Args:  cobra.ExactArgs(1),
+		Use:         "clear <id>",
+		Annotations: map[string]string{flowArgAnnotation: ""},
+		Short:       "Remove every node and edge from a flow",
+		Long: `Remove every node and edge from a flow, keeping its ID, name, and
+description. Saved node positions are dropped as well.
+
+Asks for confirmation unless --yes is given.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			id, err := uuid.Parse(args[0])
+			id, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow id")
+				return err
 			}
 
-			resp, err := state.Client.API().DeleteFlowWithResponse(context.Background(), id)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), id)
 			if err != nil {
 				return err
 			}
-			if resp.HTTPResponse.StatusCode != http.StatusNoContent {
+			if resp.JSON200 == nil {
 				return formatAPIError(resp.HTTPResponse, resp.Body)
 			}
+			flow := resp.JSON200
+
+			if !yes {
+				if !isTerminal(os.Stdin) {
+					return fmt.Errorf("refusing to clear flow without --yes")
+				}
+				question := fmt.Sprintf("Remove all %d nodes and %d edges from %q?",
+					len(flow.FlowDefinition.Nodes), len(flow.FlowDefinition.Edges), flow.Name)
+				if !confirm(question) {
+					fmt.Fprintln(os.Stdout, "Aborted.")
+					return nil
+				}
+			}
+
+			definition := flow.FlowDefinition
+			definition.Nodes = []api.FlowNode{}
+			definition.Edges = []api.FlowEdge{}
+			positions := map[string]flowNodePosition{}
+			req := api.UpdateFlowRequest{
+				Name:           &flow.Name,
+				Description:    flow.Description,
+				FlowDefinition: &definition,
+				Metadata: &api.UpdateFlowRequest_Metadata{
+					NodePositions:        &positions,
+					AdditionalProperties: flow.Metadata.AdditionalProperties,
+				},
+			}
+
+			updateResp, err := updateFlow(state, id, req)
+			if err != nil {
+				return err
+			}
+			if updateResp.JSON200 == nil {
+				return formatAPIError(updateResp.HTTPResponse, updateResp.Body)
+			}
+
+			fmt.Fprintf(os.Stdout, "✓ Flow cleared: %s\n", flow.Id)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func newFlowsDeleteCmd(state *AppState) *cobra.Command {
+	var file string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [id]",
+		Short: "Delete a flow, or many flows listed in a file",
+		Long: `Delete a flow by ID, or delete every flow listed in a file.
+
+With --file, the file (or stdin when --file is -) holds one flow ID per
+line; blank lines and lines starting with # are ignored. All IDs are
+validated before anything is deleted, and deletion continues past
+individual failures.`,
+		Example: `  echopoint flows delete <flow-id>
+  echopoint flows delete --file ids.txt --yes
+  echopoint flows list -o json --fields '$.items[*].id' | jq -r '.[]' | echopoint flows delete --file - --yes`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			if file != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("pass either a flow ID or --file, not both")
+				}
+				return deleteFlowsFromFile(state, file, yes)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("a flow ID or --file is required")
+			}
+
+			id, err := parseFlowID(state, args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := deleteFlow(state, id); err != nil {
+				return err
+			}
 
 			fmt.Fprintln(os.Stdout, "Flow deleted.")
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&file, "file", "", "File of newline-separated flow IDs to delete, or - for stdin")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt for bulk deletes")
+
 	return cmd
 }
+
+func deleteFlow(state *AppState, id uuid.UUID) error {
+	resp, err := state.Client.API().DeleteFlowWithResponse(state.Context(), id)
+	if err != nil {
+		return err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusNoContent {
+		return formatAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
+
+// deleteFlowsFromFile deletes every flow listed in path, reporting each result
+func deleteFlowsFromFile(state *AppState, path string, yes bool) error {
+	var r io.Reader = os.Stdin
+	if path != stdinPath {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ids, err := readFlowIDs(r)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(os.Stdout, "No flow IDs to delete.")
+		return nil
+	}
+
+	if !yes {
+		// Prompting needs a terminal on stdin, which --file - already consumes
+		if path == stdinPath || !isTerminal(os.Stdin) {
+			return fmt.Errorf("refusing to delete %d flows without --yes", len(ids))
+		}
+		if !confirm(fmt.Sprintf("Delete %d flows?", len(ids))) {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	failed := 0
+	for _, id := range ids {
+		if err := deleteFlow(state, id); err != nil {
+			failed++
+			fmt.Fprintf(os.Stdout, "✗ %s: %v\n", id, err)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "✓ %s\n", id)
+	}
+
+	fmt.Fprintf(os.Stdout, "\nDeleted %d of %d flows.\n", len(ids)-failed, len(ids))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deletions failed", failed, len(ids))
+	}
+	return nil
+}
+
+// readFlowIDs parses one flow ID per line, skipping blanks and # comments.
+// Every line is validated so that no deletion starts with a bad ID.
+func readFlowIDs(r io.Reader) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	var invalid []string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		id, err := uuid.Parse(text)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("line %d: %q", line, text))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid flow IDs:\n  %s", strings.Join(invalid, "\n  "))
+	}
+	return ids, nil
+}
+
+// updateFlow sends req with its definition normalized, so that rewriting an
+// unchanged definition produces identical bytes
+func updateFlow(state *AppState, id uuid.UUID, req api.UpdateFlowRequest) (*api.UpdateFlowResponse, error) {
+	if req.FlowDefinition != nil {
+		definition, err := flowbuilder.Normalize(*req.FlowDefinition)
+		if err != nil {
+			return nil, err
+		}
+		req.FlowDefinition = &definition
+	}
+	return state.Client.API().UpdateFlowWithResponse(state.Context(), id, req)
+}
+
+// createFlow sends req with its definition normalized
+func createFlow(state *AppState, req api.CreateFlowRequest) (*api.CreateFlowResponse, error) {
+	definition, err := flowbuilder.Normalize(req.FlowDefinition)
+	if err != nil {
+		return nil, err
+	}
+	req.FlowDefinition = definition
+	return state.Client.API().CreateFlowWithResponse(state.Context(), req)
+}
@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"echopoint-cli/internal/api"
 
@@ -12,34 +13,78 @@ import (
 
 // newFlowInteractiveCmd creates a simplified interactive flow builder
 func newFlowInteractiveCmd(state *AppState) *cobra.Command {
+	var name, description, method, url string
+
 	cmd := &cobra.Command{
 		Use:   "create-interactive",
 		Short: "Create a flow interactively (simplified)",
 		Long: `Create a new flow through interactive prompts.
 
-This command will guide you through creating a basic flow.
+This command will guide you through creating a basic flow: its name and
+description, then optionally a chain of request nodes connected by success
+edges. Flag values are offered as the defaults.
+
+When stdin is not a terminal nothing is asked: the flow is created from
+--name and --description, with a single request node if --url is given.
 For advanced features, use the TUI: echopoint tui`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			// Get flow name from flag or use default
-			name, _ := cmd.Flags().GetString("name")
-			if name == "" {
-				name = "New Flow"
+			if method == "" {
+				method = string(api.GET)
+			}
+
+			var requests []interactiveRequest
+			if isTerminal(os.Stdin) {
+				p := newPrompter()
+				name = p.ask("Flow name", defaultString(name, "New Flow"))
+				description = p.ask("Description (optional)", description)
+
+				if p.askYesNo("Add a request node?", true) {
+					for {
+						req := promptRequestNode(p, method, url)
+						requests = append(requests, req)
+						method, url = req.method, ""
+						if !p.askYesNo("Add another request node after it?", false) {
+							break
+						}
+					}
+				}
+			} else {
+				name = defaultString(name, "New Flow")
+				if url != "" {
+					if err := validateMethod(method); err != nil {
+						return err
+					}
+					if err := validateRequestURL(url); err != nil {
+						return err
+					}
+					requests = append(requests, interactiveRequest{
+						name:   defaultNodeName(method, url),
+						method: strings.ToUpper(method),
+						url:    url,
+					})
+				}
+			}
+
+			definition, err := buildInteractiveDefinition(name, requests)
+			if err != nil {
+				return err
 			}
 
-			// Create a simple flow with empty definition
+			autoLayout := true
 			req := api.CreateFlowRequest{
-				Name: name,
-				FlowDefinition: api.FlowDefinition{
-					Nodes: []api.FlowNode{},
-					Edges: []api.FlowEdge{},
-				},
+				Name:           name,
+				FlowDefinition: definition,
+				AutoLayout:     &autoLayout,
+			}
+			if description != "" {
+				req.Description = &description
 			}
 
-			resp, err := state.Client.API().CreateFlowWithResponse(context.Background(), req)
+			resp, err := createFlow(state, req)
 			if err != nil {
 				return fmt.Errorf("failed to create flow: %w", err)
 			}
@@ -51,37 +96,131 @@ For advanced features, use the TUI: echopoint tui`,
 			flow := resp.JSON201
 			fmt.Printf("✓ Flow created: %s\n", flow.Name)
 			fmt.Printf("  ID: %s\n", flow.Id)
+			if len(requests) > 0 {
+				fmt.Printf("  Nodes: %d request node(s)\n", len(requests))
+			}
 			fmt.Println("\nNext steps:")
 			fmt.Printf("  View flow:   echopoint flows get %s\n", flow.Id)
+			fmt.Printf("  Add nodes:   echopoint flows node add %s --type request ...\n", flow.Id)
 			fmt.Printf("  Open TUI:    echopoint tui\n")
-			fmt.Println("\nNote: Use the TUI (echopoint tui) for interactive flow editing")
 
 			return nil
 		},
 	}
 
-	cmd.Flags().String("name", "", "Flow name")
+	cmd.Flags().StringVar(&name, "name", "", "Flow name")
+	cmd.Flags().StringVar(&description, "description", "", "Flow description")
+	cmd.Flags().StringVar(&method, "method", "", "HTTP method of the first request node (default GET)")
+	cmd.Flags().StringVar(&url, "url", "", "URL of the first request node")
 
 	return cmd
 }
 
+// interactiveRequest is a request node collected by create-interactive
+type interactiveRequest struct {
+	name   string
+	method string
+	url    string
+}
+
+func promptRequestNode(p *prompter, method, url string) interactiveRequest {
+	method = strings.ToUpper(p.askRequired("  Method", method, validateMethod))
+	url = p.askRequired("  URL", url, validateRequestURL)
+	return interactiveRequest{
+		name:   p.ask("  Node name", defaultNodeName(method, url)),
+		method: method,
+		url:    url,
+	}
+}
+
+// buildInteractiveDefinition chains requests with success edges
+func buildInteractiveDefinition(name string, requests []interactiveRequest) (api.FlowDefinition, error) {
+	definition := api.FlowDefinition{
+		Name:  name,
+		Nodes: []api.FlowNode{},
+		Edges: []api.FlowEdge{},
+	}
+
+	var previous string
+	for _, req := range requests {
+		nodeUUID, err := uuid.NewV7()
+		if err != nil {
+			return definition, fmt.Errorf("failed to generate node ID: %w", err)
+		}
+		nodeID := nodeUUID.String()
+
+		var node api.FlowNode
+		if err := node.FromRequestFlowNode(api.RequestFlowNode{
+			Id:          nodeID,
+			Type:        "request",
+			DisplayName: req.name,
+			Data: api.RequestNodeData{
+				Method: api.RequestNodeDataMethod(req.method),
+				Url:    req.url,
+			},
+		}); err != nil {
+			return definition, err
+		}
+		definition.Nodes = append(definition.Nodes, node)
+
+		if previous != "" {
+			edgeUUID, err := uuid.NewV7()
+			if err != nil {
+				return definition, fmt.Errorf("failed to generate edge ID: %w", err)
+			}
+			definition.Edges = append(definition.Edges, api.FlowEdge{
+				Id:     edgeUUID.String(),
+				Source: previous,
+				Target: nodeID,
+				Type:   api.Success,
+			})
+		}
+		previous = nodeID
+	}
+
+	return definition, nil
+}
+
+// defaultNodeName names a request node after its method and URL path
+func defaultNodeName(method, url string) string {
+	path := url
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		if j := strings.Index(path, "/"); j >= 0 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+func defaultString(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
 // newFlowShowCmd displays flow information
 func newFlowShowCmd(state *AppState) *cobra.Command {
 	return &cobra.Command{
-		Use:   "show <flow-id>",
-		Short: "Display flow details",
-		Args:  cobra.ExactArgs(1),
+		Use:               "show <flow-id>",
+		Annotations:       map[string]string{flowArgAnnotation: ""},
+		Short:             "Display flow details",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFlowIDs(state),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := requireToken(state); err != nil {
 				return err
 			}
 
-			flowID, err := uuid.Parse(args[0])
+			flowID, err := parseFlowID(state, args[0])
 			if err != nil {
-				return fmt.Errorf("invalid flow ID: %w", err)
+				return err
 			}
 
-			resp, err := state.Client.API().GetFlowWithResponse(context.Background(), flowID)
+			resp, err := state.Client.API().GetFlowWithResponse(state.Context(), flowID)
 			if err != nil {
 				return fmt.Errorf("failed to get flow: %w", err)
 			}
@@ -97,9 +236,12 @@ func newFlowShowCmd(state *AppState) *cobra.Command {
 			if flow.Description != nil {
 				fmt.Printf("Description: %s\n", *flow.Description)
 			}
+			if tags := flowTags(flow); len(tags) > 0 {
+				fmt.Printf("Tags: %s\n", formatTags(tags))
+			}
 			fmt.Printf("Version: %s\n", flow.Version)
-			fmt.Printf("Created: %s\n", flow.CreatedAt)
-			fmt.Printf("Updated: %s\n", flow.UpdatedAt)
+			fmt.Printf("Created: %s\n", formatTime(state, flow.CreatedAt))
+			fmt.Printf("Updated: %s\n", formatTime(state, flow.UpdatedAt))
 
 			// Count nodes and edges
 			fmt.Printf("\nStructure:\n")
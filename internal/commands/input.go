@@ -1,11 +1,24 @@
 package commands
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
 )
 
+// stdinPath is the --file value that selects standard input
+const stdinPath = "-"
+
 func loadJSONFile(path string, value interface{}) error {
+	if path == stdinPath {
+		return readJSON(os.Stdin, value)
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return err
@@ -13,3 +26,104 @@ func loadJSONFile(path string, value interface{}) error {
 
 	return json.Unmarshal(data, value)
 }
+
+// readInputFile reads a whole file, or stdin for "-"
+func readInputFile(path string) ([]byte, error) {
+	if path == stdinPath {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// loadJSONObjectFile reads a file (or stdin for "-") that must hold a JSON object
+func loadJSONObjectFile(path string) (map[string]interface{}, error) {
+	var value interface{}
+	if err := loadJSONFile(path, &value); err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must contain a JSON object", path)
+	}
+	return object, nil
+}
+
+func readJSON(r io.Reader, value interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+// isTerminal reports whether f is an interactive terminal. Other character
+// devices such as /dev/null are not.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(f.Fd())
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// prompter asks questions on a terminal, reading answers from one buffered
+// reader so that consecutive prompts do not lose input
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter() *prompter {
+	return &prompter{in: bufio.NewReader(os.Stdin), out: os.Stderr}
+}
+
+// ask prints label and returns the trimmed answer, or def when it is empty
+func (p *prompter) ask(label, def string) string {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+	answer, _ := p.in.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def
+	}
+	return answer
+}
+
+// askRequired repeats the question until the answer passes validate
+func (p *prompter) askRequired(label, def string, validate func(string) error) string {
+	for {
+		answer := p.ask(label, def)
+		err := validate(answer)
+		if err == nil {
+			return answer
+		}
+		fmt.Fprintf(p.out, "  %v\n", err)
+	}
+}
+
+// askYesNo asks a yes/no question, returning def for an empty answer
+func (p *prompter) askYesNo(question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	fmt.Fprintf(p.out, "%s [%s]: ", question, hint)
+	answer, _ := p.in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// withStdin replaces os.Stdin with a pipe holding data for the rest of the test
+func withStdin(t *testing.T, data string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		r.Close()
+	})
+}
+
+func TestReadJSON(t *testing.T) {
+	var got map[string]interface{}
+	if err := readJSON(bytes.NewReader([]byte(`{"name": "checkout", "nodes": []}`)), &got); err != nil {
+		t.Fatalf("readJSON: %v", err)
+	}
+	want := map[string]interface{}{"name": "checkout", "nodes": []interface{}{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readJSON = %v, want %v", got, want)
+	}
+
+	if err := readJSON(bytes.NewReader([]byte(`{"name":`)), &got); err == nil {
+		t.Error("readJSON accepted truncated JSON")
+	}
+}
+
+func TestLoadJSONFileFromStdin(t *testing.T) {
+	withStdin(t, `{"name": "from stdin"}`)
+
+	var got struct{ Name string }
+	if err := loadJSONFile(stdinPath, &got); err != nil {
+		t.Fatalf("loadJSONFile(-): %v", err)
+	}
+	if got.Name != "from stdin" {
+		t.Errorf("name = %q, want %q", got.Name, "from stdin")
+	}
+}
+
+func TestLoadJSONFileFromDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.json")
+	if err := os.WriteFile(path, []byte(`{"name": "from file"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct{ Name string }
+	if err := loadJSONFile(path, &got); err != nil {
+		t.Fatalf("loadJSONFile: %v", err)
+	}
+	if got.Name != "from file" {
+		t.Errorf("name = %q, want %q", got.Name, "from file")
+	}
+
+	if err := loadJSONFile(filepath.Join(t.TempDir(), "missing.json"), &got); !os.IsNotExist(err) {
+		t.Errorf("missing file error = %v, want not-exist", err)
+	}
+}
+
+func TestLoadJSONObjectFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "object", input: `{"value": 1}`},
+		{name: "array", input: `[1, 2]`, wantErr: true},
+		{name: "string", input: `"text"`, wantErr: true},
+		{name: "invalid", input: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withStdin(t, tt.input)
+			_, err := loadJSONObjectFile(stdinPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadJSONObjectFile(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"echopoint-cli/internal/output"
+)
+
+// printJSON writes value to stdout as JSON, projected through --fields when
+// set and indented unless --pretty=false
+func printJSON(state *AppState, value interface{}) error {
+	if len(state.Fields) > 0 {
+		projected, err := output.Project(value, state.Fields)
+		if err != nil {
+			return err
+		}
+		value = projected
+	}
+	return output.PrintJSON(os.Stdout, value, state.Pretty)
+}
+
+// printJSONL writes each item to stdout as one line of JSON, projecting
+// every item through --fields when set
+func printJSONL[T any](state *AppState, items []T) error {
+	if len(state.Fields) == 0 {
+		return output.PrintJSONL(os.Stdout, items)
+	}
+	projected := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		value, err := output.Project(item, state.Fields)
+		if err != nil {
+			return err
+		}
+		projected = append(projected, value)
+	}
+	return output.PrintJSONL(os.Stdout, projected)
+}
+
+// printYAML writes value to stdout as YAML, with sorted keys when
+// --canonical is set
+func printYAML(state *AppState, value interface{}) error {
+	if state.Canonical {
+		return output.PrintCanonicalYAML(os.Stdout, value)
+	}
+	return output.PrintYAML(os.Stdout, value)
+}
+
+// printTable writes a table to stdout, truncating cells to fit the terminal
+// when --compact is set
+func printTable(state *AppState, columns []output.Column, rows [][]string) error {
+	width := 0
+	if state.Compact {
+		width = output.TerminalWidth()
+	}
+	return output.PrintColumns(os.Stdout, columns, rows, width)
+}
+
+// compactIDColumn shortens the IDs in column of rows when --compact-ids is set
+func compactIDColumn(state *AppState, rows [][]string, column int) {
+	if !state.CompactIDs {
+		return
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row[column]
+	}
+	for i, id := range output.ShortIDs(ids) {
+		rows[i][column] = id
+	}
+}
+
+// formatTime renders a timestamp using --time-format, in local time unless
+// --utc is set
+func formatTime(state *AppState, t time.Time) string {
+	loc := time.Local
+	if state.UTC {
+		loc = time.UTC
+	}
+	return output.FormatTime(t, state.TimeFormat, loc)
+}
+
+// stringValue dereferences an optional string for a table cell, collapsing
+// whitespace so multi-line values stay on one row
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(*value), " ")
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultPageConcurrency = 4
+
+// pageFetcher retrieves a single page of results starting at offset
+type pageFetcher[T any] func(offset int32) ([]T, error)
+
+// fetchRemainingPages fetches every page after the first one using a bounded
+// worker pool. Pages are reassembled in offset order regardless of completion
+// order. A failed page is retried once; pages that still fail are reported
+// together in the returned error.
+func fetchRemainingPages[T any](first []T, total int64, pageSize int32, concurrency int, fetch pageFetcher[T]) ([]T, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive")
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	pageCount := int((total + int64(pageSize) - 1) / int64(pageSize))
+	if pageCount <= 1 {
+		return first, nil
+	}
+
+	pages := make([][]T, pageCount)
+	pages[0] = first
+	pageErrs := make([]error, pageCount)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, pageCount-1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range jobs {
+				offset := int32(page) * pageSize
+				items, err := fetch(offset)
+				if err != nil {
+					items, err = fetch(offset)
+				}
+				if err != nil {
+					pageErrs[page] = fmt.Errorf("page %d (offset %d): %w", page+1, offset, err)
+					continue
+				}
+				pages[page] = items
+			}
+		}()
+	}
+
+	for page := 1; page < pageCount; page++ {
+		jobs <- page
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := errors.Join(pageErrs...); err != nil {
+		return nil, fmt.Errorf("failed to fetch all pages:\n%w", err)
+	}
+
+	items := make([]T, 0, total)
+	for _, page := range pages {
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+// streamPages passes the first page and then every following page to emit,
+// fetching them one at a time in offset order so output can start before the
+// last page arrives. A failed page is retried once before giving up.
+func streamPages[T any](first []T, total int64, pageSize int32, fetch pageFetcher[T], emit func([]T) error) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive")
+	}
+	if err := emit(first); err != nil {
+		return err
+	}
+
+	for offset := pageSize; int64(offset) < total; offset += pageSize {
+		items, err := fetch(offset)
+		if err != nil {
+			items, err = fetch(offset)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch page %d (offset %d): %w", offset/pageSize+1, offset, err)
+		}
+		if len(items) == 0 {
+			break
+		}
+		if err := emit(items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
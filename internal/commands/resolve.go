@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/google/uuid"
+)
+
+// resolveFlowID expands an abbreviated flow ID, such as one shown with
+// --compact-ids, to the full ID of the only flow it starts. Full UUIDs are
+// returned without a lookup, as are arguments that cannot be abbreviations,
+// so the command reports those in its own words.
+func resolveFlowID(state *AppState, arg string) (string, error) {
+	if _, err := uuid.Parse(arg); err == nil || !output.IsIDPrefix(arg) {
+		return arg, nil
+	}
+	if err := requireToken(state); err != nil {
+		return "", err
+	}
+
+	flows, err := fetchAllFlows(state)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]string, 0, len(flows))
+	for _, flow := range flows {
+		ids = append(ids, flow.Id.String())
+	}
+	return matchIDPrefix("flow", arg, ids)
+}
+
+// parseFlowID resolves arg with resolveFlowID and parses the result. Commands
+// call it for each flow ID argument they take.
+func parseFlowID(state *AppState, arg string) (uuid.UUID, error) {
+	resolved, err := resolveFlowID(state, arg)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := uuid.Parse(resolved)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid flow ID: %w", err)
+	}
+	return id, nil
+}
+
+// resolveCollectionID expands an abbreviated collection ID like resolveFlowID
+func resolveCollectionID(state *AppState, arg string) (string, error) {
+	if _, err := uuid.Parse(arg); err == nil || !output.IsIDPrefix(arg) {
+		return arg, nil
+	}
+	if err := requireToken(state); err != nil {
+		return "", err
+	}
+
+	collections, err := fetchAllCollections(state)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]string, 0, len(collections))
+	for _, collection := range collections {
+		ids = append(ids, collection.Id.String())
+	}
+	return matchIDPrefix("collection", arg, ids)
+}
+
+// resolveNodeID expands an abbreviated node ID against the nodes of flow
+func resolveNodeID(flow *api.Flow, arg string) (string, error) {
+	return matchIDPrefix("node", arg, flowNodeIDs(flow))
+}
+
+// resolveEdgeID expands an abbreviated edge ID against the edges of definition
+func resolveEdgeID(definition api.FlowDefinition, arg string) (string, error) {
+	ids := make([]string, 0, len(definition.Edges))
+	for _, edge := range definition.Edges {
+		ids = append(ids, edge.Id)
+	}
+	return matchIDPrefix("edge", arg, ids)
+}
+
+// matchIDPrefix returns the only ID in ids that arg equals or abbreviates.
+// Node and edge IDs are free-form, so arguments that cannot be abbreviations
+// are only matched exactly and otherwise returned unchanged.
+func matchIDPrefix(kind, arg string, ids []string) (string, error) {
+	if containsString(ids, arg) || !output.IsIDPrefix(arg) {
+		return arg, nil
+	}
+
+	prefix := strings.ToLower(arg)
+	var matches []string
+	for _, id := range ids {
+		if strings.HasPrefix(strings.ToLower(id), prefix) {
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%s not found: %s", kind, arg)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("%s ID %q is ambiguous; it matches %s", kind, arg, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMatchIDPrefix(t *testing.T) {
+	ids := []string{
+		"3f2a9c10-0000-4000-8000-000000000001",
+		"3f2a9c10-0000-4000-8000-000000000002",
+		"7b41e2aa-0000-4000-8000-000000000003",
+		"cafe",
+		"cafe1234-0000-4000-8000-000000000004",
+		"login",
+	}
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr string
+	}{
+		{name: "exact", arg: "7b41e2aa-0000-4000-8000-000000000003", want: "7b41e2aa-0000-4000-8000-000000000003"},
+		{name: "exact free-form", arg: "login", want: "login"},
+		{name: "exact and a prefix of another", arg: "cafe", want: "cafe"},
+		{name: "prefix", arg: "7b41", want: "7b41e2aa-0000-4000-8000-000000000003"},
+		{name: "upper-case prefix", arg: "7B41E2", want: "7b41e2aa-0000-4000-8000-000000000003"},
+		{name: "longer prefix with dashes", arg: "3f2a9c10-0000-4000-8000-0000000000", wantErr: "ambiguous"},
+		{name: "ambiguous", arg: "3f2a", wantErr: `node ID "3f2a" is ambiguous; it matches 3f2a9c10-0000-4000-8000-000000000001, 3f2a9c10-0000-4000-8000-000000000002`},
+		{name: "not found", arg: "dead", wantErr: "node not found: dead"},
+		{name: "too short to be a prefix", arg: "7b4", want: "7b4"},
+		{name: "not hex", arg: "checkout", want: "checkout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchIDPrefix("node", tt.arg, ids)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("matchIDPrefix error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchIDPrefix: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("matchIDPrefix = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFlowID(t *testing.T) {
+	const (
+		checkout = "3f2a9c10-0000-4000-8000-000000000001"
+		cleanup  = "3f2a9c10-0000-4000-8000-000000000002"
+		login    = "7b41e2aa-0000-4000-8000-000000000003"
+	)
+	var lists atomic.Int32
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/flows" {
+			http.NotFound(w, r)
+			return
+		}
+		lists.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"items": [
+			{"id": %q, "name": "checkout"}, {"id": %q, "name": "cleanup"}, {"id": %q, "name": "login"}
+		], "total": 3}`, checkout, cleanup, login))
+	}))
+
+	tests := []struct {
+		arg       string
+		want      string
+		wantErr   string
+		wantLists int32
+	}{
+		{arg: login, want: login},
+		{arg: "7b41", want: login, wantLists: 1},
+		{arg: "3F2A9C10-0000-4000-8000-0000000000", wantErr: "is ambiguous", wantLists: 1},
+		{arg: "beef", wantErr: "flow not found: beef", wantLists: 1},
+		{arg: "checkout", wantErr: "invalid flow ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			lists.Store(0)
+			got, err := parseFlowID(state, tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseFlowID error = %v, want it to contain %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("parseFlowID: %v", err)
+			} else if got.String() != tt.want {
+				t.Errorf("parseFlowID = %s, want %s", got, tt.want)
+			}
+			if n := lists.Load(); n != tt.wantLists {
+				t.Errorf("listed flows %d times, want %d", n, tt.wantLists)
+			}
+		})
+	}
+}
+
+func TestFlowSelectionFlags(t *testing.T) {
+	root := NewRootCmd(BuildInfo{})
+
+	tests := []struct {
+		path       string
+		selectFlow bool
+		selectNode bool
+	}{
+		{path: "flows get", selectFlow: true},
+		{path: "flows diff", selectFlow: true},
+		{path: "flows env diff", selectFlow: true},
+		{path: "flows tag add", selectFlow: true},
+		{path: "flows node remove", selectFlow: true, selectNode: true},
+		{path: "flows node assertion add", selectFlow: true, selectNode: true},
+		{path: "flows node swap", selectFlow: true},
+		{path: "flows list"},
+		{path: "flows delete"},
+		{path: "flows import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			cmd, _, err := root.Find(strings.Fields(tt.path))
+			if err != nil || cmd.CommandPath() != "echopoint "+tt.path {
+				t.Fatalf("command %q not found: %v", tt.path, err)
+			}
+			if got := cmd.Flags().Lookup("select") != nil; got != tt.selectFlow {
+				t.Errorf("--select defined = %v, want %v", got, tt.selectFlow)
+			}
+			if got := cmd.Flags().Lookup("select-node") != nil; got != tt.selectNode {
+				t.Errorf("--select-node defined = %v, want %v", got, tt.selectNode)
+			}
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"echopoint-cli/internal/auth"
 	"echopoint-cli/internal/client"
 	"echopoint-cli/internal/config"
 	"echopoint-cli/internal/output"
+	"echopoint-cli/internal/redact"
 
 	"github.com/spf13/cobra"
 )
@@ -21,101 +25,308 @@ type AppState struct {
 	Token        string
 	Client       *client.Client
 	Debug        bool
+	Fields       []string
+	Build        BuildInfo
+
+	// Wide adds extra columns to list tables; Compact truncates table
+	// cells to fit the terminal
+	Wide    bool
+	Compact bool
+
+	// TimeFormat and UTC control how timestamps are shown in table output
+	TimeFormat output.TimeFormat
+	UTC        bool
+
+	// Canonical sorts mapping keys in YAML output
+	Canonical bool
+
+	// Pretty indents JSON output; it defaults to whether stdout is a terminal
+	Pretty bool
+
+	// CompactIDs shortens IDs in tables and the TUI to their first characters
+	CompactIDs bool
+
+	// Color enables ANSI colors; it is off with --no-color, NO_COLOR, or when
+	// stdout is not a terminal
+	Color bool
+
+	// trace receives every HTTP exchange when --trace is set
+	trace io.Writer
+
+	// redactor masks sensitive body fields in traces and debug logs
+	redactor *redact.Redactor
+
+	// mockDir serves API responses from files instead of the network
+	mockDir string
+
+	// noCache is --no-cache, which bypasses every on-disk response cache
+	noCache bool
+
+	// ctx bounds the whole command with --deadline; each HTTP request is
+	// bounded separately by api.timeout. cancel releases its timer.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// prepare resolves config, credentials, and the API client for cmd.
+	// It backs PersistentPreRunE and is reused by shell completion, which
+	// cobra runs without invoking the pre-run hooks.
+	prepare func(cmd *cobra.Command) error
+
+	// configPathSource records whether ConfigPath came from a flag, the
+	// environment, or the default location
+	configPathSource config.Source
 }
 
-func NewRootCmd() *cobra.Command {
-	state := &AppState{}
+func NewRootCmd(build BuildInfo) *cobra.Command {
+	state := &AppState{Build: build}
 
 	var (
-		flagConfig string
-		flagAPIURL string
-		flagOutput string
-		flagToken  string
-		flagDebug  bool
+		flagConfig  string
+		flagAPIURL  string
+		flagOutput  string
+		flagToken   string
+		flagDebug   bool
+		flagNoCache bool
+		flagFields  []string
+		flagVersion string
+		flagWide    bool
+		flagCompact bool
+		flagTime    string
+		flagUTC     bool
+		flagCanon   bool
+		flagPretty  bool
+		flagNoColor bool
+		flagShortID bool
+		flagTrace   string
+		flagRedact  []string
+		flagMockDir string
+		flagDeadln  time.Duration
 	)
 
-	cmd := &cobra.Command{
-		Use:   "echopoint",
-		Short: "Echopoint CLI",
-		Long:  "Echopoint CLI for managing webhooks, flows, collections, and analytics.",
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			cfg, cfgPath, err := loadConfig(flagConfig)
+	state.prepare = func(cmd *cobra.Command) error {
+		cfg, cfgPath, cfgSource, err := loadConfig(flagConfig)
+		var parseErr *config.ParseError
+		if errors.As(err, &parseErr) {
+			// A typo in the config file should not lock users out of every
+			// command, including config reset
+			fmt.Fprintf(os.Stderr, "Warning: %v; using defaults\n", err)
+		} else if err != nil {
+			return err
+		}
+
+		if flagAPIURL != "" {
+			cfg.API.BaseURL = flagAPIURL
+			cfg.SetSource("api.base_url", config.SourceFlag)
+		}
+		if envAPI := os.Getenv("ECHOPOINT_API_URL"); envAPI != "" {
+			cfg.API.BaseURL = envAPI
+			cfg.SetSource("api.base_url", config.SourceEnv)
+		}
+
+		if flagVersion != "" {
+			cfg.API.Version = flagVersion
+			cfg.SetSource("api.version", config.SourceFlag)
+		}
+		if envVersion := os.Getenv("ECHOPOINT_API_VERSION"); envVersion != "" {
+			cfg.API.Version = envVersion
+			cfg.SetSource("api.version", config.SourceEnv)
+		}
+
+		if flagOutput != "" {
+			cfg.Defaults.OutputFormat = flagOutput
+			cfg.SetSource("defaults.output_format", config.SourceFlag)
+		}
+		if envOutput := os.Getenv("ECHOPOINT_OUTPUT_FORMAT"); envOutput != "" {
+			cfg.Defaults.OutputFormat = envOutput
+			cfg.SetSource("defaults.output_format", config.SourceEnv)
+		}
+		outputValue := cfg.Defaults.OutputFormat
+
+		// Skip token validation for auth commands other than whoami, which
+		// reports on the token in use, and for doctor, which checks it itself
+		var token string
+		if (cmd.Parent() == nil || cmd.Parent().Name() != "auth" || cmd.Name() == "whoami") && !isDoctorCommand(cmd) {
+			token, err = resolveToken(flagToken)
 			if err != nil {
 				return err
 			}
+		}
 
-			if flagAPIURL != "" {
-				cfg.API.BaseURL = flagAPIURL
-			}
-			if envAPI := os.Getenv("ECHOPOINT_API_URL"); envAPI != "" {
-				cfg.API.BaseURL = envAPI
-			}
+		state.Config = cfg
+		state.ConfigPath = cfgPath
+		state.configPathSource = cfgSource
+		state.OutputFormat = output.ParseFormat(outputValue)
+		if state.OutputFormat == output.FormatJSONL && !listsItems(cmd) {
+			// Single results have no items to split into lines
+			state.OutputFormat = output.FormatJSON
+		}
+		state.Token = token
+		state.Debug = flagDebug
+		state.Fields = flagFields
 
-			outputValue := cfg.Defaults.OutputFormat
-			if flagOutput != "" {
-				outputValue = flagOutput
-			}
-			if envOutput := os.Getenv("ECHOPOINT_OUTPUT_FORMAT"); envOutput != "" {
-				outputValue = envOutput
-			}
+		if len(flagFields) > 0 && state.OutputFormat != output.FormatJSON && state.OutputFormat != output.FormatJSONL {
+			return fmt.Errorf("--fields requires --output json or jsonl")
+		}
+		if flagWide && flagCompact {
+			return fmt.Errorf("--wide and --compact cannot be used together")
+		}
+		state.Wide = flagWide
+		state.Compact = flagCompact
 
-			// Skip token validation for auth commands
-			var token string
-			if cmd.Parent() == nil || cmd.Parent().Name() != "auth" {
-				token, err = resolveToken(flagToken)
-				if err != nil {
-					return err
-				}
-			}
+		state.TimeFormat, err = output.ParseTimeFormat(flagTime)
+		if err != nil {
+			return err
+		}
+		state.UTC = flagUTC
+		state.Canonical = flagCanon
+		state.CompactIDs = flagShortID
+		state.Pretty = flagPretty
+		if !cmd.Flags().Changed("pretty") {
+			// Indent for people; keep piped output on one line
+			state.Pretty = isTerminal(os.Stdout)
+		}
+		state.Color = !flagNoColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
 
-			state.Config = cfg
-			state.ConfigPath = cfgPath
-			state.OutputFormat = output.ParseFormat(outputValue)
-			state.Token = token
-			state.Debug = flagDebug
+		if flagDeadln < 0 {
+			return fmt.Errorf("--deadline must not be negative")
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = state.Context()
+		}
+		if flagDeadln > 0 {
+			ctx, state.cancel = context.WithTimeout(ctx, flagDeadln)
+			cmd.SetContext(ctx)
+		}
+		state.ctx = ctx
 
-			// Set debug environment variable if --debug flag is used
-			if flagDebug {
-				os.Setenv("ECHOPOINT_DEBUG", "DEBUG")
-			}
+		// Set debug environment variable if --debug flag is used
+		if flagDebug {
+			os.Setenv("ECHOPOINT_DEBUG", "DEBUG")
+		}
 
-			cli, err := client.New(cfg.API.BaseURL, token, cfg.API.Timeout)
+		state.redactor = redact.New(append(strings.Split(cfg.Debug.RedactKeys, ","), flagRedact...)...)
+
+		if flagTrace != "" {
+			file, err := os.OpenFile(flagTrace, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
 			if err != nil {
+				return fmt.Errorf("failed to open trace file: %w", err)
+			}
+			state.trace = file
+		}
+
+		state.mockDir = flagMockDir
+		if state.mockDir == "" {
+			state.mockDir = os.Getenv("ECHOPOINT_MOCK_DIR")
+		}
+
+		state.noCache = flagNoCache
+
+		cli, err := state.newClient(token, cfg.Cache.TTL)
+		if err != nil {
+			// The config commands never call the API, and must keep working
+			// so a bad api.base_url can be fixed; doctor reports it as a check
+			switch {
+			case isDoctorCommand(cmd):
+			case isConfigCommand(cmd):
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			default:
 				return err
 			}
-			state.Client = cli
+		}
+		state.Client = cli
+
+		return nil
+	}
 
+	cmd := &cobra.Command{
+		Use:   "echopoint",
+		Short: "Echopoint CLI",
+		Long:  "Echopoint CLI for managing webhooks, flows, collections, and analytics.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return state.prepare(cmd)
+		},
+		// Cobra skips post-run hooks when a command fails; main exits right
+		// after, which releases the same resources
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			state.release()
 			return nil
 		},
 	}
 
 	cmd.PersistentFlags().StringVar(&flagConfig, "config", "", "Path to config file")
 	cmd.PersistentFlags().StringVar(&flagAPIURL, "api-url", "", "Override API base URL")
-	cmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "Output format: table, json, yaml")
+	cmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "Output format: table, json, jsonl, yaml")
 	cmd.PersistentFlags().StringVar(&flagToken, "token", "", "Session token (overrides stored credentials)")
 	cmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Enable debug logging")
+	cmd.PersistentFlags().StringArrayVar(&flagFields, "fields", nil, "JSONPath expression to project JSON output (repeatable)")
+	cmd.PersistentFlags().BoolVar(&flagWide, "wide", false, "Show extra columns in table output")
+	cmd.PersistentFlags().BoolVar(&flagCompact, "compact", false, "Truncate table cells to fit the terminal width")
+	cmd.PersistentFlags().StringVar(&flagTime, "time-format", string(output.TimeFormatRFC3339), "Timestamp format: rfc3339 or relative")
+	cmd.PersistentFlags().BoolVar(&flagUTC, "utc", false, "Show timestamps in UTC instead of local time")
+	cmd.PersistentFlags().BoolVar(&flagCanon, "canonical", false, "Sort keys in YAML output so repeated exports are byte-identical")
+	cmd.PersistentFlags().BoolVar(&flagPretty, "pretty", false, "Indent JSON output; use --pretty=false for single-line JSON (default: indent only on a terminal)")
+	cmd.PersistentFlags().BoolVar(&flagShortID, "compact-ids", false, "Show IDs in tables and the TUI shortened to 8 characters (longer where needed to stay unique)")
+	cmd.PersistentFlags().BoolVar(&flagNoColor, "no-color", false, "Disable colored output (also set by the NO_COLOR environment variable)")
+	cmd.PersistentFlags().StringVar(&flagVersion, "api-version", "", "Pin requests to a server API version (sent as X-API-Version)")
+	cmd.PersistentFlags().StringVar(&flagTrace, "trace", "", "Record every HTTP request and response (credentials redacted) to this file")
+	cmd.PersistentFlags().StringArrayVar(&flagRedact, "body-redact", nil, "Also mask body fields whose names contain this in --trace and debug logs (repeatable; adds to debug.redact_keys)")
+	cmd.PersistentFlags().StringVar(&flagMockDir, "mock-dir", "", "Serve API responses from canned files in this directory (for testing)")
+	_ = cmd.PersistentFlags().MarkHidden("mock-dir")
+	cmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Bypass the local response caches, including ETag revalidation of flows")
+	cmd.PersistentFlags().DurationVar(&flagDeadln, "deadline", 0, "Abort the whole command after this long, across all its requests (e.g. 2m; 0 for none)")
 
 	cmd.AddCommand(
 		newAuthCmd(state),
 		newFlowsCmd(state),
 		newCollectionsCmd(state),
+		newWebhooksCmd(state),
+		newAnalyticsCmd(state),
 		newConfigCmd(state),
+		newCacheCmd(state),
 		newTUICmd(state),
+		newDoctorCmd(state),
+		newVersionCmd(state),
 	)
 
 	return cmd
 }
 
-func loadConfig(flagConfig string) (config.Config, string, error) {
-	if flagConfig != "" {
-		return config.LoadFrom(flagConfig)
-	}
+// newClient builds an API client from the resolved config for token.
+// A zero cacheTTL disables the list cache; --no-cache disables every cache.
+func (s *AppState) newClient(token string, cacheTTL time.Duration) (*client.Client, error) {
+	return client.New(client.Config{
+		BaseURL:    s.Config.API.BaseURL,
+		Token:      token,
+		Timeout:    s.Config.API.Timeout,
+		CacheTTL:   cacheTTL,
+		APIVersion: s.Config.API.Version,
+		UserAgent:  s.Build.UserAgent(),
+		Trace:      s.trace,
+		Redact:     s.redactor,
+		NoCache:    s.noCache,
+		MockDir:    s.mockDir,
 
-	if envConfig := os.Getenv("ECHOPOINT_CONFIG"); envConfig != "" {
-		return config.LoadFrom(envConfig)
+		MaxIdleConns:        s.Config.API.MaxIdleConns,
+		MaxIdleConnsPerHost: s.Config.API.MaxIdleConnsPerHost,
+		IdleConnTimeout:     s.Config.API.IdleConnTimeout,
+	})
+}
+
+// loadConfig reads the config file chosen by flag, env, or the default
+// location, and reports which of those selected it
+func loadConfig(flagConfig string) (config.Config, string, config.Source, error) {
+	path, source := flagConfig, config.SourceFlag
+	if path == "" {
+		path, source = os.Getenv("ECHOPOINT_CONFIG"), config.SourceEnv
+	}
+	if path == "" {
+		cfg, cfgPath, err := config.Load()
+		return cfg, cfgPath, config.SourceDefault, err
 	}
 
-	return config.Load()
+	cfg, cfgPath, err := config.LoadFrom(path)
+	return cfg, cfgPath, source, err
 }
 
 func resolveToken(flagToken string) (string, error) {
@@ -139,6 +350,50 @@ func resolveToken(flagToken string) (string, error) {
 	return "", nil
 }
 
+// Context returns the context for API calls made by the running command.
+// It is cancelled when --deadline passes.
+func (s *AppState) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// release stops the --deadline timer and closes the --trace file once the
+// command has finished
+func (s *AppState) release() {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	if closer, ok := s.trace.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close trace file: %v\n", err)
+		}
+		s.trace = nil
+	}
+}
+
+// jsonlAnnotation marks commands that print one JSON line per item with
+// --output jsonl; other commands print plain JSON instead
+const jsonlAnnotation = "echopoint/jsonl"
+
+// listsItems reports whether cmd supports --output jsonl
+func listsItems(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[jsonlAnnotation]
+	return ok
+}
+
+// isConfigCommand reports whether cmd is the config command or one of its subcommands
+func isConfigCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "config" && c.HasParent() && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
 func requireToken(state *AppState) error {
 	if state.Token == "" {
 		return fmt.Errorf("authentication required: run 'echopoint auth login' or set ECHOPOINT_TOKEN")
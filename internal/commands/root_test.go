@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"echopoint-cli/internal/client"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestState returns an authenticated state whose client talks to handler,
+// with the cache directory in a temporary home
+func newTestState(t *testing.T, handler http.Handler) *AppState {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("HOME", t.TempDir())
+
+	c, err := client.New(client.Config{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	return &AppState{Token: "test-token", Client: c}
+}
+
+// executeRoot runs the CLI with args against a temporary home and config
+// file and returns the root command
+func executeRoot(t *testing.T, args ...string) (*cobra.Command, error) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ECHOPOINT_TOKEN", "")
+
+	root := NewRootCmd(BuildInfo{Version: "test"})
+	root.SetArgs(append([]string{"--config", filepath.Join(home, "config.yaml")}, args...))
+	return root, root.Execute()
+}
+
+func TestDeadlineReleasedAfterCommand(t *testing.T) {
+	root, err := executeRoot(t, "--deadline", "1h", "version")
+	if err != nil {
+		t.Fatalf("version: %v", err)
+	}
+	cmd, _, err := root.Find([]string{"version"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := cmd.Context()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("command context has no deadline")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("command context error = %v after the command finished, want it cancelled", ctx.Err())
+	}
+}
+
+func TestReleaseClosesTraceFile(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "trace.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	state := &AppState{trace: file, ctx: ctx, cancel: cancel}
+
+	state.release()
+
+	if _, err := file.WriteString("late"); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write after release = %v, want %v", err, os.ErrClosed)
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("context error after release = %v, want it cancelled", ctx.Err())
+	}
+	state.release() // a second call is a no-op
+}
@@ -3,8 +3,11 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"echopoint-cli/internal/tui"
+	"echopoint-cli/internal/tui/floweditor"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -12,6 +15,8 @@ import (
 
 func newTUICmd(state *AppState) *cobra.Command {
 	var flagDebug bool
+	var flagMaxBody int
+	var flagWatch time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "tui",
@@ -26,10 +31,27 @@ func newTUICmd(state *AppState) *cobra.Command {
 			if flagDebug {
 				os.Setenv("ECHOPOINT_DEBUG", "DEBUG")
 			}
+			floweditor.GetLogger().SetRedactor(state.redactor)
+			if cmd.Flags().Changed("max-body-log") {
+				if flagMaxBody < 0 {
+					return fmt.Errorf("--max-body-log must not be negative")
+				}
+				os.Setenv("ECHOPOINT_DEBUG_MAX_BODY", strconv.Itoa(flagMaxBody))
+			}
+
+			// Refreshing from the response cache would hide external changes
+			cli := state.Client
+			if flagWatch > 0 {
+				var err error
+				cli, err = state.newClient(state.Token, 0)
+				if err != nil {
+					return err
+				}
+			}
 
 			// Launch TUI with authenticated client
-			model := tui.New(state.Client)
-			program := tea.NewProgram(model, tea.WithAltScreen())
+			model := tui.New(cli, flagWatch, state.CompactIDs)
+			program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 			if _, err := program.Run(); err != nil {
 				fmt.Fprintf(os.Stderr, "TUI error: %v\n", err)
 				return err
@@ -39,6 +61,9 @@ func newTUICmd(state *AppState) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&flagDebug, "debug", false, "Enable debug logging for flow editor")
+	cmd.Flags().IntVar(&flagMaxBody, "max-body-log", floweditor.DefaultMaxBodyLog, "Bytes of each request/response body to keep in the debug log (0 for no limit; also ECHOPOINT_DEBUG_MAX_BODY)")
+	cmd.Flags().DurationVar(&flagWatch, "watch", 0, "Refresh the flow list at this interval (e.g. 10s)")
+	cmd.Flags().Lookup("watch").NoOptDefVal = "10s"
 
 	return cmd
 }
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// BuildInfo describes the running binary; release builds set it via ldflags
+type BuildInfo struct {
+	Version string `json:"version" yaml:"version"`
+	Commit  string `json:"commit" yaml:"commit"`
+	Date    string `json:"date" yaml:"date"`
+}
+
+// UserAgent identifies the CLI build and platform to the API
+func (b BuildInfo) UserAgent() string {
+	return fmt.Sprintf("echopoint-cli/%s (%s; %s)", b.Version, runtime.GOOS, runtime.GOARCH)
+}
+
+func newVersionCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the CLI version",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := struct {
+				BuildInfo `yaml:",inline"`
+				GoVersion string `json:"go_version" yaml:"go_version"`
+				Platform  string `json:"platform" yaml:"platform"`
+			}{
+				BuildInfo: state.Build,
+				GoVersion: runtime.Version(),
+				Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, info)
+			case output.FormatYAML:
+				return printYAML(state, info)
+			default:
+				fmt.Fprintf(os.Stdout, "echopoint %s\n", info.Version)
+				fmt.Fprintf(os.Stdout, "Commit: %s\n", info.Commit)
+				fmt.Fprintf(os.Stdout, "Built: %s\n", info.Date)
+				fmt.Fprintf(os.Stdout, "Go: %s (%s)\n", info.GoVersion, info.Platform)
+				return nil
+			}
+		},
+	}
+}
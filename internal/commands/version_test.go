@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestBuildInfoUserAgent(t *testing.T) {
+	info := BuildInfo{Version: "1.4.0", Commit: "abc123", Date: "2026-01-01"}
+	want := "echopoint-cli/1.4.0 (" + runtime.GOOS + "; " + runtime.GOARCH + ")"
+	if got := info.UserAgent(); got != want {
+		t.Errorf("UserAgent() = %q, want %q", got, want)
+	}
+}
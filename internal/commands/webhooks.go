@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// newWebhooksCmd creates the webhooks command. The API has no endpoint that
+// lists webhooks, so there is no webhooks list; endpoints are addressed by the
+// ID printed when they are created.
+func newWebhooksCmd(state *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Manage webhook endpoints",
+		Long: `Manage webhook endpoints and inspect the requests they receive.
+
+The API cannot list webhook endpoints, so keep the ID printed by
+"echopoint webhooks create" to address an endpoint later.`,
+	}
+
+	cmd.AddCommand(
+		newWebhooksGetCmd(state),
+		newWebhooksCreateCmd(state),
+		newWebhooksDeleteCmd(state),
+		newWebhooksRequestsCmd(state),
+	)
+
+	return cmd
+}
+
+func newWebhooksGetCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get webhook endpoint details",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().GetWebhookWithResponse(state.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, resp.JSON200)
+			case output.FormatYAML:
+				return printYAML(state, resp.JSON200)
+			default:
+				printWebhook(state, resp.JSON200)
+				return nil
+			}
+		},
+	}
+}
+
+func newWebhooksCreateCmd(state *AppState) *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a webhook endpoint",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+			if name == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			resp, err := state.Client.API().CreateWebhookWithResponse(state.Context(), api.CreateWebhookRequest{Name: name})
+			if err != nil {
+				return err
+			}
+			if resp.JSON201 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, resp.JSON201)
+			case output.FormatYAML:
+				return printYAML(state, resp.JSON201)
+			default:
+				fmt.Fprintf(os.Stdout, "ID: %s\n", resp.JSON201.Id)
+				fmt.Fprintf(os.Stdout, "Name: %s\n", resp.JSON201.Name)
+				fmt.Fprintf(os.Stdout, "URL: %s\n", resp.JSON201.Url)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Webhook endpoint name")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newWebhooksDeleteCmd(state *AppState) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a webhook endpoint",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			resp, err := state.Client.API().DeleteWebhookWithResponse(state.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if resp.HTTPResponse.StatusCode != http.StatusNoContent {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+
+			fmt.Fprintln(os.Stdout, "Webhook deleted.")
+			return nil
+		},
+	}
+}
+
+func newWebhooksRequestsCmd(state *AppState) *cobra.Command {
+	var limit, offset int32 = 20, 0
+	var methods []string
+	var search string
+
+	cmd := &cobra.Command{
+		Use:   "requests <id>",
+		Short: "List requests received by a webhook endpoint",
+		Example: `  echopoint webhooks requests <id>
+  echopoint webhooks requests <id> --method POST --search order_id
+  echopoint webhooks requests <id> -o jsonl --limit 100`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{jsonlAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireToken(state); err != nil {
+				return err
+			}
+
+			req := api.WebhookRequestSearchRequest{
+				Pagination: &api.PaginationRequest{Limit: &limit, Offset: &offset},
+			}
+			if search != "" {
+				req.FullTextSearch = &search
+			}
+			if len(methods) > 0 {
+				filter := &api.WebhookRequestFilter{}
+				for _, method := range methods {
+					if err := validateMethod(method); err != nil {
+						return err
+					}
+					filter.Methods = append(filter.Methods, api.HttpMethod(strings.ToUpper(method)))
+				}
+				req.Filter = filter
+			}
+
+			resp, err := state.Client.API().SearchWebhookRequestsWithResponse(state.Context(), args[0], req)
+			if err != nil {
+				return err
+			}
+			if resp.JSON200 == nil {
+				return formatAPIError(resp.HTTPResponse, resp.Body)
+			}
+			list := resp.JSON200
+
+			switch state.OutputFormat {
+			case output.FormatJSON:
+				return printJSON(state, list)
+			case output.FormatYAML:
+				return printYAML(state, list)
+			case output.FormatJSONL:
+				return printJSONL(state, list.Items)
+			default:
+				columns := output.Columns("ID", "Method", "Received", "IP", "Content-Type")
+				rows := make([][]string, 0, len(list.Items))
+				for _, request := range list.Items {
+					rows = append(rows, []string{
+						request.Id,
+						string(request.Method),
+						formatTime(state, request.ReceivedAt),
+						request.IpAddress,
+						stringValue(request.ContentType),
+					})
+				}
+				compactIDColumn(state, rows, 0)
+				printListTotal(list.Total, len(list.Items), len(list.Items), false)
+				return printTable(state, columns, rows)
+			}
+		},
+	}
+
+	cmd.Flags().Int32Var(&limit, "limit", limit, "Maximum number of requests to return")
+	cmd.Flags().Int32Var(&offset, "offset", offset, "Number of requests to skip")
+	cmd.Flags().StringArrayVar(&methods, "method", nil, "Only show requests with this HTTP method (repeatable)")
+	cmd.Flags().StringVar(&search, "search", "", "Only show requests matching this text")
+	return cmd
+}
+
+func printWebhook(state *AppState, webhook *api.Webhook) {
+	fmt.Fprintf(os.Stdout, "ID: %s\n", webhook.Id)
+	fmt.Fprintf(os.Stdout, "Name: %s\n", webhook.Name)
+	fmt.Fprintf(os.Stdout, "URL: %s\n", webhook.Url)
+	fmt.Fprintf(os.Stdout, "Requests: %d\n", webhook.RequestCount)
+	fmt.Fprintf(os.Stdout, "Updated: %s\n", formatTime(state, webhook.UpdatedAt))
+	fmt.Fprintf(os.Stdout, "Created: %s\n", formatTime(state, webhook.CreatedAt))
+}
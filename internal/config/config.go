@@ -2,8 +2,10 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -12,22 +14,85 @@ import (
 const (
 	defaultBaseURL      = "https://apidev.echopoint.dev"
 	defaultOutputFormat = "table"
+
+	// The standard library keeps only two idle connections per host, so
+	// concurrent page fetches would keep reconnecting
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 16
+	defaultIdleConnTimeout     = 90 * time.Second
 )
 
 type Config struct {
 	API struct {
-		BaseURL string        `yaml:"base_url"`
+		BaseURL string        `yaml:"base_url" config:"url"`
 		Timeout time.Duration `yaml:"timeout"`
+		Version string        `yaml:"version,omitempty"`
+
+		// Connection pool settings, tuned for commands that send many
+		// requests at once such as flows list --all
+		MaxIdleConns        int           `yaml:"max_idle_conns"`
+		MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"`
+		IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout"`
 	} `yaml:"api"`
 	Defaults struct {
-		OutputFormat string `yaml:"output_format"`
+		OutputFormat string `yaml:"output_format" config:"enum=table|json|jsonl|yaml"`
 	} `yaml:"defaults"`
+	Cache struct {
+		TTL time.Duration `yaml:"ttl"`
+	} `yaml:"cache"`
+	Debug struct {
+		// RedactKeys lists extra body field names, comma-separated, whose
+		// values are masked in traces and debug logs
+		RedactKeys string `yaml:"redact_keys,omitempty"`
+	} `yaml:"debug"`
+
+	// Sources records where each key's value came from. Keys that are
+	// absent still hold their default.
+	Sources map[string]Source `yaml:"-" json:"-"`
+
+	// unexpanded holds the keys whose file value referenced environment
+	// variables, so Save can write the reference back
+	unexpanded map[string]envValue
+}
+
+// envValue is a config value as written in the file and as loaded
+type envValue struct {
+	raw, loaded string
+}
+
+// Source identifies the layer that provided a config value
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// SetSource records that src provided the value of key
+func (c *Config) SetSource(key string, src Source) {
+	if c.Sources == nil {
+		c.Sources = make(map[string]Source)
+	}
+	c.Sources[key] = src
+}
+
+// Source reports which layer provided the value of key
+func (c *Config) Source(key string) Source {
+	if src, ok := c.Sources[key]; ok {
+		return src
+	}
+	return SourceDefault
 }
 
 func Default() Config {
 	cfg := Config{}
 	cfg.API.BaseURL = defaultBaseURL
 	cfg.API.Timeout = 30 * time.Second
+	cfg.API.MaxIdleConns = defaultMaxIdleConns
+	cfg.API.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	cfg.API.IdleConnTimeout = defaultIdleConnTimeout
 	cfg.Defaults.OutputFormat = defaultOutputFormat
 	return cfg
 }
@@ -64,6 +129,23 @@ func Load() (Config, string, error) {
 	return LoadFrom(path)
 }
 
+// ParseError reports a config file that exists but cannot be decoded
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid config file %s: %v (fix it or run 'echopoint config reset')", e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// LoadFrom reads the config file at path. A missing file yields the defaults.
+// A malformed file yields the defaults together with a *ParseError, so
+// callers can choose to continue without it.
 func LoadFrom(path string) (Config, string, error) {
 	cfg := Default()
 	data, err := os.ReadFile(path)
@@ -75,19 +157,54 @@ func LoadFrom(path string) (Config, string, error) {
 	}
 
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return Config{}, "", err
+		return Default(), path, &ParseError{Path: path, Err: err}
 	}
 
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Default(), path, &ParseError{Path: path, Err: err}
+	}
+	for _, key := range Keys() {
+		if hasKey(raw, key) {
+			cfg.SetSource(key, SourceFile)
+		}
+	}
+	cfg.expandEnv()
+
 	if cfg.API.BaseURL == "" {
 		cfg.API.BaseURL = defaultBaseURL
+		delete(cfg.Sources, "api.base_url")
 	}
 	if cfg.Defaults.OutputFormat == "" {
 		cfg.Defaults.OutputFormat = defaultOutputFormat
+		delete(cfg.Sources, "defaults.output_format")
+	}
+	for key, value := range cfg.unexpanded {
+		value.loaded, _ = cfg.Get(key)
+		cfg.unexpanded[key] = value
 	}
 
 	return cfg, path, nil
 }
 
+// hasKey reports whether the dotted key is present in a decoded yaml document
+func hasKey(doc map[string]interface{}, key string) bool {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		value, ok := doc[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		if doc, ok = value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
 func Save(cfg Config) (string, error) {
 	path, err := ConfigPath()
 	if err != nil {
@@ -97,6 +214,13 @@ func Save(cfg Config) (string, error) {
 		return "", err
 	}
 
+	// Keep ${VAR} references for values that were not changed
+	for key, value := range cfg.unexpanded {
+		if field, _, err := cfg.lookup(key); err == nil && field.String() == value.loaded {
+			field.SetString(value.raw)
+		}
+	}
+
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return "", err
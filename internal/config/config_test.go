@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("EP_HOST", "api.example.com")
+	t.Setenv("EP_EMPTY", "")
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "https://${EP_HOST}", want: "https://api.example.com"},
+		{value: "${EP_UNSET:-https://fallback.example.com}", want: "https://fallback.example.com"},
+		{value: "${EP_EMPTY:-default}", want: "default"},
+		{value: "${EP_HOST:-unused}", want: "api.example.com"},
+		{value: "${EP_UNSET}", want: ""},
+		{value: "price$5 and $EP_HOST", want: "price$5 and $EP_HOST"},
+		{value: "${not valid}", want: "${not valid}"},
+		{value: "${EP_HOST}-${EP_UNSET:-x}", want: "api.example.com-x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := expandEnv(tt.value); got != tt.want {
+				t.Errorf("expandEnv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// writeConfig writes a config file into a temporary home and returns its path
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".echopoint", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFromExpandsEnv(t *testing.T) {
+	t.Setenv("EP_API_URL", "https://staging.example.com")
+	t.Setenv("EP_FORMAT", "")
+	path := writeConfig(t, `api:
+  base_url: ${EP_API_URL}
+  version: ${EP_API_VERSION:-2}
+defaults:
+  output_format: ${EP_FORMAT:-yaml}
+debug:
+  redact_keys: $literal,${EP_UNSET}
+`)
+
+	cfg, _, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	for key, want := range map[string]string{
+		"api.base_url":           "https://staging.example.com",
+		"api.version":            "2",
+		"defaults.output_format": "yaml",
+		"debug.redact_keys":      "$literal,",
+	} {
+		if got, _ := cfg.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadFromDefaultsWhenExpansionIsEmpty(t *testing.T) {
+	path := writeConfig(t, "api:\n  base_url: ${EP_UNSET_URL}\n")
+
+	cfg, _, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if cfg.API.BaseURL != defaultBaseURL {
+		t.Errorf("api.base_url = %q, want the default %q", cfg.API.BaseURL, defaultBaseURL)
+	}
+	if cfg.Sources["api.base_url"] == SourceFile {
+		t.Error("api.base_url is reported as set by the file")
+	}
+}
+
+func TestSaveKeepsEnvReferences(t *testing.T) {
+	t.Setenv("EP_API_URL", "https://staging.example.com")
+	path := writeConfig(t, "api:\n  base_url: ${EP_API_URL}\n  version: ${EP_API_VERSION:-2}\n")
+
+	cfg, _, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if err := cfg.Set("api.version", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	if !strings.Contains(saved, "base_url: ${EP_API_URL}") {
+		t.Errorf("saved config lost the unchanged reference:\n%s", saved)
+	}
+	if !strings.Contains(saved, `version: "3"`) {
+		t.Errorf("saved config lost the changed value:\n%s", saved)
+	}
+	if strings.Contains(saved, "staging.example.com") {
+		t.Errorf("saved config contains the expanded value:\n%s", saved)
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config keys are the dotted yaml tag paths of Config's leaf fields, e.g.
+// "api.base_url". Fields may carry a `config` tag for extra validation:
+//
+//	config:"url"            value must be an absolute http(s) URL
+//	config:"enum=a|b|c"     value must be one of the listed options
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Keys returns every settable config key in declaration order.
+func Keys() []string {
+	var keys []string
+	walkFields(reflect.TypeOf(Config{}), "", func(key string, _ reflect.StructField, _ []int) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// Get returns the value of key formatted as it would be passed to Set.
+func (c *Config) Get(key string) (string, error) {
+	field, _, err := c.lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	if field.Type() == durationType {
+		return time.Duration(field.Int()).String(), nil
+	}
+	return fmt.Sprint(field.Interface()), nil
+}
+
+// Set parses value according to the type of key and stores it.
+func (c *Config) Set(key, value string) error {
+	field, meta, err := c.lookup(key)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration for %s: %q (e.g. 30s, 1m)", key, value)
+		}
+		if d < 0 {
+			return fmt.Errorf("invalid duration for %s: must not be negative", key)
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean for %s: %q (use true or false)", key, value)
+		}
+		field.SetBool(b)
+	case field.CanInt():
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %q", key, value)
+		}
+		if n < 0 {
+			return fmt.Errorf("invalid integer for %s: must not be negative", key)
+		}
+		field.SetInt(n)
+	case field.Kind() == reflect.String:
+		if err := validateString(key, value, meta.Tag.Get("config")); err != nil {
+			return err
+		}
+		field.SetString(value)
+	default:
+		return fmt.Errorf("config key %s has unsupported type %s", key, field.Type())
+	}
+
+	return nil
+}
+
+func (c *Config) lookup(key string) (reflect.Value, reflect.StructField, error) {
+	var (
+		found bool
+		meta  reflect.StructField
+		index []int
+	)
+	walkFields(reflect.TypeOf(*c), "", func(k string, f reflect.StructField, i []int) {
+		if k == key {
+			found, meta, index = true, f, i
+		}
+	})
+	if !found {
+		return reflect.Value{}, reflect.StructField{}, fmt.Errorf("unknown config key: %s (valid keys: %s)", key, strings.Join(Keys(), ", "))
+	}
+	return reflect.ValueOf(c).Elem().FieldByIndex(index), meta, nil
+}
+
+// walkFields calls fn for each leaf field of t with its dotted yaml key
+func walkFields(t reflect.Type, prefix string, fn func(key string, field reflect.StructField, index []int)) {
+	walkFieldsIndex(t, prefix, nil, fn)
+}
+
+func walkFieldsIndex(t reflect.Type, prefix string, parent []int, fn func(string, reflect.StructField, []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		index := append(append([]int{}, parent...), i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			walkFieldsIndex(field.Type, key, index, fn)
+			continue
+		}
+		fn(key, field, index)
+	}
+}
+
+// envReference matches ${NAME} and ${NAME:-default}
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${NAME} references in value with the environment
+// variable, or with the default given as ${NAME:-default} when it is unset or
+// empty. A bare $ is left alone so values such as URLs are never mangled.
+func expandEnv(value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		match := envReference.FindStringSubmatch(ref)
+		if v := os.Getenv(match[1]); v != "" {
+			return v
+		}
+		return match[2]
+	})
+}
+
+// expandEnv expands environment references in every string field
+func (c *Config) expandEnv() {
+	walkFields(reflect.TypeOf(*c), "", func(key string, f reflect.StructField, index []int) {
+		if f.Type.Kind() != reflect.String {
+			return
+		}
+		field := reflect.ValueOf(c).Elem().FieldByIndex(index)
+		value := field.String()
+		if expanded := expandEnv(value); expanded != value {
+			if c.unexpanded == nil {
+				c.unexpanded = make(map[string]envValue)
+			}
+			c.unexpanded[key] = envValue{raw: value}
+			field.SetString(expanded)
+		}
+	})
+}
+
+func validateString(key, value, rule string) error {
+	switch {
+	case rule == "url":
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid URL for %s: %q (expected http(s)://host)", key, value)
+		}
+	case strings.HasPrefix(rule, "enum="):
+		options := strings.Split(strings.TrimPrefix(rule, "enum="), "|")
+		for _, option := range options {
+			if value == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value for %s: %q (must be one of: %s)", key, value, strings.Join(options, ", "))
+	}
+	return nil
+}
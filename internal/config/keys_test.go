@@ -0,0 +1,89 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string // value reported by Get afterwards
+		wantErr    string
+	}{
+		{key: "api.timeout", value: "45s", want: "45s"},
+		{key: "api.timeout", value: "1m30s", want: "1m30s"},
+		{key: "api.timeout", value: "0s", want: "0s"},
+		{key: "api.timeout", value: "30", wantErr: "invalid duration"},
+		{key: "api.timeout", value: "-5s", wantErr: "must not be negative"},
+		{key: "cache.ttl", value: "1m", want: "1m0s"},
+
+		{key: "api.max_idle_conns", value: "50", want: "50"},
+		{key: "api.max_idle_conns", value: "many", wantErr: "invalid integer"},
+		{key: "api.max_idle_conns", value: "-1", wantErr: "must not be negative"},
+		{key: "api.max_idle_conns_per_host", value: "99999999999999999999", wantErr: "invalid integer"},
+
+		{key: "api.base_url", value: "https://api.example.com", want: "https://api.example.com"},
+		{key: "api.base_url", value: "http://localhost:8080", want: "http://localhost:8080"},
+		{key: "api.base_url", value: "api.example.com", wantErr: "invalid URL"},
+		{key: "api.base_url", value: "ftp://api.example.com", wantErr: "invalid URL"},
+
+		{key: "defaults.output_format", value: "yaml", want: "yaml"},
+		{key: "defaults.output_format", value: "xml", wantErr: "must be one of: table, json, jsonl, yaml"},
+
+		{key: "debug.redact_keys", value: "secret,pin", want: "secret,pin"},
+
+		{key: "api.nope", value: "1", wantErr: "unknown config key: api.nope (valid keys: api.base_url"},
+		{key: "api", value: "1", wantErr: "unknown config key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			cfg := Default()
+			err := cfg.Set(tt.key, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Set error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			got, err := cfg.Get(tt.key)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLeavesValueOnError(t *testing.T) {
+	cfg := Default()
+	if err := cfg.Set("api.timeout", "soon"); err == nil {
+		t.Fatal("Set accepted an invalid duration")
+	}
+	if got, _ := cfg.Get("api.timeout"); got != "30s" {
+		t.Errorf("api.timeout = %q after a failed Set, want the default 30s", got)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+	for _, key := range []string{"api.base_url", "api.timeout", "api.version", "defaults.output_format", "cache.ttl", "debug.redact_keys"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("Keys() is missing %s", key)
+		}
+	}
+	for _, key := range keys {
+		if strings.HasPrefix(key, "sources") || strings.HasPrefix(key, "unexpanded") {
+			t.Errorf("Keys() includes internal field %s", key)
+		}
+		if _, err := (&Config{}).Get(key); err != nil {
+			t.Errorf("Get(%s): %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `# service settings
+BASE_URL=https://api.example.com
+export TOKEN = abc123
+
+EMPTY=
+SPACED =   padded value   
+COMMENTED=value # trailing comment
+HASH=color#fff
+SINGLE='keep $HOME and \n # as is'
+DOUBLE="line one\nline two\t\"quoted\" \\ done" # comment
+URL_WITH_EQUALS=https://example.com/?a=1&b=2
+BASE_URL=https://override.example.com
+`
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := map[string]string{
+		"BASE_URL":        "https://override.example.com",
+		"TOKEN":           "abc123",
+		"EMPTY":           "",
+		"SPACED":          "padded value",
+		"COMMENTED":       "value",
+		"HASH":            "color#fff",
+		"SINGLE":          `keep $HOME and \n # as is`,
+		"DOUBLE":          "line one\nline two\t\"quoted\" \\ done",
+		"URL_WITH_EQUALS": "https://example.com/?a=1&b=2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "no equals", input: "A=1\nJUSTAKEY", wantErr: "line 2: expected KEY=VALUE"},
+		{name: "empty key", input: "=value", wantErr: "line 1: expected KEY=VALUE"},
+		{name: "space in key", input: "MY KEY=value", wantErr: "line 1: expected KEY=VALUE"},
+		{name: "unterminated double quote", input: `A="open`, wantErr: "line 1: unterminated \" quote"},
+		{name: "unterminated single quote", input: `A='open`, wantErr: "line 1: unterminated ' quote"},
+		{name: "text after quote", input: `A="one" two`, wantErr: "line 1: unexpected text after closing quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("A=1\nB\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), path+": line 2") {
+		t.Errorf("ParseFile error = %v, want it to name the file and line", err)
+	}
+
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "missing.env")); !os.IsNotExist(err) {
+		t.Errorf("ParseFile of a missing file = %v, want a not-exist error", err)
+	}
+}
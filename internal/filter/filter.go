@@ -0,0 +1,166 @@
+// Package filter evaluates --filter expressions against list items on the
+// client side.
+//
+// An expression is FIELD OP VALUE with one of these operators:
+//
+//	=   equals (case-insensitive for text; same day for a date-only time)
+//	~   contains (text only, case-insensitive)
+//	>   greater than (numbers and times)
+//	<   less than (numbers and times)
+//
+// Times accept RFC 3339 or a YYYY-MM-DD date in local time.
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the type of a field's values
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindNumber
+	KindTime
+)
+
+// Field extracts one filterable value from an item. Set the accessor that
+// matches Kind.
+type Field[T any] struct {
+	Kind   Kind
+	String func(T) string
+	Number func(T) float64
+	Time   func(T) time.Time
+}
+
+// Expr is a parsed filter expression
+type Expr struct {
+	Field string
+	Op    string
+	Value string
+}
+
+const operators = "=~<>"
+
+// Parse splits s into field, operator, and value
+func Parse(s string) (Expr, error) {
+	i := strings.IndexAny(s, operators)
+	if i <= 0 {
+		return Expr{}, fmt.Errorf("invalid filter %q: expected FIELD OP VALUE with OP one of = ~ > <", s)
+	}
+
+	expr := Expr{
+		Field: strings.ToLower(strings.TrimSpace(s[:i])),
+		Op:    s[i : i+1],
+		Value: strings.TrimSpace(s[i+1:]),
+	}
+	if expr.Field == "" {
+		return Expr{}, fmt.Errorf("invalid filter %q: missing field", s)
+	}
+	return expr, nil
+}
+
+// Compile parses every expression and returns a predicate that matches items
+// satisfying all of them. Unknown fields, and operators that do not apply to
+// a field's kind, are errors.
+func Compile[T any](exprs []string, fields map[string]Field[T]) (func(T) bool, error) {
+	var predicates []func(T) bool
+	for _, s := range exprs {
+		expr, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		field, ok := fields[expr.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q (valid: %s)", expr.Field, strings.Join(fieldNames(fields), ", "))
+		}
+		predicate, err := compileExpr(expr, field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	return func(item T) bool {
+		for _, predicate := range predicates {
+			if !predicate(item) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func compileExpr[T any](expr Expr, field Field[T]) (func(T) bool, error) {
+	switch field.Kind {
+	case KindString:
+		want := strings.ToLower(expr.Value)
+		switch expr.Op {
+		case "=":
+			return func(item T) bool { return strings.ToLower(field.String(item)) == want }, nil
+		case "~":
+			return func(item T) bool { return strings.Contains(strings.ToLower(field.String(item)), want) }, nil
+		}
+		return nil, fmt.Errorf("%s is text and supports = and ~", expr.Field)
+
+	case KindNumber:
+		want, err := strconv.ParseFloat(expr.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a number", expr.Field)
+		}
+		switch expr.Op {
+		case "=":
+			return func(item T) bool { return field.Number(item) == want }, nil
+		case ">":
+			return func(item T) bool { return field.Number(item) > want }, nil
+		case "<":
+			return func(item T) bool { return field.Number(item) < want }, nil
+		}
+		return nil, fmt.Errorf("%s is a number and supports =, >, and <", expr.Field)
+
+	case KindTime:
+		want, dateOnly, err := parseTime(expr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects an RFC 3339 time or YYYY-MM-DD date", expr.Field)
+		}
+		switch expr.Op {
+		case "=":
+			if dateOnly {
+				end := want.AddDate(0, 0, 1)
+				return func(item T) bool {
+					t := field.Time(item)
+					return !t.Before(want) && t.Before(end)
+				}, nil
+			}
+			return func(item T) bool { return field.Time(item).Equal(want) }, nil
+		case ">":
+			return func(item T) bool { return field.Time(item).After(want) }, nil
+		case "<":
+			return func(item T) bool { return field.Time(item).Before(want) }, nil
+		}
+		return nil, fmt.Errorf("%s is a time and supports =, >, and <", expr.Field)
+	}
+
+	return nil, fmt.Errorf("unsupported field %s", expr.Field)
+}
+
+func parseTime(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+func fieldNames[T any](fields map[string]Field[T]) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
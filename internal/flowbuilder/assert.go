@@ -0,0 +1,355 @@
+package flowbuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"echopoint-cli/internal/api"
+)
+
+// Extractor types understood by the local evaluator
+const (
+	ExtractorStatusCode api.ExtractorType = "statusCode"
+	ExtractorBody       api.ExtractorType = "body"
+	ExtractorHeader     api.ExtractorType = "header"
+	ExtractorJSONPath   api.ExtractorType = "jsonPath"
+	ExtractorXMLPath    api.ExtractorType = "xmlPath"
+)
+
+// Response is the part of an HTTP response that extractors read
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// AssertionResult is the outcome of one assertion. Message holds the value
+// that was extracted, or why the assertion could not be evaluated.
+type AssertionResult struct {
+	Assertion string `json:"assertion" yaml:"assertion"`
+	Passed    bool   `json:"passed" yaml:"passed"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// EvaluateAssertion extracts the asserted value from resp and applies the
+// assertion's operator to it, following the backend's rules as closely as a
+// client can: values compare loosely, so "200" equals 200.
+func EvaluateAssertion(resp Response, assertion api.CompositeAssertion) AssertionResult {
+	result := AssertionResult{Assertion: DescribeAssertion(assertion)}
+
+	actual, err := Extract(resp, assertion.ExtractorType, assertion.ExtractorData)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	passed, err := ApplyOperator(assertion.OperatorType, actual, assertion.OperatorData)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	result.Passed = passed
+	result.Message = "got " + displayValue(actual)
+	return result
+}
+
+// EvaluateOutput extracts the value of a node output from resp and converts
+// it to the output's type
+func EvaluateOutput(resp Response, out api.Output) (interface{}, error) {
+	data := make(map[string]interface{})
+	if out.Extractor.Path != nil {
+		data["path"] = *out.Extractor.Path
+	}
+	if out.Extractor.HeaderName != nil {
+		data["header_name"] = *out.Extractor.HeaderName
+	}
+	value, err := Extract(resp, out.Extractor.Type, data)
+	if err != nil {
+		return nil, err
+	}
+	as := api.OutputExtractorAsString
+	if out.Extractor.As != nil {
+		as = *out.Extractor.As
+	}
+	return CoerceOutput(value, as)
+}
+
+// CoerceOutput converts an extracted value to an output type. Outputs are
+// strings unless they ask otherwise: other values become their JSON encoding.
+// json decodes strings that hold JSON, such as a body.
+func CoerceOutput(value interface{}, as api.OutputExtractorAs) (interface{}, error) {
+	switch as {
+	case "", api.OutputExtractorAsString:
+		return textValue(value), nil
+	case api.OutputExtractorAsNumber:
+		n, ok := numberValue(value)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a number", displayValue(value))
+		}
+		return n, nil
+	case api.OutputExtractorAsBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(textValue(value)))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a boolean", displayValue(value))
+		}
+		return b, nil
+	case api.OutputExtractorAsJson:
+		s, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("%s is not JSON", displayValue(value))
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unknown output type %q", as)
+	}
+}
+
+// DescribeAssertion renders an assertion as "extractor [target] operator [value]",
+// such as `jsonPath $.id equals "42"`
+func DescribeAssertion(assertion api.CompositeAssertion) string {
+	parts := []string{string(assertion.ExtractorType)}
+	for _, key := range []string{"path", "header_name"} {
+		if target, ok := assertion.ExtractorData[key].(string); ok && target != "" {
+			parts = append(parts, target)
+		}
+	}
+	parts = append(parts, string(assertion.OperatorType))
+	if value, ok := assertion.OperatorData["value"]; ok {
+		parts = append(parts, displayValue(value))
+	}
+	if assertion.OperatorType == api.Between {
+		parts = append(parts, displayValue(assertion.OperatorData["min"]), "and", displayValue(assertion.OperatorData["max"]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Extract reads the value an extractor selects from resp. Status codes are
+// ints, bodies and headers strings, and JSONPath matches decoded JSON as
+// returned by ExtractJSONPath. default_value in data stands in for a missing
+// header or an unmatched path.
+func Extract(resp Response, extractor api.ExtractorType, data map[string]interface{}) (interface{}, error) {
+	fallback, hasFallback := data["default_value"]
+
+	switch extractor {
+	case ExtractorStatusCode:
+		return resp.StatusCode, nil
+	case ExtractorBody:
+		return string(resp.Body), nil
+	case ExtractorHeader:
+		name, _ := data["header_name"].(string)
+		if name == "" {
+			name, _ = data["path"].(string)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("header extractor has no header_name")
+		}
+		if values := resp.Header.Values(name); len(values) > 0 {
+			return values[0], nil
+		}
+		if hasFallback {
+			return fallback, nil
+		}
+		return nil, fmt.Errorf("header %s not found", name)
+	case ExtractorJSONPath:
+		expr, _ := data["path"].(string)
+		if expr == "" {
+			return nil, fmt.Errorf("jsonPath extractor has no path")
+		}
+		value, err := ExtractJSONPath(resp.Body, expr)
+		if errors.Is(err, ErrNoMatch) && hasFallback {
+			return fallback, nil
+		}
+		return value, err
+	case ExtractorXMLPath:
+		return nil, fmt.Errorf("xmlPath extractors are not evaluated locally")
+	default:
+		return nil, fmt.Errorf("unknown extractor %q", extractor)
+	}
+}
+
+// ApplyOperator reports whether actual satisfies operator. Most operators
+// compare against data["value"]; between reads data["min"] and data["max"],
+// and regex also accepts its pattern as data["pattern"].
+func ApplyOperator(operator api.OperatorType, actual interface{}, data map[string]interface{}) (bool, error) {
+	expected := data["value"]
+
+	switch operator {
+	case api.Equals:
+		return looseEqual(actual, expected), nil
+	case api.NotEquals:
+		return !looseEqual(actual, expected), nil
+	case api.Contains:
+		return containsValue(actual, expected), nil
+	case api.NotContains:
+		return !containsValue(actual, expected), nil
+	case api.StartsWith:
+		return strings.HasPrefix(textValue(actual), textValue(expected)), nil
+	case api.EndsWith:
+		return strings.HasSuffix(textValue(actual), textValue(expected)), nil
+	case api.Empty:
+		return isEmptyValue(actual), nil
+	case api.NotEmpty:
+		return !isEmptyValue(actual), nil
+	case api.Regex:
+		pattern := textValue(expected)
+		if p, ok := data["pattern"].(string); ok {
+			pattern = p
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		return re.MatchString(textValue(actual)), nil
+	case api.GreaterThan, api.GreaterThanOrEqual, api.LessThan, api.LessThanOrEqual:
+		a, ok := numberValue(actual)
+		if !ok {
+			return false, fmt.Errorf("%s needs a number, got %s", operator, displayValue(actual))
+		}
+		b, ok := numberValue(expected)
+		if !ok {
+			return false, fmt.Errorf("%s needs a numeric value, got %s", operator, displayValue(expected))
+		}
+		switch operator {
+		case api.GreaterThan:
+			return a > b, nil
+		case api.GreaterThanOrEqual:
+			return a >= b, nil
+		case api.LessThan:
+			return a < b, nil
+		default:
+			return a <= b, nil
+		}
+	case api.Between:
+		a, ok := numberValue(actual)
+		if !ok {
+			return false, fmt.Errorf("between needs a number, got %s", displayValue(actual))
+		}
+		low, lowOK := numberValue(data["min"])
+		high, highOK := numberValue(data["max"])
+		if !lowOK || !highOK {
+			return false, fmt.Errorf("between needs numeric min and max")
+		}
+		return a >= low && a <= high, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q", operator)
+	}
+}
+
+// looseEqual compares values the way assertions written on the command line
+// expect: "200" equals 200, and other values compare by their JSON encoding
+func looseEqual(a, b interface{}) bool {
+	if x, ok := numberValue(a); ok {
+		if y, ok := numberValue(b); ok {
+			return x == y
+		}
+	}
+	_, aString := a.(string)
+	_, bString := b.(string)
+	if aString || bString {
+		return textValue(a) == textValue(b)
+	}
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+// containsValue reports whether a string holds a substring, an array holds an
+// element, or an object holds a key
+func containsValue(actual, expected interface{}) bool {
+	switch v := actual.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if looseEqual(item, expected) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		_, ok := v[textValue(expected)]
+		return ok
+	default:
+		return strings.Contains(textValue(actual), textValue(expected))
+	}
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// numberValue converts numbers and numeric strings to float64
+func numberValue(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// textValue returns strings as-is and other values JSON-encoded
+func textValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// displayValue renders a value for messages, quoting strings
+func displayValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// normalizeJSON round-trips v through JSON so differently typed but equal
+// values compare equal
+func normalizeJSON(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
@@ -0,0 +1,226 @@
+package flowbuilder
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"echopoint-cli/internal/api"
+)
+
+func TestApplyOperator(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator api.OperatorType
+		actual   interface{}
+		data     map[string]interface{}
+		want     bool
+	}{
+		{name: "equals number", operator: api.Equals, actual: 200, data: map[string]interface{}{"value": 200.0}, want: true},
+		{name: "equals string and number", operator: api.Equals, actual: 200, data: map[string]interface{}{"value": "200"}, want: true},
+		{name: "equals number and string", operator: api.Equals, actual: "200", data: map[string]interface{}{"value": 200}, want: true},
+		{name: "equals padded numeric string", operator: api.Equals, actual: 200, data: map[string]interface{}{"value": " 200 "}, want: true},
+		{name: "equals different number", operator: api.Equals, actual: 201, data: map[string]interface{}{"value": "200"}},
+		{name: "equals string", operator: api.Equals, actual: "ok", data: map[string]interface{}{"value": "ok"}, want: true},
+		{name: "equals bool and string", operator: api.Equals, actual: true, data: map[string]interface{}{"value": "true"}, want: true},
+		{name: "equals object", operator: api.Equals, actual: map[string]interface{}{"a": 1.0}, data: map[string]interface{}{"value": map[string]interface{}{"a": 1}}, want: true},
+		{name: "equals array", operator: api.Equals, actual: []interface{}{1.0, "x"}, data: map[string]interface{}{"value": []interface{}{1, "x"}}, want: true},
+		{name: "equals null", operator: api.Equals, actual: nil, data: map[string]interface{}{"value": nil}, want: true},
+		{name: "notEquals", operator: api.NotEquals, actual: 404, data: map[string]interface{}{"value": "200"}, want: true},
+		{name: "notEquals loose match", operator: api.NotEquals, actual: 200, data: map[string]interface{}{"value": "200"}},
+
+		{name: "contains substring", operator: api.Contains, actual: "hello world", data: map[string]interface{}{"value": "lo w"}, want: true},
+		{name: "contains missing substring", operator: api.Contains, actual: "hello", data: map[string]interface{}{"value": "bye"}},
+		{name: "contains array element", operator: api.Contains, actual: []interface{}{1.0, 2.0}, data: map[string]interface{}{"value": "2"}, want: true},
+		{name: "contains missing element", operator: api.Contains, actual: []interface{}{1.0, 2.0}, data: map[string]interface{}{"value": 3}},
+		{name: "contains object key", operator: api.Contains, actual: map[string]interface{}{"id": 1.0}, data: map[string]interface{}{"value": "id"}, want: true},
+		{name: "contains object value", operator: api.Contains, actual: map[string]interface{}{"id": "x"}, data: map[string]interface{}{"value": "x"}},
+		{name: "notContains", operator: api.NotContains, actual: "hello", data: map[string]interface{}{"value": "bye"}, want: true},
+		{name: "notContains present", operator: api.NotContains, actual: []interface{}{"a"}, data: map[string]interface{}{"value": "a"}},
+
+		{name: "startsWith", operator: api.StartsWith, actual: "application/json; charset=utf-8", data: map[string]interface{}{"value": "application/json"}, want: true},
+		{name: "startsWith number", operator: api.StartsWith, actual: 204, data: map[string]interface{}{"value": "2"}, want: true},
+		{name: "startsWith no match", operator: api.StartsWith, actual: "text/html", data: map[string]interface{}{"value": "application"}},
+		{name: "endsWith", operator: api.EndsWith, actual: "report.pdf", data: map[string]interface{}{"value": ".pdf"}, want: true},
+		{name: "endsWith no match", operator: api.EndsWith, actual: "report.pdf", data: map[string]interface{}{"value": ".csv"}},
+
+		{name: "empty string", operator: api.Empty, actual: "", want: true},
+		{name: "empty null", operator: api.Empty, actual: nil, want: true},
+		{name: "empty array", operator: api.Empty, actual: []interface{}{}, want: true},
+		{name: "empty object", operator: api.Empty, actual: map[string]interface{}{}, want: true},
+		{name: "empty zero is not empty", operator: api.Empty, actual: 0.0},
+		{name: "notEmpty string", operator: api.NotEmpty, actual: "x", want: true},
+		{name: "notEmpty empty array", operator: api.NotEmpty, actual: []interface{}{}},
+
+		{name: "regex value", operator: api.Regex, actual: "order-1234", data: map[string]interface{}{"value": `^order-\d+$`}, want: true},
+		{name: "regex pattern", operator: api.Regex, actual: "order-abc", data: map[string]interface{}{"pattern": `^order-\d+$`}},
+		{name: "regex pattern wins over value", operator: api.Regex, actual: "abc", data: map[string]interface{}{"value": "x", "pattern": "b"}, want: true},
+		{name: "regex on number", operator: api.Regex, actual: 201, data: map[string]interface{}{"value": `^2\d\d$`}, want: true},
+
+		{name: "greaterThan", operator: api.GreaterThan, actual: 5.0, data: map[string]interface{}{"value": 4}, want: true},
+		{name: "greaterThan equal", operator: api.GreaterThan, actual: 5.0, data: map[string]interface{}{"value": 5}},
+		{name: "greaterThanOrEqual equal", operator: api.GreaterThanOrEqual, actual: "5", data: map[string]interface{}{"value": 5}, want: true},
+		{name: "greaterThanOrEqual below", operator: api.GreaterThanOrEqual, actual: 4, data: map[string]interface{}{"value": "4.5"}},
+		{name: "lessThan", operator: api.LessThan, actual: 199, data: map[string]interface{}{"value": "200"}, want: true},
+		{name: "lessThan equal", operator: api.LessThan, actual: 200, data: map[string]interface{}{"value": 200}},
+		{name: "lessThanOrEqual equal", operator: api.LessThanOrEqual, actual: 200, data: map[string]interface{}{"value": 200}, want: true},
+		{name: "lessThanOrEqual above", operator: api.LessThanOrEqual, actual: 201, data: map[string]interface{}{"value": 200}},
+
+		{name: "between inside", operator: api.Between, actual: 250, data: map[string]interface{}{"min": 200, "max": 299}, want: true},
+		{name: "between at min", operator: api.Between, actual: 200, data: map[string]interface{}{"min": 200.0, "max": 299.0}, want: true},
+		{name: "between at max", operator: api.Between, actual: "299", data: map[string]interface{}{"min": 200, "max": 299}, want: true},
+		{name: "between below", operator: api.Between, actual: 199.5, data: map[string]interface{}{"min": 200, "max": 299}},
+		{name: "between above", operator: api.Between, actual: 300, data: map[string]interface{}{"min": "200", "max": "299"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyOperator(tt.operator, tt.actual, tt.data)
+			if err != nil {
+				t.Fatalf("ApplyOperator: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyOperator(%s, %v, %v) = %v, want %v", tt.operator, tt.actual, tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOperatorErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator api.OperatorType
+		actual   interface{}
+		data     map[string]interface{}
+		wantErr  string
+	}{
+		{name: "invalid regex", operator: api.Regex, actual: "x", data: map[string]interface{}{"value": "("}, wantErr: `invalid regex "("`},
+		{name: "comparison on text", operator: api.GreaterThan, actual: "abc", data: map[string]interface{}{"value": 1}, wantErr: `greaterThan needs a number, got "abc"`},
+		{name: "comparison with text", operator: api.LessThan, actual: 1, data: map[string]interface{}{"value": "abc"}, wantErr: `lessThan needs a numeric value, got "abc"`},
+		{name: "between on text", operator: api.Between, actual: "abc", data: map[string]interface{}{"min": 1, "max": 2}, wantErr: `between needs a number, got "abc"`},
+		{name: "between without max", operator: api.Between, actual: 1, data: map[string]interface{}{"min": 1}, wantErr: "between needs numeric min and max"},
+		{name: "unknown operator", operator: "near", actual: 1, wantErr: `unknown operator "near"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyOperator(tt.operator, tt.actual, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ApplyOperator error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	resp := Response{
+		StatusCode: 201,
+		Header:     http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"first", "second"}},
+		Body:       []byte(`{"id": 42, "user": {"name": "alice"}, "tags": ["a", "b"], "note": null}`),
+	}
+
+	tests := []struct {
+		name      string
+		extractor api.ExtractorType
+		data      map[string]interface{}
+		want      interface{}
+		wantErr   string
+	}{
+		{name: "status code", extractor: ExtractorStatusCode, want: 201},
+		{name: "body", extractor: ExtractorBody, want: string(resp.Body)},
+		{name: "header", extractor: ExtractorHeader, data: map[string]interface{}{"header_name": "content-type"}, want: "application/json"},
+		{name: "header first value", extractor: ExtractorHeader, data: map[string]interface{}{"header_name": "X-Request-Id"}, want: "first"},
+		{name: "header by path", extractor: ExtractorHeader, data: map[string]interface{}{"path": "Content-Type"}, want: "application/json"},
+		{name: "missing header", extractor: ExtractorHeader, data: map[string]interface{}{"header_name": "ETag"}, wantErr: "header ETag not found"},
+		{name: "missing header default", extractor: ExtractorHeader, data: map[string]interface{}{"header_name": "ETag", "default_value": "none"}, want: "none"},
+		{name: "present header ignores default", extractor: ExtractorHeader, data: map[string]interface{}{"header_name": "Content-Type", "default_value": "none"}, want: "application/json"},
+		{name: "header without name", extractor: ExtractorHeader, wantErr: "header extractor has no header_name"},
+		{name: "json path", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.user.name"}, want: "alice"},
+		{name: "json path number", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.id"}, want: 42.0},
+		{name: "json path null", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.note", "default_value": "x"}, want: nil},
+		{name: "json path wildcard", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.tags[*]"}, want: []interface{}{"a", "b"}},
+		{name: "json path missing", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.user.email"}, wantErr: "path matched nothing"},
+		{name: "json path missing default", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.user.email", "default_value": "n/a"}, want: "n/a"},
+		{name: "json path null default", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.user.email", "default_value": nil}, want: nil},
+		{name: "json path invalid ignores default", extractor: ExtractorJSONPath, data: map[string]interface{}{"path": "$.tags[", "default_value": "n/a"}, wantErr: "invalid JSONPath"},
+		{name: "json path without path", extractor: ExtractorJSONPath, wantErr: "jsonPath extractor has no path"},
+		{name: "xml path", extractor: ExtractorXMLPath, data: map[string]interface{}{"path": "/a"}, wantErr: "not evaluated locally"},
+		{name: "unknown", extractor: "cookie", wantErr: `unknown extractor "cookie"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract(resp, tt.extractor, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Extract error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateAssertion(t *testing.T) {
+	resp := Response{StatusCode: 200, Header: http.Header{}, Body: []byte(`{"items": [1, 2, 3]}`)}
+
+	tests := []struct {
+		name        string
+		assertion   api.CompositeAssertion
+		wantPassed  bool
+		wantMessage string
+		wantText    string
+	}{
+		{
+			name: "loose status code",
+			assertion: api.CompositeAssertion{
+				ExtractorType: ExtractorStatusCode, ExtractorData: map[string]interface{}{},
+				OperatorType: api.Equals, OperatorData: map[string]interface{}{"value": "200"},
+			},
+			wantPassed:  true,
+			wantMessage: "got 200",
+			wantText:    `statusCode equals "200"`,
+		},
+		{
+			name: "between",
+			assertion: api.CompositeAssertion{
+				ExtractorType: ExtractorStatusCode, ExtractorData: map[string]interface{}{},
+				OperatorType: api.Between, OperatorData: map[string]interface{}{"min": 300, "max": 399},
+			},
+			wantMessage: "got 200",
+			wantText:    "statusCode between 300 and 399",
+		},
+		{
+			name: "json path",
+			assertion: api.CompositeAssertion{
+				ExtractorType: ExtractorJSONPath, ExtractorData: map[string]interface{}{"path": "$.items"},
+				OperatorType: api.Contains, OperatorData: map[string]interface{}{"value": 2},
+			},
+			wantPassed:  true,
+			wantMessage: "got [1,2,3]",
+			wantText:    "jsonPath $.items contains 2",
+		},
+		{
+			name: "extraction fails",
+			assertion: api.CompositeAssertion{
+				ExtractorType: ExtractorHeader, ExtractorData: map[string]interface{}{"header_name": "ETag"},
+				OperatorType: api.NotEmpty, OperatorData: map[string]interface{}{},
+			},
+			wantMessage: "header ETag not found",
+			wantText:    "header ETag notEmpty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateAssertion(resp, tt.assertion)
+			want := AssertionResult{Assertion: tt.wantText, Passed: tt.wantPassed, Message: tt.wantMessage}
+			if got != want {
+				t.Errorf("EvaluateAssertion = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package flowbuilder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"echopoint-cli/internal/jsonpath"
+)
+
+// ErrNoMatch is returned by ExtractJSONPath when a path that selects a single
+// value finds nothing, so callers can fall back to a default
+var ErrNoMatch = errors.New("path matched nothing")
+
+// ExtractJSONPath evaluates a JSONPath expression such as "$.data.items[0].id"
+// against a raw JSON document. It supports the subset described in package
+// jsonpath: members by dot or bracket, array indexes (negative from the end),
+// wildcards, and recursive descent.
+//
+// A path that can select at most one value returns that value, or an error
+// wrapping ErrNoMatch when it is missing. Paths with wildcards or recursive
+// descent return every match as a []interface{}, which may be empty. Numbers
+// are decoded as float64, as with encoding/json.
+func ExtractJSONPath(body []byte, expr string) (interface{}, error) {
+	path, err := jsonpath.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("response body is empty, not JSON")
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("response body is not JSON: %w", err)
+	}
+
+	matches := path.Eval(doc)
+	if !path.Definite() {
+		if matches == nil {
+			matches = []interface{}{}
+		}
+		return matches, nil
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s: %w", expr, ErrNoMatch)
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,93 @@
+package flowbuilder
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractJSONPath(t *testing.T) {
+	body := []byte(`{
+		"data": {
+			"items": [
+				{"id": 1, "name": "first", "tags": ["a"]},
+				{"id": 2, "name": "second", "tags": []},
+				{"id": 3, "name": "third", "meta": {"id": 30}}
+			],
+			"total": 3,
+			"next": null,
+			"weird key": true
+		}
+	}`)
+
+	tests := []struct {
+		expr string
+		want interface{}
+	}{
+		{expr: "$.data.total", want: 3.0},
+		{expr: "data.total", want: 3.0},
+		{expr: "$.data.next", want: nil},
+		{expr: "$['data']['weird key']", want: true},
+		{expr: `$.data["weird key"]`, want: true},
+		{expr: "$.data.items[0].name", want: "first"},
+		{expr: "$.data.items[2].id", want: 3.0},
+		{expr: "$.data.items[-1].name", want: "third"},
+		{expr: "$.data.items[ 1 ].name", want: "second"},
+		{expr: "$.data.items[0].tags[0]", want: "a"},
+		{expr: "$.data.items[*].name", want: []interface{}{"first", "second", "third"}},
+		{expr: "$.data.items.*.id", want: []interface{}{1.0, 2.0, 3.0}},
+		{expr: "$.data.items[1].tags[*]", want: []interface{}{}},
+		{expr: "$.data.items[*].missing", want: []interface{}{}},
+		{expr: "$..id", want: []interface{}{1.0, 2.0, 3.0, 30.0}},
+		{expr: "$.data.items[2].meta.*", want: []interface{}{30.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ExtractJSONPath(body, tt.expr)
+			if err != nil {
+				t.Fatalf("ExtractJSONPath: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractJSONPath = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractJSONPathErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expr        string
+		wantErr     string
+		wantNoMatch bool
+	}{
+		{name: "missing member", body: `{"a": {"b": 1}}`, expr: "$.a.c", wantErr: "$.a.c: path matched nothing", wantNoMatch: true},
+		{name: "member of a scalar", body: `{"a": 1}`, expr: "$.a.b", wantNoMatch: true},
+		{name: "index out of range", body: `{"a": [1, 2]}`, expr: "$.a[2]", wantNoMatch: true},
+		{name: "negative index out of range", body: `{"a": [1, 2]}`, expr: "$.a[-3]", wantNoMatch: true},
+		{name: "index into an object", body: `{"a": {"0": 1}}`, expr: "$.a[0]", wantNoMatch: true},
+		{name: "unterminated bracket", body: `{}`, expr: "$.a[0", wantErr: `invalid JSONPath "$.a[0": unterminated '['`},
+		{name: "non-numeric index", body: `{}`, expr: "$.a[first]", wantErr: `invalid index "first"`},
+		{name: "empty member", body: `{}`, expr: "$.a..", wantErr: "expected member name after '..'"},
+		{name: "trailing dot", body: `{}`, expr: "$.a.", wantErr: "expected member name"},
+		{name: "stray character", body: `{}`, expr: "$a", wantErr: `unexpected 'a'`},
+		{name: "empty body", body: "  ", expr: "$.a", wantErr: "response body is empty"},
+		{name: "body not JSON", body: "<html></html>", expr: "$.a", wantErr: "response body is not JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExtractJSONPath([]byte(tt.body), tt.expr)
+			if err == nil {
+				t.Fatal("ExtractJSONPath succeeded, want an error")
+			}
+			if errors.Is(err, ErrNoMatch) != tt.wantNoMatch {
+				t.Errorf("errors.Is(%v, ErrNoMatch) = %v, want %v", err, !tt.wantNoMatch, tt.wantNoMatch)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExtractJSONPath error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
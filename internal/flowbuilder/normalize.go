@@ -0,0 +1,91 @@
+package flowbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"echopoint-cli/internal/api"
+)
+
+// Normalize returns a copy of def in a canonical form so that logically equal
+// definitions serialize to identical bytes: edges are ordered by ID, and every
+// node is re-encoded from its typed form, which fixes the field order of the
+// raw JSON the server returned (map keys, such as headers, are always sorted
+// by the encoder). Nodes of types this client does not model are re-encoded
+// from their generic JSON form instead. Nodes keep their order, which users
+// set deliberately with "flows node reorder"; execution order comes from
+// edges either way.
+func Normalize(def api.FlowDefinition) (api.FlowDefinition, error) {
+	result := def
+	result.Nodes = make([]api.FlowNode, 0, len(def.Nodes))
+	for _, node := range def.Nodes {
+		normalized, err := normalizeNode(node)
+		if err != nil {
+			return def, err
+		}
+		result.Nodes = append(result.Nodes, normalized)
+	}
+
+	result.Edges = append([]api.FlowEdge{}, def.Edges...)
+	sort.SliceStable(result.Edges, func(i, j int) bool {
+		a, b := result.Edges[i], result.Edges[j]
+		if a.Id != b.Id {
+			return a.Id < b.Id
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+
+	return result, nil
+}
+
+// normalizeNode re-encodes node from its typed form, or from its decoded JSON
+// when its type is unknown
+func normalizeNode(node api.FlowNode) (api.FlowNode, error) {
+	discriminator, err := node.Discriminator()
+	if err != nil {
+		return node, fmt.Errorf("failed to read node: %w", err)
+	}
+
+	var normalized api.FlowNode
+	switch discriminator {
+	case "request", "delay":
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			return node, fmt.Errorf("failed to read node: %w", err)
+		}
+		var id string
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			id = n.Id
+			err = normalized.FromRequestFlowNode(n)
+		case api.DelayFlowNode:
+			id = n.Id
+			err = normalized.FromDelayFlowNode(n)
+		}
+		if err != nil {
+			return node, fmt.Errorf("failed to encode node %s: %w", id, err)
+		}
+		return normalized, nil
+	}
+
+	raw, err := node.MarshalJSON()
+	if err != nil {
+		return node, fmt.Errorf("failed to read node: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return node, fmt.Errorf("failed to read %s node: %w", discriminator, err)
+	}
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return node, fmt.Errorf("failed to encode %s node: %w", discriminator, err)
+	}
+	if err := normalized.UnmarshalJSON(canonical); err != nil {
+		return node, fmt.Errorf("failed to encode %s node: %w", discriminator, err)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,95 @@
+package flowbuilder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"echopoint-cli/internal/api"
+)
+
+// normalizedJSON decodes a definition, normalizes it, and encodes it again
+func normalizedJSON(t *testing.T, raw string) []byte {
+	t.Helper()
+	var def api.FlowDefinition
+	if err := json.Unmarshal([]byte(raw), &def); err != nil {
+		t.Fatal(err)
+	}
+	normalized, err := Normalize(def)
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestNormalizeEqualDefinitions(t *testing.T) {
+	a := `{
+		"name": "orders", "version": "1.0",
+		"nodes": [
+			{"id": "login", "type": "request", "display_name": "Log in",
+			 "data": {"method": "POST", "url": "https://example.com/login",
+			          "headers": {"X-B": "2", "Content-Type": "application/json", "X-A": "1"}},
+			 "assertions": [{"extractor_type": "statusCode", "extractor_data": {},
+			                 "operator_type": "between", "operator_data": {"min": 200, "max": 299}}]},
+			{"id": "wait", "type": "delay", "display_name": "Wait", "data": {"duration": 100}},
+			{"id": "js", "type": "script", "display_name": "Transform", "data": {"source": "x", "lang": "js"}}
+		],
+		"edges": [
+			{"id": "e2", "source": "wait", "target": "js", "type": "success"},
+			{"id": "e1", "source": "login", "target": "wait", "type": "success"}
+		]
+	}`
+	b := `{
+		"version": "1.0", "name": "orders",
+		"edges": [
+			{"type": "success", "target": "wait", "source": "login", "id": "e1"},
+			{"target": "js", "id": "e2", "type": "success", "source": "wait"}
+		],
+		"nodes": [
+			{"type": "request", "assertions": [{"operator_data": {"max": 299, "min": 200}, "operator_type": "between",
+			                                    "extractor_data": {}, "extractor_type": "statusCode"}],
+			 "data": {"headers": {"X-A": "1", "X-B": "2", "Content-Type": "application/json"},
+			          "url": "https://example.com/login", "method": "POST"},
+			 "display_name": "Log in", "id": "login"},
+			{"data": {"duration": 100}, "display_name": "Wait", "type": "delay", "id": "wait"},
+			{"data": {"lang": "js", "source": "x"}, "display_name": "Transform", "id": "js", "type": "script"}
+		]
+	}`
+
+	first, second := normalizedJSON(t, a), normalizedJSON(t, b)
+	if !bytes.Equal(first, second) {
+		t.Errorf("equal definitions normalized differently:\n%s\n%s", first, second)
+	}
+	if again := normalizedJSON(t, string(first)); !bytes.Equal(again, first) {
+		t.Errorf("normalizing twice changed the definition:\n%s\n%s", again, first)
+	}
+}
+
+func TestNormalizeKeepsNodeOrder(t *testing.T) {
+	var def api.FlowDefinition
+	if err := json.Unmarshal([]byte(`{"name": "f", "nodes": [
+		{"id": "b", "type": "delay", "display_name": "B", "data": {"duration": 1}},
+		{"id": "a", "type": "delay", "display_name": "A", "data": {"duration": 1}}
+	], "edges": []}`), &def); err != nil {
+		t.Fatal(err)
+	}
+	normalized, err := Normalize(def)
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	var ids []string
+	for _, node := range normalized.Nodes {
+		value, err := node.AsDelayFlowNode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, value.Id)
+	}
+	if len(ids) != 2 || ids[0] != "b" || ids[1] != "a" {
+		t.Errorf("node order = %v, want [b a]", ids)
+	}
+}
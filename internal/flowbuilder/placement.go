@@ -1,9 +1,11 @@
 package flowbuilder
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +24,41 @@ type NodePlacement struct {
 	Height   int
 }
 
+// Direction is the way levels advance across the canvas
+type Direction string
+
+// Layout directions. The zero value lays out top to bottom.
+const (
+	DirectionTB Direction = "TB" // top to bottom
+	DirectionLR Direction = "LR" // left to right
+	DirectionBT Direction = "BT" // bottom to top
+	DirectionRL Direction = "RL" // right to left
+)
+
+// Directions lists the supported layout directions
+var Directions = []Direction{DirectionTB, DirectionLR, DirectionBT, DirectionRL}
+
+// ParseDirection parses a direction name, ignoring case
+func ParseDirection(s string) (Direction, error) {
+	d := Direction(strings.ToUpper(strings.TrimSpace(s)))
+	for _, known := range Directions {
+		if d == known {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unknown layout direction %q (expected TB, LR, BT, or RL)", s)
+}
+
+// horizontal reports whether levels advance along the X axis
+func (d Direction) horizontal() bool {
+	return d == DirectionLR || d == DirectionRL
+}
+
+// reversed reports whether levels advance toward smaller coordinates
+func (d Direction) reversed() bool {
+	return d == DirectionBT || d == DirectionRL
+}
+
 // Grid represents the flow canvas
 type Grid struct {
 	Width      int
@@ -30,32 +67,127 @@ type Grid struct {
 	NodeHeight int
 	PaddingX   int
 	PaddingY   int
+	Direction  Direction
 }
 
-// NewGrid creates a new grid with default settings
-func NewGrid() *Grid {
-	return &Grid{
+// GridOptions configures the canvas, spacing, and direction of a Grid
+type GridOptions struct {
+	Width      int
+	Height     int
+	NodeWidth  int
+	NodeHeight int
+	PaddingX   int
+	PaddingY   int
+	Direction  Direction
+}
+
+// DefaultGridOptions returns the settings used by NewGrid
+func DefaultGridOptions() GridOptions {
+	return GridOptions{
 		Width:      2000,
 		Height:     1000,
 		NodeWidth:  220,
 		NodeHeight: 80,
 		PaddingX:   60,
 		PaddingY:   100,
+		Direction:  DirectionTB,
 	}
 }
 
+// NewGrid creates a new grid with default settings
+func NewGrid() *Grid {
+	grid, _ := NewGridWithOptions(DefaultGridOptions())
+	return grid
+}
+
+// NewGridWithOptions creates a grid with custom settings. Every dimension must
+// be positive and the canvas must fit at least one node with its margins.
+func NewGridWithOptions(opts GridOptions) (*Grid, error) {
+	dimensions := []struct {
+		name  string
+		value int
+	}{
+		{"width", opts.Width},
+		{"height", opts.Height},
+		{"node width", opts.NodeWidth},
+		{"node height", opts.NodeHeight},
+		{"horizontal padding", opts.PaddingX},
+		{"vertical padding", opts.PaddingY},
+	}
+	for _, d := range dimensions {
+		if d.value <= 0 {
+			return nil, fmt.Errorf("grid %s must be positive, got %d", d.name, d.value)
+		}
+	}
+	if opts.Width < opts.NodeWidth+2*canvasMargin {
+		return nil, fmt.Errorf("grid width %d is too small for node width %d", opts.Width, opts.NodeWidth)
+	}
+	if opts.Height < opts.NodeHeight+2*canvasMargin {
+		return nil, fmt.Errorf("grid height %d is too small for node height %d", opts.Height, opts.NodeHeight)
+	}
+	direction := DirectionTB
+	if opts.Direction != "" {
+		var err error
+		if direction, err = ParseDirection(string(opts.Direction)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Grid{
+		Width:      opts.Width,
+		Height:     opts.Height,
+		NodeWidth:  opts.NodeWidth,
+		NodeHeight: opts.NodeHeight,
+		PaddingX:   opts.PaddingX,
+		PaddingY:   opts.PaddingY,
+		Direction:  direction,
+	}, nil
+}
+
+// canvasMargin is the space kept clear around the edge of the canvas
+const canvasMargin = 100
+
 // AutoPlacementAlgorithm places nodes optimally using a layered graph layout algorithm
-// Based on Sugiyama-style hierarchical layout with collision detection
-func (g *Grid) AutoPlacementAlgorithm(nodes []NodePlacement, edges []Edge) []NodePlacement {
+// Based on Sugiyama-style hierarchical layout with collision detection.
+// The canvas grows when the widest level or the number of levels does not fit:
+// g.Width and g.Height are updated in place, so read them after the call for
+// the size of the finished layout.
+// Edges that close a cycle are ignored for leveling and returned as back edges.
+// The layout depends only on the graph, not on the order of nodes or edges,
+// so persisting it twice never produces a diff.
+// Levels advance along g.Direction; other directions are laid out top to
+// bottom on a transposed or mirrored canvas and then turned into place.
+func (g *Grid) AutoPlacementAlgorithm(nodes []NodePlacement, edges []Edge) ([]NodePlacement, []Edge) {
 	if len(nodes) == 0 {
-		return nodes
+		return nodes, nil
+	}
+	if g.Direction != "" && g.Direction != DirectionTB {
+		return g.orientedPlacement(nodes, edges)
 	}
 
+	// Work on copies sorted by ID; the steps below follow input order
+	sortedNodes := append([]NodePlacement(nil), nodes...)
+	sort.Slice(sortedNodes, func(i, j int) bool {
+		return lessID(sortedNodes[i].ID, sortedNodes[j].ID)
+	})
+	sortedEdges := append([]Edge(nil), edges...)
+	sort.Slice(sortedEdges, func(i, j int) bool {
+		if sortedEdges[i].From != sortedEdges[j].From {
+			return lessID(sortedEdges[i].From, sortedEdges[j].From)
+		}
+		if sortedEdges[i].To != sortedEdges[j].To {
+			return lessID(sortedEdges[i].To, sortedEdges[j].To)
+		}
+		return sortedEdges[i].Weight < sortedEdges[j].Weight
+	})
+	edges = sortedEdges
+
 	// Step 1: Build adjacency list and calculate levels (topological layers)
-	levels := g.calculateLevels(nodes, edges)
+	levels, backEdges := g.calculateLevels(sortedNodes, edges)
 
 	// Step 2: Group nodes by level
-	levelGroups := g.groupByLevel(nodes, levels)
+	levelGroups := g.groupByLevel(sortedNodes, levels)
+	g.fitLevels(levelGroups)
 
 	// Step 3: Calculate initial positions based on levels
 	positions := g.calculateInitialPositions(levelGroups)
@@ -80,60 +212,212 @@ func (g *Grid) AutoPlacementAlgorithm(nodes []NodePlacement, edges []Edge) []Nod
 		}
 	}
 
-	return result
+	return result, backEdges
+}
+
+// orientedPlacement lays nodes out top to bottom on a canvas whose axes are
+// swapped for horizontal directions, then maps the result back, mirroring the
+// level axis for BT and RL. The canvas grows as the top-to-bottom layout does.
+func (g *Grid) orientedPlacement(nodes []NodePlacement, edges []Edge) ([]NodePlacement, []Edge) {
+	layout := *g
+	layout.Direction = DirectionTB
+	if g.Direction.horizontal() {
+		layout.Width, layout.Height = g.Height, g.Width
+		layout.NodeWidth, layout.NodeHeight = g.NodeHeight, g.NodeWidth
+		layout.PaddingX, layout.PaddingY = g.PaddingY, g.PaddingX
+	}
+
+	placed, backEdges := layout.AutoPlacementAlgorithm(nodes, edges)
+
+	if g.Direction.horizontal() {
+		g.Width, g.Height = layout.Height, layout.Width
+		for i := range placed {
+			placed[i].Position.X, placed[i].Position.Y = placed[i].Position.Y, placed[i].Position.X
+		}
+	} else {
+		g.Width, g.Height = layout.Width, layout.Height
+	}
+
+	if g.Direction.reversed() {
+		minPos, maxPos := math.MaxInt, math.MinInt
+		for _, node := range placed {
+			pos := node.Position.Y
+			if g.Direction.horizontal() {
+				pos = node.Position.X
+			}
+			minPos, maxPos = min(minPos, pos), max(maxPos, pos)
+		}
+		for i := range placed {
+			if g.Direction.horizontal() {
+				placed[i].Position.X = minPos + maxPos - placed[i].Position.X
+			} else {
+				placed[i].Position.Y = minPos + maxPos - placed[i].Position.Y
+			}
+		}
+	}
+
+	return placed, backEdges
+}
+
+// lessID orders node IDs bytewise
+func lessID(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
 }
 
-// Edge represents a connection between nodes
+// sortedLevels returns the levels of levelGroups in ascending order
+func sortedLevels(levelGroups map[int][]uuid.UUID) []int {
+	levels := make([]int, 0, len(levelGroups))
+	for level := range levelGroups {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+	return levels
+}
+
+// Edge represents a connection between nodes. Weight sets how strongly the
+// layout pulls the target toward the source when ordering a level; zero counts
+// as 1, so heavier edges such as a flow's success path line up first.
 type Edge struct {
-	From uuid.UUID
-	To   uuid.UUID
+	From   uuid.UUID
+	To     uuid.UUID
+	Weight int
+}
+
+// weight returns the edge's weight, treating unset or negative as 1
+func (e Edge) weight() int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// Levels assigns each node its depth in the flow: roots are level 0 and every
+// other node sits one below its deepest predecessor. Edges that close a cycle
+// are ignored and returned.
+func Levels(nodes []NodePlacement, edges []Edge) (map[uuid.UUID]int, []Edge) {
+	return (&Grid{}).calculateLevels(nodes, edges)
 }
 
-// calculateLevels assigns each node to a hierarchical level using topological sort
-func (g *Grid) calculateLevels(nodes []NodePlacement, edges []Edge) map[uuid.UUID]int {
-	levels := make(map[uuid.UUID]int)
+// LevelGroups orders nodes by level for execution: each group holds the nodes
+// of one level, which can run in parallel, in the order they appear in nodes.
+// Edges that close a cycle are ignored and returned.
+func LevelGroups(nodes []NodePlacement, edges []Edge) ([][]uuid.UUID, []Edge) {
+	g := &Grid{}
+	levels, backEdges := g.calculateLevels(nodes, edges)
+	byLevel := g.groupByLevel(nodes, levels)
+
+	groups := make([][]uuid.UUID, 0, len(byLevel))
+	for level := 0; len(groups) < len(byLevel); level++ {
+		if group, ok := byLevel[level]; ok {
+			groups = append(groups, group)
+		}
+	}
+	return groups, backEdges
+}
+
+// calculateLevels assigns each node to a hierarchical level using topological sort.
+// Cycles are broken by ignoring their back edges, which are returned so callers
+// can report them. Traversal follows the order of nodes and edges, so the same
+// input always yields the same back edges.
+func (g *Grid) calculateLevels(nodes []NodePlacement, edges []Edge) (map[uuid.UUID]int, []Edge) {
+	known := make(map[uuid.UUID]bool, len(nodes))
+	for _, node := range nodes {
+		known[node.ID] = true
+	}
 
-	// Build adjacency list (incoming edges)
-	incoming := make(map[uuid.UUID][]uuid.UUID)
+	// Build adjacency list (outgoing edges), ignoring edges to unknown nodes
+	outgoing := make(map[uuid.UUID][]Edge)
 	for _, edge := range edges {
-		incoming[edge.To] = append(incoming[edge.To], edge.From)
+		if known[edge.From] && known[edge.To] {
+			outgoing[edge.From] = append(outgoing[edge.From], edge)
+		}
 	}
 
-	// Initialize nodes with no incoming edges to level 0
+	// Depth-first search marking nodes on the current path; an edge back to
+	// one of them closes a cycle
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[uuid.UUID]int, len(nodes))
+	backEdges := make([]Edge, 0)
+	isBackEdge := make(map[Edge]bool)
+
+	var visit func(id uuid.UUID)
+	visit = func(id uuid.UUID) {
+		state[id] = onPath
+		for _, edge := range outgoing[id] {
+			switch state[edge.To] {
+			case onPath:
+				if !isBackEdge[edge] {
+					isBackEdge[edge] = true
+					backEdges = append(backEdges, edge)
+				}
+			case unvisited:
+				visit(edge.To)
+			}
+		}
+		state[id] = done
+	}
+
+	// Start from roots so cycles are broken at the edge that returns toward
+	// the start of the flow, then cover nodes reachable only through a cycle
+	incoming := make(map[uuid.UUID]int)
+	for _, edge := range edges {
+		if known[edge.From] && known[edge.To] {
+			incoming[edge.To]++
+		}
+	}
+	for _, node := range nodes {
+		if incoming[node.ID] == 0 && state[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+	for _, node := range nodes {
+		if state[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+
+	// Assign longest-path levels over the remaining acyclic graph (Kahn's algorithm)
+	indegree := make(map[uuid.UUID]int, len(nodes))
+	for _, list := range outgoing {
+		for _, edge := range list {
+			if !isBackEdge[edge] {
+				indegree[edge.To]++
+			}
+		}
+	}
+
+	levels := make(map[uuid.UUID]int, len(nodes))
 	queue := make([]uuid.UUID, 0)
 	for _, node := range nodes {
-		if len(incoming[node.ID]) == 0 {
+		if indegree[node.ID] == 0 {
 			levels[node.ID] = 0
 			queue = append(queue, node.ID)
 		}
 	}
 
-	// BFS to assign levels
 	for len(queue) > 0 {
 		current := queue[0]
 		queue = queue[1:]
-		currentLevel := levels[current]
-
-		// Find all outgoing edges from current node
-		for _, edge := range edges {
-			if edge.From == current {
-				targetLevel := currentLevel + 1
-				if existingLevel, exists := levels[edge.To]; !exists || targetLevel > existingLevel {
-					levels[edge.To] = targetLevel
-					queue = append(queue, edge.To)
-				}
-			}
-		}
-	}
 
-	// Handle disconnected nodes (assign to level 0)
-	for _, node := range nodes {
-		if _, exists := levels[node.ID]; !exists {
-			levels[node.ID] = 0
+		for _, edge := range outgoing[current] {
+			if isBackEdge[edge] {
+				continue
+			}
+			if targetLevel := levels[current] + 1; targetLevel > levels[edge.To] {
+				levels[edge.To] = targetLevel
+			}
+			indegree[edge.To]--
+			if indegree[edge.To] == 0 {
+				queue = append(queue, edge.To)
+			}
 		}
 	}
 
-	return levels
+	return levels, backEdges
 }
 
 // groupByLevel groups nodes by their assigned level
@@ -148,31 +432,44 @@ func (g *Grid) groupByLevel(nodes []NodePlacement, levels map[uuid.UUID]int) map
 	return groups
 }
 
+// fitLevels grows the canvas so the widest level and every level fit
+// inside the margins, rather than letting nodes overflow the edges
+func (g *Grid) fitLevels(levelGroups map[int][]uuid.UUID) {
+	maxLevel := 0
+	for level, nodes := range levelGroups {
+		if width := g.levelWidth(len(nodes)) + 2*canvasMargin; width > g.Width {
+			g.Width = width
+		}
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+
+	height := (maxLevel+1)*g.NodeHeight + maxLevel*g.PaddingY + 2*canvasMargin
+	if height > g.Height {
+		g.Height = height
+	}
+}
+
+// levelWidth is the horizontal space taken by numNodes side by side
+func (g *Grid) levelWidth(numNodes int) int {
+	return (numNodes * g.NodeWidth) + ((numNodes - 1) * g.PaddingX)
+}
+
 // calculateInitialPositions assigns initial X,Y coordinates based on levels
 func (g *Grid) calculateInitialPositions(levelGroups map[int][]uuid.UUID) map[uuid.UUID]Position {
 	positions := make(map[uuid.UUID]Position)
 
-	// Get sorted levels
-	var levels []int
-	for level := range levelGroups {
-		levels = append(levels, level)
-	}
-	sort.Ints(levels)
-
 	// Place nodes in each level
-	for _, level := range levels {
+	for _, level := range sortedLevels(levelGroups) {
 		nodes := levelGroups[level]
 		numNodes := len(nodes)
 
 		// Calculate Y position for this level
-		y := 100 + (level * (g.NodeHeight + g.PaddingY))
+		y := canvasMargin + (level * (g.NodeHeight + g.PaddingY))
 
 		// Center nodes horizontally within the level
-		totalWidth := (numNodes * g.NodeWidth) + ((numNodes - 1) * g.PaddingX)
-		startX := (g.Width - totalWidth) / 2
-		if startX < 100 {
-			startX = 100
-		}
+		startX := (g.Width - g.levelWidth(numNodes)) / 2
 
 		for i, nodeID := range nodes {
 			x := startX + (i * (g.NodeWidth + g.PaddingX))
@@ -194,7 +491,8 @@ func (g *Grid) resolveCollisions(
 		hasCollision := false
 
 		// Check for collisions within each level
-		for _, nodes := range levelGroups {
+		for _, level := range sortedLevels(levelGroups) {
+			nodes := levelGroups[level]
 			for i := 0; i < len(nodes); i++ {
 				for j := i + 1; j < len(nodes); j++ {
 					pos1 := positions[nodes[i]]
@@ -231,51 +529,59 @@ func (g *Grid) minimizeEdgeCrossings(
 	edges []Edge,
 	levelGroups map[int][]uuid.UUID,
 ) map[uuid.UUID]Position {
-	// Simple heuristic: sort nodes within each level by average X position of their connected nodes
-	for level, nodes := range levelGroups {
+	// Simple heuristic: sort nodes within each level by the weighted average X position of their
+	// connected nodes, so a heavy edge pulls its target toward that parent. Siblings with the same
+	// average are ordered heaviest first, putting the main path before its side branches.
+	// Levels go top-down so each one is ordered against its parents' final positions.
+	for _, level := range sortedLevels(levelGroups) {
+		nodes := levelGroups[level]
 		if level == 0 {
 			continue // Skip first level
 		}
 
 		// Calculate average X position of incoming connections for each node
 		type nodeScore struct {
-			id    uuid.UUID
-			score float64
+			id     uuid.UUID
+			score  float64
+			weight int
 		}
 		scores := make([]nodeScore, 0, len(nodes))
 
 		for _, nodeID := range nodes {
 			var totalX float64
-			var count int
+			var weight int
 
 			for _, edge := range edges {
 				if edge.To == nodeID {
 					if parentPos, exists := positions[edge.From]; exists {
-						totalX += float64(parentPos.X)
-						count++
+						totalX += float64(parentPos.X * edge.weight())
+						weight += edge.weight()
 					}
 				}
 			}
 
-			if count > 0 {
-				scores = append(scores, nodeScore{id: nodeID, score: totalX / float64(count)})
+			if weight > 0 {
+				scores = append(scores, nodeScore{id: nodeID, score: totalX / float64(weight), weight: weight})
 			} else {
 				scores = append(scores, nodeScore{id: nodeID, score: float64(positions[nodeID].X)})
 			}
 		}
 
-		// Sort by score (average parent X position)
+		// Sort by score (weighted average parent X position), breaking ties by
+		// weight and then by ID
 		sort.Slice(scores, func(i, j int) bool {
-			return scores[i].score < scores[j].score
+			if scores[i].score != scores[j].score {
+				return scores[i].score < scores[j].score
+			}
+			if scores[i].weight != scores[j].weight {
+				return scores[i].weight > scores[j].weight
+			}
+			return lessID(scores[i].id, scores[j].id)
 		})
 
 		// Reassign X positions based on sorted order
 		numNodes := len(scores)
-		totalWidth := (numNodes * g.NodeWidth) + ((numNodes - 1) * g.PaddingX)
-		startX := (g.Width - totalWidth) / 2
-		if startX < 100 {
-			startX = 100
-		}
+		startX := (g.Width - g.levelWidth(numNodes)) / 2
 
 		for i, score := range scores {
 			x := startX + (i * (g.NodeWidth + g.PaddingX))
@@ -293,8 +599,8 @@ func (g *Grid) fineTunePositions(
 	levelGroups map[int][]uuid.UUID,
 ) map[uuid.UUID]Position {
 	// Center the entire graph
-	var minX, maxX, minY, maxY int
-	minX, minY = g.Width, g.Height
+	minX, maxX := math.MaxInt, math.MinInt
+	minY, maxY := math.MaxInt, math.MinInt
 
 	for _, pos := range positions {
 		if pos.X < minX {
@@ -315,8 +621,17 @@ func (g *Grid) fineTunePositions(
 	graphWidth := maxX - minX + g.NodeWidth
 	graphHeight := maxY - minY + g.NodeHeight
 
-	offsetX := (g.Width - graphWidth) / 2
-	offsetY := (g.Height - graphHeight) / 2
+	// Collision resolution can spread a level past the canvas; grow it so
+	// centering never pushes nodes to negative coordinates
+	if width := graphWidth + 2*canvasMargin; width > g.Width {
+		g.Width = width
+	}
+	if height := graphHeight + 2*canvasMargin; height > g.Height {
+		g.Height = height
+	}
+
+	offsetX := max((g.Width-graphWidth)/2, canvasMargin)
+	offsetY := max((g.Height-graphHeight)/2, canvasMargin)
 
 	// Apply offset to all positions
 	for id, pos := range positions {
@@ -329,18 +644,44 @@ func (g *Grid) fineTunePositions(
 	return positions
 }
 
-// CalculateNewNodePosition determines the best position for a new node
+// CalculateNewNodePosition determines the best position for a new node. A node
+// connected from existing ones goes one level past them along g.Direction,
+// moving aside within that level until it finds a free spot; any other node
+// starts a new branch beside the first level.
 func (g *Grid) CalculateNewNodePosition(
 	existingNodes []NodePlacement,
 	edges []Edge,
 	connectedFrom []uuid.UUID,
 ) Position {
+	// step moves from one level to the next, and spread from one node to the
+	// next within a level
+	var step, spread Position
+	switch g.Direction {
+	case DirectionLR:
+		step, spread = Position{X: g.NodeWidth + g.PaddingX}, Position{Y: g.NodeHeight + g.PaddingY/2}
+	case DirectionRL:
+		step, spread = Position{X: -(g.NodeWidth + g.PaddingX)}, Position{Y: g.NodeHeight + g.PaddingY/2}
+	case DirectionBT:
+		step, spread = Position{Y: -(g.NodeHeight + g.PaddingY)}, Position{X: g.NodeWidth + g.PaddingX/2}
+	default:
+		step, spread = Position{Y: g.NodeHeight + g.PaddingY}, Position{X: g.NodeWidth + g.PaddingX/2}
+	}
+
 	if len(existingNodes) == 0 {
-		// First node - place at center
-		return Position{X: g.Width / 2, Y: 100}
+		// First node - centered across the start of the first level
+		switch g.Direction {
+		case DirectionLR:
+			return Position{X: canvasMargin, Y: g.Height / 2}
+		case DirectionRL:
+			return Position{X: g.Width - canvasMargin - g.NodeWidth, Y: g.Height / 2}
+		case DirectionBT:
+			return Position{X: g.Width / 2, Y: g.Height - canvasMargin - g.NodeHeight}
+		default:
+			return Position{X: g.Width / 2, Y: canvasMargin}
+		}
 	}
 
-	// If connected to existing nodes, place to the right of them
+	// If connected to existing nodes, place one level past them
 	if len(connectedFrom) > 0 {
 		var totalX, totalY int
 		validConnections := 0
@@ -357,36 +698,43 @@ func (g *Grid) CalculateNewNodePosition(
 		}
 
 		if validConnections > 0 {
-			avgX := totalX / validConnections
-			avgY := totalY / validConnections
-
-			// Place to the right with some vertical offset based on existing nodes
-			newX := avgX + g.NodeWidth + g.PaddingX
-			newY := avgY
+			newX := totalX/validConnections + step.X
+			newY := totalY/validConnections + step.Y
 
-			// Check if position is occupied and adjust
+			// Check if position is occupied and move along the level
 			for g.isPositionOccupied(newX, newY, existingNodes) {
-				newY += g.NodeHeight + g.PaddingY/2
+				newX += spread.X
+				newY += spread.Y
 			}
 
 			return Position{X: newX, Y: newY}
 		}
 	}
 
-	// Find the rightmost node and place to its right
-	maxX := 0
+	// Otherwise start at the first level, past the last node across it
+	first := existingNodes[0].Position
+	last := existingNodes[0].Position
 	for _, node := range existingNodes {
-		if node.Position.X > maxX {
-			maxX = node.Position.X
+		pos := node.Position
+		if step.Y > 0 && pos.Y < first.Y || step.Y < 0 && pos.Y > first.Y {
+			first.Y = pos.Y
 		}
+		if step.X > 0 && pos.X < first.X || step.X < 0 && pos.X > first.X {
+			first.X = pos.X
+		}
+		last.X, last.Y = max(last.X, pos.X), max(last.Y, pos.Y)
 	}
 
-	// Find a suitable Y position
-	newX := maxX + g.NodeWidth + g.PaddingX
-	newY := 100
+	var newX, newY int
+	if g.Direction.horizontal() {
+		newX, newY = first.X, last.Y+g.NodeHeight+g.PaddingY
+	} else {
+		newX, newY = last.X+g.NodeWidth+g.PaddingX, first.Y
+	}
 
 	for g.isPositionOccupied(newX, newY, existingNodes) {
-		newY += g.NodeHeight + g.PaddingY/2
+		newX += spread.X
+		newY += spread.Y
 	}
 
 	return Position{X: newX, Y: newY}
@@ -0,0 +1,396 @@
+package flowbuilder
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testNodes returns n nodes with predictable IDs
+func testNodes(n int) []NodePlacement {
+	nodes := make([]NodePlacement, n)
+	for i := range nodes {
+		nodes[i] = NodePlacement{ID: uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1))}
+	}
+	return nodes
+}
+
+// fanOut connects the first node to every other node, putting them all in
+// one level
+func fanOut(nodes []NodePlacement) []Edge {
+	edges := make([]Edge, 0, len(nodes)-1)
+	for _, node := range nodes[1:] {
+		edges = append(edges, Edge{From: nodes[0].ID, To: node.ID})
+	}
+	return edges
+}
+
+// assertInsideCanvas fails when a placed node is outside the grid's canvas
+func assertInsideCanvas(t *testing.T, g *Grid, placed []NodePlacement) {
+	t.Helper()
+	for _, node := range placed {
+		pos := node.Position
+		if pos.X < 0 || pos.Y < 0 || pos.X+g.NodeWidth > g.Width || pos.Y+g.NodeHeight > g.Height {
+			t.Errorf("node %s at %s is outside the %dx%d canvas", node.ID, FormatPosition(pos), g.Width, g.Height)
+		}
+	}
+}
+
+// assertNoOverlap fails when two placed nodes overlap
+func assertNoOverlap(t *testing.T, g *Grid, placed []NodePlacement) {
+	t.Helper()
+	for i := range placed {
+		for j := i + 1; j < len(placed); j++ {
+			a, b := placed[i].Position, placed[j].Position
+			if a.X < b.X+g.NodeWidth && b.X < a.X+g.NodeWidth && a.Y < b.Y+g.NodeHeight && b.Y < a.Y+g.NodeHeight {
+				t.Errorf("nodes %s at %s and %s at %s overlap", placed[i].ID, FormatPosition(a), placed[j].ID, FormatPosition(b))
+			}
+		}
+	}
+}
+
+func TestNewGridWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*GridOptions)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*GridOptions) {}},
+		{name: "zero width", modify: func(o *GridOptions) { o.Width = 0 }, wantErr: "grid width must be positive"},
+		{name: "negative node height", modify: func(o *GridOptions) { o.NodeHeight = -80 }, wantErr: "grid node height must be positive"},
+		{name: "zero padding", modify: func(o *GridOptions) { o.PaddingX = 0 }, wantErr: "grid horizontal padding must be positive"},
+		{name: "canvas narrower than a node", modify: func(o *GridOptions) { o.Width = 300 }, wantErr: "too small for node width"},
+		{name: "canvas shorter than a node", modify: func(o *GridOptions) { o.Height = 200 }, wantErr: "too small for node height"},
+		{name: "lower-case direction", modify: func(o *GridOptions) { o.Direction = "lr" }},
+		{name: "unknown direction", modify: func(o *GridOptions) { o.Direction = "up" }, wantErr: "unknown layout direction"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultGridOptions()
+			tt.modify(&opts)
+			_, err := NewGridWithOptions(opts)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("NewGridWithOptions: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("NewGridWithOptions error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAutoPlacementGrowsCanvasForWideLevel(t *testing.T) {
+	g := NewGrid()
+	width := g.Width
+	nodes := testNodes(21) // a root and 20 children side by side
+
+	placed, _ := g.AutoPlacementAlgorithm(nodes, fanOut(nodes))
+
+	if want := g.levelWidth(20) + 2*canvasMargin; g.Width < want {
+		t.Errorf("canvas width = %d, want at least %d for 20 nodes in a level", g.Width, want)
+	}
+	if g.Width <= width {
+		t.Errorf("canvas width = %d, want it grown from %d", g.Width, width)
+	}
+	assertInsideCanvas(t, g, placed)
+	assertNoOverlap(t, g, placed)
+}
+
+func TestAutoPlacementKeepsCanvasForSmallGraph(t *testing.T) {
+	g := NewGrid()
+	nodes := testNodes(3)
+
+	placed, _ := g.AutoPlacementAlgorithm(nodes, fanOut(nodes))
+
+	if defaults := DefaultGridOptions(); g.Width != defaults.Width || g.Height != defaults.Height {
+		t.Errorf("canvas = %dx%d, want the default %dx%d", g.Width, g.Height, defaults.Width, defaults.Height)
+	}
+	assertInsideCanvas(t, g, placed)
+	assertNoOverlap(t, g, placed)
+}
+
+func TestAutoPlacementEmpty(t *testing.T) {
+	placed, backEdges := NewGrid().AutoPlacementAlgorithm(nil, nil)
+	if len(placed) != 0 || len(backEdges) != 0 {
+		t.Errorf("AutoPlacementAlgorithm(nil) = %v, %v, want nothing", placed, backEdges)
+	}
+}
+
+func TestCalculateNewNodePosition(t *testing.T) {
+	parent := NodePlacement{ID: uuid.New(), Position: Position{X: 500, Y: 400}}
+	opts := DefaultGridOptions()
+	levelX, levelY := opts.NodeWidth+opts.PaddingX, opts.NodeHeight+opts.PaddingY
+
+	tests := []struct {
+		direction Direction
+		want      Position
+	}{
+		{direction: DirectionTB, want: Position{X: 500, Y: 400 + levelY}},
+		{direction: DirectionBT, want: Position{X: 500, Y: 400 - levelY}},
+		{direction: DirectionLR, want: Position{X: 500 + levelX, Y: 400}},
+		{direction: DirectionRL, want: Position{X: 500 - levelX, Y: 400}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.direction), func(t *testing.T) {
+			opts := DefaultGridOptions()
+			opts.Direction = tt.direction
+			g, err := NewGridWithOptions(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := g.CalculateNewNodePosition([]NodePlacement{parent}, nil, []uuid.UUID{parent.ID})
+			if got != tt.want {
+				t.Errorf("connected node at %s, want %s", FormatPosition(got), FormatPosition(tt.want))
+			}
+		})
+	}
+}
+
+func TestCalculateNewNodePositionAvoidsSibling(t *testing.T) {
+	g := NewGrid()
+	parent := NodePlacement{ID: uuid.New(), Position: Position{X: 500, Y: 100}}
+	first := g.CalculateNewNodePosition([]NodePlacement{parent}, nil, []uuid.UUID{parent.ID})
+	sibling := NodePlacement{ID: uuid.New(), Position: first}
+
+	second := g.CalculateNewNodePosition([]NodePlacement{parent, sibling}, nil, []uuid.UUID{parent.ID})
+	if second.Y != first.Y {
+		t.Errorf("second child at %s, want it on the same level as %s", FormatPosition(second), FormatPosition(first))
+	}
+	if second.X <= first.X {
+		t.Errorf("second child at %s, want it beside the first at %s", FormatPosition(second), FormatPosition(first))
+	}
+	assertNoOverlap(t, g, []NodePlacement{parent, sibling, {ID: uuid.New(), Position: second}})
+}
+
+func TestCalculateNewNodePositionUnconnected(t *testing.T) {
+	g := NewGrid()
+	if got := g.CalculateNewNodePosition(nil, nil, nil); got != (Position{X: g.Width / 2, Y: canvasMargin}) {
+		t.Errorf("first node at %s, want the top center", FormatPosition(got))
+	}
+
+	existing := []NodePlacement{
+		{ID: uuid.New(), Position: Position{X: 400, Y: 100}},
+		{ID: uuid.New(), Position: Position{X: 700, Y: 280}},
+	}
+	got := g.CalculateNewNodePosition(existing, nil, nil)
+	if got.Y != 100 || got.X <= 700 {
+		t.Errorf("unconnected node at %s, want it on the top level right of x=700", FormatPosition(got))
+	}
+}
+
+// chain connects the nodes one after another, giving one level per node
+func chain(nodes []NodePlacement) []Edge {
+	edges := make([]Edge, 0, len(nodes)-1)
+	for i := 1; i < len(nodes); i++ {
+		edges = append(edges, Edge{From: nodes[i-1].ID, To: nodes[i].ID})
+	}
+	return edges
+}
+
+func TestAutoPlacementLargeGraphStaysOnCanvas(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []NodePlacement
+		edges func([]NodePlacement) []Edge
+	}{
+		{name: "deep", nodes: testNodes(40), edges: chain},
+		{name: "wide", nodes: testNodes(60), edges: fanOut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGrid()
+			placed, _ := g.AutoPlacementAlgorithm(tt.nodes, tt.edges(tt.nodes))
+
+			if len(placed) != len(tt.nodes) {
+				t.Fatalf("placed %d nodes, want %d", len(placed), len(tt.nodes))
+			}
+			for _, node := range placed {
+				if node.Position.X < canvasMargin || node.Position.Y < canvasMargin {
+					t.Errorf("node %s at %s, want both coordinates at least %d", node.ID, FormatPosition(node.Position), canvasMargin)
+				}
+			}
+			assertInsideCanvas(t, g, placed)
+		})
+	}
+}
+
+func TestAutoPlacementRepeatable(t *testing.T) {
+	nodes := testNodes(12)
+	edges := append(chain(nodes[:6]), fanOut(nodes[5:])...)
+
+	first, _ := NewGrid().AutoPlacementAlgorithm(nodes, edges)
+	second, _ := NewGrid().AutoPlacementAlgorithm(nodes, edges)
+
+	if len(first) != len(second) {
+		t.Fatalf("placed %d nodes, then %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("node %d placed at %s %s, then %s %s", i, first[i].ID, FormatPosition(first[i].Position), second[i].ID, FormatPosition(second[i].Position))
+		}
+	}
+}
+
+func TestLevelsBreaksCycles(t *testing.T) {
+	nodes := testNodes(4)
+	a, b, c, d := nodes[0].ID, nodes[1].ID, nodes[2].ID, nodes[3].ID
+
+	tests := []struct {
+		name      string
+		nodes     []NodePlacement
+		edges     []Edge
+		want      map[uuid.UUID]int
+		wantBreak Edge
+	}{
+		{
+			name:      "three-node cycle",
+			nodes:     nodes[:3],
+			edges:     []Edge{{From: a, To: b}, {From: b, To: c}, {From: c, To: a}},
+			want:      map[uuid.UUID]int{a: 0, b: 1, c: 2},
+			wantBreak: Edge{From: c, To: a},
+		},
+		{
+			name:      "cycle below a root",
+			nodes:     nodes,
+			edges:     []Edge{{From: a, To: b}, {From: b, To: c}, {From: c, To: d}, {From: d, To: b}},
+			want:      map[uuid.UUID]int{a: 0, b: 1, c: 2, d: 3},
+			wantBreak: Edge{From: d, To: b},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels, backEdges := Levels(tt.nodes, tt.edges)
+			for id, want := range tt.want {
+				if levels[id] != want {
+					t.Errorf("level of %s = %d, want %d", id, levels[id], want)
+				}
+			}
+			if len(backEdges) != 1 || backEdges[0] != tt.wantBreak {
+				t.Errorf("back edges = %v, want [%v]", backEdges, tt.wantBreak)
+			}
+
+			placed, placedBack := NewGrid().AutoPlacementAlgorithm(tt.nodes, tt.edges)
+			if len(placedBack) != 1 || placedBack[0] != tt.wantBreak {
+				t.Errorf("layout back edges = %v, want [%v]", placedBack, tt.wantBreak)
+			}
+			rows := make(map[int]bool)
+			for _, node := range placed {
+				rows[node.Position.Y] = true
+			}
+			if len(rows) != len(tt.nodes) {
+				t.Errorf("layout used %d levels, want one per node in the chain", len(rows))
+			}
+		})
+	}
+}
+
+func TestAutoPlacementIgnoresInputOrder(t *testing.T) {
+	nodes := testNodes(14)
+	edges := append(chain(nodes[:5]), fanOut(nodes[4:])...)
+	edges = append(edges, Edge{From: nodes[1].ID, To: nodes[9].ID}, Edge{From: nodes[13].ID, To: nodes[2].ID})
+
+	for _, direction := range []Direction{DirectionTB, DirectionLR} {
+		t.Run(string(direction), func(t *testing.T) {
+			layout := func(nodes []NodePlacement, edges []Edge) (map[uuid.UUID]Position, []Edge) {
+				opts := DefaultGridOptions()
+				opts.Direction = direction
+				g, err := NewGridWithOptions(opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				placed, backEdges := g.AutoPlacementAlgorithm(nodes, edges)
+				positions := make(map[uuid.UUID]Position, len(placed))
+				for _, node := range placed {
+					positions[node.ID] = node.Position
+				}
+				return positions, backEdges
+			}
+			want, wantBack := layout(nodes, edges)
+
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 5; i++ {
+				shuffledNodes := append([]NodePlacement(nil), nodes...)
+				rng.Shuffle(len(shuffledNodes), func(i, j int) { shuffledNodes[i], shuffledNodes[j] = shuffledNodes[j], shuffledNodes[i] })
+				shuffledEdges := append([]Edge(nil), edges...)
+				rng.Shuffle(len(shuffledEdges), func(i, j int) { shuffledEdges[i], shuffledEdges[j] = shuffledEdges[j], shuffledEdges[i] })
+
+				got, gotBack := layout(shuffledNodes, shuffledEdges)
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("shuffle %d: layout changed with input order:\n%v\nwant\n%v", i, got, want)
+				}
+				if !reflect.DeepEqual(gotBack, wantBack) {
+					t.Errorf("shuffle %d: back edges = %v, want %v", i, gotBack, wantBack)
+				}
+			}
+		})
+	}
+}
+
+func TestAutoPlacementDirection(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		// along returns the coordinate levels advance on, signed so that it
+		// grows from one level to the next
+		along func(Position) int
+	}{
+		{direction: DirectionTB, along: func(p Position) int { return p.Y }},
+		{direction: DirectionBT, along: func(p Position) int { return -p.Y }},
+		{direction: DirectionLR, along: func(p Position) int { return p.X }},
+		{direction: DirectionRL, along: func(p Position) int { return -p.X }},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.direction), func(t *testing.T) {
+			// A chain of four levels whose last node fans out to six more
+			nodes := testNodes(10)
+			edges := append(chain(nodes[:4]), fanOut(nodes[3:])...)
+			levels, _ := Levels(nodes, edges)
+
+			opts := DefaultGridOptions()
+			opts.Direction = tt.direction
+			g, err := NewGridWithOptions(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			placed, _ := g.AutoPlacementAlgorithm(nodes, edges)
+
+			levelPos := map[int]int{}
+			for _, node := range placed {
+				pos := tt.along(node.Position)
+				if want, ok := levelPos[levels[node.ID]]; ok && pos != want {
+					t.Errorf("node %s at %s is off its level's line", node.ID, FormatPosition(node.Position))
+				}
+				levelPos[levels[node.ID]] = pos
+			}
+			for level := 1; level < len(levelPos); level++ {
+				if levelPos[level] <= levelPos[level-1] {
+					t.Errorf("level %d at %d does not advance past level %d at %d", level, levelPos[level], level-1, levelPos[level-1])
+				}
+			}
+			assertInsideCanvas(t, g, placed)
+			assertNoOverlap(t, g, placed)
+
+			// Twenty levels do not fit the default canvas, which grows along
+			// the level axis only
+			deep := testNodes(20)
+			g, _ = NewGridWithOptions(opts)
+			placed, _ = g.AutoPlacementAlgorithm(deep, chain(deep))
+			defaults := DefaultGridOptions()
+			grown, kept, span, keep := g.Height, g.Width, 19*(g.NodeHeight+g.PaddingY), defaults.Width
+			if tt.direction.horizontal() {
+				grown, kept, span, keep = g.Width, g.Height, 19*(g.NodeWidth+g.PaddingX), defaults.Height
+			}
+			if grown < span {
+				t.Errorf("canvas = %dx%d, want at least %d along the level axis for 20 levels", g.Width, g.Height, span)
+			}
+			if kept != keep {
+				t.Errorf("canvas = %dx%d, want the other axis kept at %d", g.Width, g.Height, keep)
+			}
+			assertInsideCanvas(t, g, placed)
+		})
+	}
+}
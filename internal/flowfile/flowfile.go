@@ -0,0 +1,416 @@
+// Package flowfile defines the on-disk format for exported flows.
+//
+// The format is independent of the generated API types so that exported
+// files keep working when the API models change. Every file records the
+// schemaVersion it was written with; readers reject versions they do not
+// understand instead of guessing.
+package flowfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+)
+
+// SchemaVersion is the version of the format written by this package
+const SchemaVersion = 1
+
+// File is an exported flow. Environment holds the flow's environment
+// variables when they were exported with it.
+type File struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	Version       string            `json:"version,omitempty"`
+	Nodes         []Node            `json:"nodes"`
+	Edges         []Edge            `json:"edges"`
+	Environment   map[string]string `json:"environment,omitempty"`
+}
+
+// Node is a flow node. Exactly one of Request and Delay is set, matching Type.
+type Node struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Disabled   bool        `json:"disabled,omitempty"`
+	Position   *Position   `json:"position,omitempty"`
+	Request    *Request    `json:"request,omitempty"`
+	Delay      *Delay      `json:"delay,omitempty"`
+	Outputs    []Output    `json:"outputs,omitempty"`
+	Assertions []Assertion `json:"assertions,omitempty"`
+	Retry      *Retry      `json:"retry,omitempty"`
+}
+
+// Node types
+const (
+	TypeRequest = "request"
+	TypeDelay   = "delay"
+)
+
+// Position is a node's location in the web editor
+type Position struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// nodePosition matches the element type of the API's node_positions map
+type nodePosition = struct {
+	X *float32 `json:"x,omitempty"`
+	Y *float32 `json:"y,omitempty"`
+}
+
+// Request configures a request node
+type Request struct {
+	Method      string                 `json:"method"`
+	URL         string                 `json:"url"`
+	Headers     map[string]string      `json:"headers,omitempty"`
+	QueryParams map[string]interface{} `json:"queryParams,omitempty"`
+	Body        interface{}            `json:"body,omitempty"`
+	TimeoutMs   *int                   `json:"timeoutMs,omitempty"`
+}
+
+// Delay configures a delay node
+type Delay struct {
+	DurationMs int `json:"durationMs"`
+}
+
+// Retry re-runs a node when its assertions fail
+type Retry struct {
+	Count   int `json:"count"`
+	DelayMs int `json:"delayMs"`
+}
+
+// Output extracts a named value from a node's result
+type Output struct {
+	Name       string `json:"name"`
+	Extractor  string `json:"extractor"`
+	Path       string `json:"path,omitempty"`
+	HeaderName string `json:"headerName,omitempty"`
+	As         string `json:"as,omitempty"`
+}
+
+// Assertion validates a node's result
+type Assertion struct {
+	Extractor     string                 `json:"extractor"`
+	ExtractorData map[string]interface{} `json:"extractorData,omitempty"`
+	Operator      string                 `json:"operator"`
+	OperatorData  map[string]interface{} `json:"operatorData,omitempty"`
+}
+
+// Edge connects two nodes
+type Edge struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// Read decodes a file and checks that its schema version is supported
+func Read(r io.Reader) (File, error) {
+	var f File
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return File{}, fmt.Errorf("invalid flow file: %w", err)
+	}
+
+	switch {
+	case f.SchemaVersion == 0:
+		return File{}, fmt.Errorf("invalid flow file: missing schemaVersion")
+	case f.SchemaVersion > SchemaVersion:
+		return File{}, fmt.Errorf("flow file uses schemaVersion %d; this CLI supports up to %d, upgrade to import it", f.SchemaVersion, SchemaVersion)
+	}
+
+	return f, nil
+}
+
+// Write encodes f as indented JSON
+func Write(w io.Writer, f File) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(f)
+}
+
+// FromFlow converts a flow from the API into the on-disk format
+func FromFlow(flow *api.Flow) (File, error) {
+	// Export in a stable order so that re-exporting an unchanged flow
+	// produces the same file
+	definition, err := flowbuilder.Normalize(flow.FlowDefinition)
+	if err != nil {
+		return File{}, err
+	}
+
+	f := File{
+		SchemaVersion: SchemaVersion,
+		Name:          flow.Name,
+		Version:       definition.Version,
+		Nodes:         make([]Node, 0, len(definition.Nodes)),
+		Edges:         make([]Edge, 0, len(definition.Edges)),
+	}
+	if flow.Description != nil {
+		f.Description = *flow.Description
+	}
+
+	var positions map[string]nodePosition
+	if flow.Metadata.NodePositions != nil {
+		positions = *flow.Metadata.NodePositions
+	}
+
+	for i, apiNode := range definition.Nodes {
+		value, err := apiNode.ValueByDiscriminator()
+		if err != nil {
+			return File{}, fmt.Errorf("failed to decode node %d: %w", i, err)
+		}
+
+		var node Node
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			node = Node{ID: n.Id, Type: TypeRequest, Name: n.DisplayName, Disabled: n.Disabled != nil && *n.Disabled}
+			node.Request = &Request{
+				Method:    string(n.Data.Method),
+				URL:       n.Data.Url,
+				Body:      n.Data.Body,
+				TimeoutMs: n.Data.Timeout,
+			}
+			if n.Data.Headers != nil {
+				node.Request.Headers = *n.Data.Headers
+			}
+			if n.Data.QueryParams != nil {
+				node.Request.QueryParams = *n.Data.QueryParams
+			}
+			node.Outputs = fromOutputs(n.Outputs)
+			node.Assertions = fromAssertions(n.Assertions)
+			node.Retry = fromRetry(n.Retry)
+		case api.DelayFlowNode:
+			node = Node{ID: n.Id, Type: TypeDelay, Name: n.DisplayName, Disabled: n.Disabled != nil && *n.Disabled}
+			node.Delay = &Delay{DurationMs: n.Data.Duration}
+			node.Outputs = fromOutputs(n.Outputs)
+			node.Assertions = fromAssertions(n.Assertions)
+			node.Retry = fromRetry(n.Retry)
+		default:
+			return File{}, fmt.Errorf("node %d has an unsupported type", i)
+		}
+
+		if pos, ok := positions[node.ID]; ok && pos.X != nil && pos.Y != nil {
+			node.Position = &Position{X: *pos.X, Y: *pos.Y}
+		}
+		f.Nodes = append(f.Nodes, node)
+	}
+
+	for _, edge := range definition.Edges {
+		f.Edges = append(f.Edges, Edge{
+			ID:     edge.Id,
+			Source: edge.Source,
+			Target: edge.Target,
+			Type:   string(edge.Type),
+		})
+	}
+
+	return f, nil
+}
+
+// ToCreateRequest converts the file into a request that creates the flow.
+// Saved node positions are kept; without any the backend lays out the flow.
+func (f File) ToCreateRequest() (api.CreateFlowRequest, error) {
+	definition := api.FlowDefinition{
+		Name:    f.Name,
+		Version: f.Version,
+		Nodes:   make([]api.FlowNode, 0, len(f.Nodes)),
+		Edges:   make([]api.FlowEdge, 0, len(f.Edges)),
+	}
+	if definition.Version == "" {
+		definition.Version = "1.0"
+	}
+
+	positions := make(map[string]nodePosition)
+
+	for i, node := range f.Nodes {
+		var apiNode api.FlowNode
+		var disabled *bool
+		if node.Disabled {
+			disabled = &node.Disabled
+		}
+		switch node.Type {
+		case TypeRequest:
+			if node.Request == nil {
+				return api.CreateFlowRequest{}, fmt.Errorf("node %d (%s): request node has no request", i, node.ID)
+			}
+			data := api.RequestNodeData{
+				Method:  api.RequestNodeDataMethod(node.Request.Method),
+				Url:     node.Request.URL,
+				Body:    node.Request.Body,
+				Timeout: node.Request.TimeoutMs,
+			}
+			if node.Request.Headers != nil {
+				headers := node.Request.Headers
+				data.Headers = &headers
+			}
+			if node.Request.QueryParams != nil {
+				params := node.Request.QueryParams
+				data.QueryParams = &params
+			}
+			if err := apiNode.FromRequestFlowNode(api.RequestFlowNode{
+				Id:          node.ID,
+				DisplayName: node.Name,
+				Disabled:    disabled,
+				Data:        data,
+				Outputs:     toOutputs(node.Outputs),
+				Assertions:  toAssertions(node.Assertions),
+				Retry:       toRetry(node.Retry),
+			}); err != nil {
+				return api.CreateFlowRequest{}, err
+			}
+		case TypeDelay:
+			if node.Delay == nil {
+				return api.CreateFlowRequest{}, fmt.Errorf("node %d (%s): delay node has no delay", i, node.ID)
+			}
+			if err := apiNode.FromDelayFlowNode(api.DelayFlowNode{
+				Id:          node.ID,
+				DisplayName: node.Name,
+				Disabled:    disabled,
+				Data:        api.DelayNodeData{Duration: node.Delay.DurationMs},
+				Outputs:     toOutputs(node.Outputs),
+				Assertions:  toAssertions(node.Assertions),
+				Retry:       toRetry(node.Retry),
+			}); err != nil {
+				return api.CreateFlowRequest{}, err
+			}
+		default:
+			return api.CreateFlowRequest{}, fmt.Errorf("node %d (%s): unsupported type %q", i, node.ID, node.Type)
+		}
+		definition.Nodes = append(definition.Nodes, apiNode)
+
+		if node.Position != nil {
+			x, y := node.Position.X, node.Position.Y
+			positions[node.ID] = nodePosition{X: &x, Y: &y}
+		}
+	}
+
+	for _, edge := range f.Edges {
+		definition.Edges = append(definition.Edges, api.FlowEdge{
+			Id:     edge.ID,
+			Source: edge.Source,
+			Target: edge.Target,
+			Type:   api.FlowEdgeType(edge.Type),
+		})
+	}
+
+	req := api.CreateFlowRequest{
+		Name:           f.Name,
+		FlowDefinition: definition,
+	}
+	if f.Description != "" {
+		description := f.Description
+		req.Description = &description
+		req.FlowDefinition.Description = &description
+	}
+	if len(positions) > 0 {
+		autoLayout := false
+		req.AutoLayout = &autoLayout
+		req.Metadata = &api.CreateFlowRequest_Metadata{NodePositions: &positions}
+	}
+
+	return req, nil
+}
+
+func fromOutputs(outputs *[]api.Output) []Output {
+	if outputs == nil {
+		return nil
+	}
+	result := make([]Output, 0, len(*outputs))
+	for _, o := range *outputs {
+		output := Output{Name: o.Name, Extractor: string(o.Extractor.Type)}
+		if o.Extractor.Path != nil {
+			output.Path = *o.Extractor.Path
+		}
+		if o.Extractor.HeaderName != nil {
+			output.HeaderName = *o.Extractor.HeaderName
+		}
+		if o.Extractor.As != nil {
+			output.As = string(*o.Extractor.As)
+		}
+		result = append(result, output)
+	}
+	return result
+}
+
+func toOutputs(outputs []Output) *[]api.Output {
+	if outputs == nil {
+		return nil
+	}
+	result := make([]api.Output, 0, len(outputs))
+	for _, o := range outputs {
+		var output api.Output
+		output.Name = o.Name
+		output.Extractor.Type = api.ExtractorType(o.Extractor)
+		if o.Path != "" {
+			path := o.Path
+			output.Extractor.Path = &path
+		}
+		if o.HeaderName != "" {
+			headerName := o.HeaderName
+			output.Extractor.HeaderName = &headerName
+		}
+		if o.As != "" {
+			as := api.OutputExtractorAs(o.As)
+			output.Extractor.As = &as
+		}
+		result = append(result, output)
+	}
+	return &result
+}
+
+func fromAssertions(assertions *[]api.CompositeAssertion) []Assertion {
+	if assertions == nil {
+		return nil
+	}
+	result := make([]Assertion, 0, len(*assertions))
+	for _, a := range *assertions {
+		result = append(result, Assertion{
+			Extractor:     string(a.ExtractorType),
+			ExtractorData: a.ExtractorData,
+			Operator:      string(a.OperatorType),
+			OperatorData:  a.OperatorData,
+		})
+	}
+	return result
+}
+
+func toAssertions(assertions []Assertion) *[]api.CompositeAssertion {
+	if assertions == nil {
+		return nil
+	}
+	result := make([]api.CompositeAssertion, 0, len(assertions))
+	for _, a := range assertions {
+		assertion := api.CompositeAssertion{
+			ExtractorType: api.ExtractorType(a.Extractor),
+			ExtractorData: a.ExtractorData,
+			OperatorType:  api.OperatorType(a.Operator),
+			OperatorData:  a.OperatorData,
+		}
+		// The API requires both maps to be present
+		if assertion.ExtractorData == nil {
+			assertion.ExtractorData = map[string]interface{}{}
+		}
+		if assertion.OperatorData == nil {
+			assertion.OperatorData = map[string]interface{}{}
+		}
+		result = append(result, assertion)
+	}
+	return &result
+}
+
+func fromRetry(retry *api.RetryPolicy) *Retry {
+	if retry == nil {
+		return nil
+	}
+	return &Retry{Count: retry.Count, DelayMs: retry.Delay}
+}
+
+func toRetry(retry *Retry) *api.RetryPolicy {
+	if retry == nil {
+		return nil
+	}
+	return &api.RetryPolicy{Count: retry.Count, Delay: retry.DelayMs}
+}
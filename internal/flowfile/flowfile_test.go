@@ -0,0 +1,172 @@
+package flowfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"echopoint-cli/internal/api"
+)
+
+const testFlow = `{
+	"id": "6f1c1d2e-8d53-4a8e-9a3b-1d1f1b2c3d4e",
+	"name": "checkout",
+	"description": "Log in and place an order",
+	"flow_definition": {
+		"name": "checkout",
+		"version": "1.2",
+		"nodes": [
+			{
+				"id": "login", "type": "request", "display_name": "Log in",
+				"data": {
+					"method": "POST", "url": "{{baseUrl}}/login", "timeout": 5000,
+					"headers": {"Content-Type": "application/json"},
+					"query_params": {"debug": "1"},
+					"body": {"user": "{{user}}", "remember": true}
+				},
+				"outputs": [{"name": "token", "extractor": {"type": "jsonPath", "path": "$.token", "as": "string"}}],
+				"assertions": [{
+					"extractor_type": "statusCode", "extractor_data": {},
+					"operator_type": "equals", "operator_data": {"value": 200}
+				}],
+				"retry": {"count": 2, "delay": 500}
+			},
+			{
+				"id": "wait", "type": "delay", "display_name": "Wait", "disabled": true,
+				"data": {"duration": 1000}
+			}
+		],
+		"edges": [{"id": "e1", "source": "login", "target": "wait", "type": "success"}]
+	},
+	"metadata": {"node_positions": {"login": {"x": 100, "y": 100}, "wait": {"x": 100, "y": 280.5}}}
+}`
+
+func TestRoundTrip(t *testing.T) {
+	var flow api.Flow
+	if err := json.Unmarshal([]byte(testFlow), &flow); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := FromFlow(&flow)
+	if err != nil {
+		t.Fatalf("FromFlow: %v", err)
+	}
+	exported.Environment = map[string]string{"baseUrl": "https://shop.example.com", "user": "alice"}
+
+	written := encode(t, exported)
+	read, err := Read(bytes.NewReader(written))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got := encode(t, read); !bytes.Equal(got, written) {
+		t.Fatalf("file changed after Read:\n%s\nwant\n%s", got, written)
+	}
+
+	// Import the file and export the created flow again
+	req, err := read.ToCreateRequest()
+	if err != nil {
+		t.Fatalf("ToCreateRequest: %v", err)
+	}
+	if req.AutoLayout == nil || *req.AutoLayout {
+		t.Error("ToCreateRequest asked for auto layout although the file has positions")
+	}
+	created := api.Flow{Name: req.Name, Description: req.Description, FlowDefinition: req.FlowDefinition}
+	created.Metadata.NodePositions = req.Metadata.NodePositions
+
+	reexported, err := FromFlow(&created)
+	if err != nil {
+		t.Fatalf("FromFlow after import: %v", err)
+	}
+	reexported.Environment = read.Environment
+	if got := encode(t, reexported); !bytes.Equal(got, written) {
+		t.Errorf("re-exported file\n%s\nwant\n%s", got, written)
+	}
+
+	if len(exported.Nodes) != 2 || len(exported.Edges) != 1 {
+		t.Fatalf("exported %d nodes and %d edges, want 2 and 1", len(exported.Nodes), len(exported.Edges))
+	}
+	login := exported.Nodes[0]
+	if login.Position == nil || *login.Position != (Position{X: 100, Y: 100}) {
+		t.Errorf("login position = %v, want 100,100", login.Position)
+	}
+	if login.Retry == nil || *login.Retry != (Retry{Count: 2, DelayMs: 500}) {
+		t.Errorf("login retry = %v, want 2 times after 500ms", login.Retry)
+	}
+	if wait := exported.Nodes[1]; !wait.Disabled || wait.Delay == nil || wait.Delay.DurationMs != 1000 {
+		t.Errorf("wait node = %+v, want a disabled 1000ms delay", wait)
+	}
+}
+
+// encode returns f as Write would store it
+func encode(t *testing.T, f File) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, f); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRoundTripWithoutPositions(t *testing.T) {
+	f := File{
+		SchemaVersion: SchemaVersion,
+		Name:          "pause",
+		Nodes:         []Node{{ID: "wait", Type: TypeDelay, Name: "Wait", Delay: &Delay{DurationMs: 10}}},
+		Edges:         []Edge{},
+	}
+	req, err := f.ToCreateRequest()
+	if err != nil {
+		t.Fatalf("ToCreateRequest: %v", err)
+	}
+	if req.AutoLayout != nil || req.Metadata != nil {
+		t.Errorf("ToCreateRequest set auto layout %v and metadata %v, want the backend to lay out the flow", req.AutoLayout, req.Metadata)
+	}
+	if req.FlowDefinition.Version != "1.0" {
+		t.Errorf("version = %q, want the default 1.0", req.FlowDefinition.Version)
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "current version", input: `{"schemaVersion": 1, "name": "a", "nodes": [], "edges": []}`},
+		{name: "missing version", input: `{"name": "a", "nodes": [], "edges": []}`, wantErr: "missing schemaVersion"},
+		{name: "newer version", input: `{"schemaVersion": 2, "name": "a"}`, wantErr: "schemaVersion 2; this CLI supports up to 1"},
+		{name: "not json", input: `name: a`, wantErr: "invalid flow file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Read error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToCreateRequestRejectsBadNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    Node
+		wantErr string
+	}{
+		{name: "request without request", node: Node{ID: "a", Type: TypeRequest}, wantErr: "request node has no request"},
+		{name: "delay without delay", node: Node{ID: "a", Type: TypeDelay}, wantErr: "delay node has no delay"},
+		{name: "unknown type", node: Node{ID: "a", Type: "script"}, wantErr: `unsupported type "script"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := File{SchemaVersion: SchemaVersion, Nodes: []Node{tt.node}}.ToCreateRequest()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ToCreateRequest error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package interpolate
+
+import (
+	"fmt"
+	"sort"
+
+	"echopoint-cli/internal/api"
+)
+
+// Usage is a variable referenced by a request node field
+type Usage struct {
+	Variable string `json:"variable" yaml:"variable"`
+	NodeID   string `json:"node_id" yaml:"node_id"`
+	Field    string `json:"field" yaml:"field"`
+}
+
+// UndefinedVariables scans the URL, headers, query parameters, and body of
+// every request node in def and returns the variable references whose names
+// are not in known, sorted by variable, node, and field. Node output
+// references are not variables and are skipped.
+func UndefinedVariables(def api.FlowDefinition, known map[string]bool) ([]Usage, error) {
+	var usages []Usage
+
+	for _, node := range def.Nodes {
+		value, err := node.ValueByDiscriminator()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read node: %w", err)
+		}
+		request, ok := value.(api.RequestFlowNode)
+		if !ok {
+			continue
+		}
+
+		seen := make(map[Usage]bool)
+		check := func(field, s string) {
+			for _, ref := range Scan(s) {
+				if ref.IsNodeOutput() || known[ref.Name] {
+					continue
+				}
+				usage := Usage{Variable: ref.Name, NodeID: request.Id, Field: field}
+				if !seen[usage] {
+					seen[usage] = true
+					usages = append(usages, usage)
+				}
+			}
+		}
+		checkValue := func(field string, v interface{}) {
+			walkStrings(v, func(s string) { check(field, s) })
+		}
+
+		check("url", request.Data.Url)
+		if request.Data.Headers != nil {
+			for key, val := range *request.Data.Headers {
+				check("header "+key, key)
+				check("header "+key, val)
+			}
+		}
+		if request.Data.QueryParams != nil {
+			for key, val := range *request.Data.QueryParams {
+				check("query "+key, key)
+				checkValue("query "+key, val)
+			}
+		}
+		checkValue("body", request.Data.Body)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.Variable != b.Variable {
+			return a.Variable < b.Variable
+		}
+		if a.NodeID != b.NodeID {
+			return a.NodeID < b.NodeID
+		}
+		return a.Field < b.Field
+	})
+
+	return usages, nil
+}
+
+// walkStrings calls fn for every string inside a decoded JSON value
+func walkStrings(v interface{}, fn func(string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case map[string]interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
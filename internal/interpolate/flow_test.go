@@ -0,0 +1,82 @@
+package interpolate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"echopoint-cli/internal/api"
+)
+
+func TestUndefinedVariables(t *testing.T) {
+	var def api.FlowDefinition
+	if err := json.Unmarshal([]byte(`{
+		"name": "orders",
+		"nodes": [
+			{
+				"id": "login", "type": "request", "display_name": "Log in",
+				"data": {
+					"method": "POST", "url": "{{baseUrl}}/login",
+					"headers": {"X-Tenant": "{{tenant}}", "{{headerName}}": "static"},
+					"body": {"user": "{{user}}", "nested": [{"pin": "{{pin}}"}], "count": 1}
+				}
+			},
+			{
+				"id": "order", "type": "request", "display_name": "Order",
+				"data": {
+					"method": "GET", "url": "{{baseUrl}}/orders/{{orderId}}?t={{orderId}}",
+					"query_params": {"token": "{{login.token}}", "page": "{{page}}"},
+					"headers": {"Authorization": "Bearer {{login.token}}"}
+				}
+			},
+			{"id": "wait", "type": "delay", "display_name": "Wait", "data": {"duration": 10}}
+		],
+		"edges": []
+	}`), &def); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		known map[string]bool
+		want  []Usage
+	}{
+		{
+			name:  "all defined",
+			known: map[string]bool{"baseUrl": true, "tenant": true, "headerName": true, "user": true, "pin": true, "orderId": true, "page": true},
+		},
+		{
+			name:  "some missing",
+			known: map[string]bool{"baseUrl": true, "headerName": true, "user": true, "page": true},
+			want: []Usage{
+				{Variable: "orderId", NodeID: "order", Field: "url"},
+				{Variable: "pin", NodeID: "login", Field: "body"},
+				{Variable: "tenant", NodeID: "login", Field: "header X-Tenant"},
+			},
+		},
+		{
+			name: "no environment",
+			want: []Usage{
+				{Variable: "baseUrl", NodeID: "login", Field: "url"},
+				{Variable: "baseUrl", NodeID: "order", Field: "url"},
+				{Variable: "headerName", NodeID: "login", Field: "header {{headerName}}"},
+				{Variable: "orderId", NodeID: "order", Field: "url"},
+				{Variable: "page", NodeID: "order", Field: "query page"},
+				{Variable: "pin", NodeID: "login", Field: "body"},
+				{Variable: "tenant", NodeID: "login", Field: "header X-Tenant"},
+				{Variable: "user", NodeID: "login", Field: "body"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UndefinedVariables(def, tt.known)
+			if err != nil {
+				t.Fatalf("UndefinedVariables: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UndefinedVariables =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package interpolate finds and substitutes {{variable}} placeholders in flow
+// definitions.
+//
+// Placeholders name either a flow environment variable ({{baseUrl}}) or the
+// output of an earlier node ({{node-id.outputName}}). Only variables are
+// substituted; node output references are left in place since their values
+// exist only while the flow runs.
+package interpolate
+
+import "strings"
+
+// Ref is a placeholder found in a string
+type Ref struct {
+	// Name is the trimmed text between the braces
+	Name string
+
+	// Start and End are the byte offsets of the placeholder, braces included
+	Start, End int
+}
+
+// IsNodeOutput reports whether the ref names a node output rather than a variable
+func (r Ref) IsNodeOutput() bool {
+	return strings.Contains(r.Name, ".")
+}
+
+// Scan returns the placeholders in s in order. When placeholders are nested,
+// such as {{outer{{inner}}}}, only the innermost is returned. Unterminated or
+// empty braces are not placeholders.
+func Scan(s string) []Ref {
+	var refs []Ref
+	for i := 0; i < len(s); {
+		open := strings.Index(s[i:], "{{")
+		if open < 0 {
+			break
+		}
+		open += i
+		// In a run of braces the placeholder opens at the last pair
+		for open+2 < len(s) && s[open+2] == '{' {
+			open++
+		}
+
+		close := strings.Index(s[open+2:], "}}")
+		if close < 0 {
+			break
+		}
+		close += open + 2
+
+		// Another opening inside means this one is not innermost; rescan from there
+		if inner := strings.LastIndex(s[open+2:close], "{{"); inner >= 0 {
+			open += 2 + inner
+		}
+
+		name := strings.TrimSpace(s[open+2 : close])
+		if name != "" {
+			refs = append(refs, Ref{Name: name, Start: open, End: close + 2})
+		}
+		i = close + 2
+	}
+	return refs
+}
+
+// Expand substitutes variables in s from vars. It returns the expanded string
+// and the names of referenced variables that vars does not define, in order
+// of first use. Node output references are kept as written.
+func Expand(s string, vars map[string]string) (string, []string) {
+	return expand(s, vars, false)
+}
+
+// ExpandAll is Expand for callers that also know node output values, such as
+// when a single node runs outside its flow. Values are keyed by placeholder
+// name, so "login.token" fills {{login.token}}; references of either kind that
+// values does not define are reported as undefined.
+func ExpandAll(s string, values map[string]string) (string, []string) {
+	return expand(s, values, true)
+}
+
+func expand(s string, vars map[string]string, outputs bool) (string, []string) {
+	var b strings.Builder
+	var undefined []string
+	seen := make(map[string]bool)
+
+	last := 0
+	for _, ref := range Scan(s) {
+		b.WriteString(s[last:ref.Start])
+		last = ref.End
+
+		if ref.IsNodeOutput() && !outputs {
+			b.WriteString(s[ref.Start:ref.End])
+			continue
+		}
+		value, ok := vars[ref.Name]
+		if !ok {
+			if !seen[ref.Name] {
+				seen[ref.Name] = true
+				undefined = append(undefined, ref.Name)
+			}
+			b.WriteString(s[ref.Start:ref.End])
+			continue
+		}
+		b.WriteString(value)
+	}
+	b.WriteString(s[last:])
+
+	return b.String(), undefined
+}
+
+// ExpandValue expands every string inside a decoded JSON value, such as a
+// request body, returning a new value and the undefined variable names
+func ExpandValue(value interface{}, vars map[string]string) (interface{}, []string) {
+	return expandValue(value, vars, false)
+}
+
+// ExpandValueAll is ExpandValue with the substitutions of ExpandAll
+func ExpandValueAll(value interface{}, values map[string]string) (interface{}, []string) {
+	return expandValue(value, values, true)
+}
+
+func expandValue(value interface{}, vars map[string]string, outputs bool) (interface{}, []string) {
+	var undefined []string
+	seen := make(map[string]bool)
+	record := func(names []string) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				undefined = append(undefined, name)
+			}
+		}
+	}
+
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case string:
+			expanded, missing := expand(v, vars, outputs)
+			record(missing)
+			return expanded
+		case map[string]interface{}:
+			result := make(map[string]interface{}, len(v))
+			for key, item := range v {
+				result[key] = walk(item)
+			}
+			return result
+		case []interface{}:
+			result := make([]interface{}, len(v))
+			for i, item := range v {
+				result[i] = walk(item)
+			}
+			return result
+		default:
+			return v
+		}
+	}
+
+	return walk(value), undefined
+}
@@ -0,0 +1,86 @@
+package interpolate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []Ref
+	}{
+		{input: "no placeholders"},
+		{input: "{{baseUrl}}/users", want: []Ref{{Name: "baseUrl", Start: 0, End: 11}}},
+		{input: "{{ spaced }}", want: []Ref{{Name: "spaced", Start: 0, End: 12}}},
+		{input: "{{a}}-{{login.token}}", want: []Ref{{Name: "a", Start: 0, End: 5}, {Name: "login.token", Start: 6, End: 21}}},
+		{input: "{{outer{{inner}}}}", want: []Ref{{Name: "inner", Start: 7, End: 16}}},
+		{input: "{{{host}}}", want: []Ref{{Name: "host", Start: 1, End: 9}}},
+		{input: "{{}} and {{  }}"},
+		{input: "{{unterminated"},
+		{input: "{{a}} then {{unterminated", want: []Ref{{Name: "a", Start: 0, End: 5}}},
+		{input: "{single}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Scan(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"baseUrl": "https://api.example.com", "id": "42", "empty": ""}
+
+	tests := []struct {
+		input         string
+		want          string
+		wantUndefined []string
+	}{
+		{input: "{{baseUrl}}/users/{{ id }}", want: "https://api.example.com/users/42"},
+		{input: "x{{empty}}y", want: "xy"},
+		{input: "{{missing}}/{{baseUrl}}/{{missing}}/{{other}}", want: "{{missing}}/https://api.example.com/{{missing}}/{{other}}", wantUndefined: []string{"missing", "other"}},
+		{input: "Bearer {{login.token}}", want: "Bearer {{login.token}}"},
+		{input: "{{outer{{id}}}}", want: "{{outer42}}"},
+		{input: "{{unterminated", want: "{{unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, undefined := Expand(tt.input, vars)
+			if got != tt.want {
+				t.Errorf("Expand = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(undefined, tt.wantUndefined) {
+				t.Errorf("undefined = %v, want %v", undefined, tt.wantUndefined)
+			}
+		})
+	}
+}
+
+func TestExpandValue(t *testing.T) {
+	body := map[string]interface{}{
+		"user":  "{{user}}",
+		"tags":  []interface{}{"{{tag}}", "fixed", 3.0},
+		"inner": map[string]interface{}{"token": "{{login.token}}", "missing": "{{nope}}"},
+		"count": 1.0,
+	}
+
+	got, undefined := ExpandValue(body, map[string]string{"user": "alice", "tag": "beta"})
+
+	want := map[string]interface{}{
+		"user":  "alice",
+		"tags":  []interface{}{"beta", "fixed", 3.0},
+		"inner": map[string]interface{}{"token": "{{login.token}}", "missing": "{{nope}}"},
+		"count": 1.0,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandValue = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(undefined, []string{"nope"}) {
+		t.Errorf("undefined = %v, want [nope]", undefined)
+	}
+	if body["user"] != "{{user}}" {
+		t.Error("ExpandValue modified its input")
+	}
+}
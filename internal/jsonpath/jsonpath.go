@@ -0,0 +1,229 @@
+// Package jsonpath implements a small subset of JSONPath for projecting
+// decoded JSON documents.
+//
+// Supported syntax:
+//
+//	$                 the root document
+//	.name, ['name']   object member
+//	[n], [-n]         array element (negative indexes count from the end)
+//	.*, [*]           every member or element
+//	..name            recursive descent
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	segmentChild segmentKind = iota
+	segmentIndex
+	segmentWildcard
+	segmentDescendant
+)
+
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// Parse compiles a JSONPath expression. The leading "$" is optional.
+func Parse(expr string) (*Path, error) {
+	p := &parser{input: strings.TrimSpace(expr)}
+	segments, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+	}
+	return &Path{expr: expr, segments: segments}, nil
+}
+
+// String returns the original expression.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Definite reports whether the path can match at most one value.
+func (p *Path) Definite() bool {
+	for _, seg := range p.segments {
+		if seg.kind == segmentWildcard || seg.kind == segmentDescendant {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval applies the path to a document decoded with encoding/json and
+// returns every matching value in document order.
+func (p *Path) Eval(doc interface{}) []interface{} {
+	current := []interface{}{doc}
+	for _, seg := range p.segments {
+		var next []interface{}
+		for _, value := range current {
+			next = append(next, seg.apply(value)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func (s segment) apply(value interface{}) []interface{} {
+	switch s.kind {
+	case segmentChild:
+		if obj, ok := value.(map[string]interface{}); ok {
+			if child, ok := obj[s.name]; ok {
+				return []interface{}{child}
+			}
+		}
+	case segmentIndex:
+		if arr, ok := value.([]interface{}); ok {
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []interface{}{arr[i]}
+			}
+		}
+	case segmentWildcard:
+		return children(value)
+	case segmentDescendant:
+		var matches []interface{}
+		collectDescendants(value, s.name, &matches)
+		return matches
+	}
+	return nil
+}
+
+// children returns array elements in order, or object members sorted by key
+// so results are stable.
+func children(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		out := make([]interface{}, 0, len(v))
+		for _, key := range keys {
+			out = append(out, v[key])
+		}
+		return out
+	}
+	return nil
+}
+
+func collectDescendants(value interface{}, name string, matches *[]interface{}) {
+	if obj, ok := value.(map[string]interface{}); ok && name != "*" {
+		if child, ok := obj[name]; ok {
+			*matches = append(*matches, child)
+		}
+	}
+	for _, child := range children(value) {
+		if name == "*" {
+			*matches = append(*matches, child)
+		}
+		collectDescendants(child, name, matches)
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) parse() ([]segment, error) {
+	if strings.HasPrefix(p.input, "$") {
+		p.pos++
+	}
+
+	var segments []segment
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '.':
+			p.pos++
+			if p.peek() == '.' {
+				p.pos++
+				name := p.readName()
+				if name == "" {
+					return nil, fmt.Errorf("expected member name after '..' at offset %d", p.pos)
+				}
+				segments = append(segments, segment{kind: segmentDescendant, name: name})
+				continue
+			}
+			name := p.readName()
+			switch name {
+			case "":
+				return nil, fmt.Errorf("expected member name at offset %d", p.pos)
+			case "*":
+				segments = append(segments, segment{kind: segmentWildcard})
+			default:
+				segments = append(segments, segment{kind: segmentChild, name: name})
+			}
+		case '[':
+			seg, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		default:
+			if p.pos == 0 {
+				// Allow a bare leading member name, e.g. "flowDefinition.nodes".
+				segments = append(segments, segment{kind: segmentChild, name: p.readName()})
+				continue
+			}
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos], p.pos)
+		}
+	}
+	return segments, nil
+}
+
+func (p *parser) parseBracket() (segment, error) {
+	end := strings.IndexByte(p.input[p.pos:], ']')
+	if end < 0 {
+		return segment{}, fmt.Errorf("unterminated '[' at offset %d", p.pos)
+	}
+	body := strings.TrimSpace(p.input[p.pos+1 : p.pos+end])
+	p.pos += end + 1
+
+	switch {
+	case body == "*":
+		return segment{kind: segmentWildcard}, nil
+	case len(body) >= 2 && (body[0] == '\'' || body[0] == '"') && body[len(body)-1] == body[0]:
+		return segment{kind: segmentChild, name: body[1 : len(body)-1]}, nil
+	default:
+		index, err := strconv.Atoi(body)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid index %q", body)
+		}
+		return segment{kind: segmentIndex, index: index}, nil
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) readName() string {
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '.' && p.input[p.pos] != '[' {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
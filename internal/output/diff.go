@@ -0,0 +1,191 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// diffContext is how many unchanged lines are kept around each change
+	diffContext = 3
+
+	// maxDiffCells bounds the line comparison table; larger edits are shown
+	// as the whole changed block removed and re-added
+	maxDiffCells = 4_000_000
+
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+	ansiReset = "\x1b[0m"
+)
+
+// diffLine is one line of a diff: ' ' unchanged, '-' removed, '+' added
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// PrintDiff writes a line-based diff from oldJSON to newJSON to w and reports
+// whether they differ. Both documents are re-indented with sorted keys first,
+// so formatting and key order never show up as changes. Removed lines start
+// with "-" and added lines with "+", in red and green when color is set.
+func PrintDiff(w io.Writer, oldJSON, newJSON []byte, color bool) (bool, error) {
+	oldLines, err := jsonLines(oldJSON)
+	if err != nil {
+		return false, err
+	}
+	newLines, err := jsonLines(newJSON)
+	if err != nil {
+		return false, err
+	}
+
+	lines := diffLines(oldLines, newLines)
+	changed := false
+	for _, line := range lines {
+		if line.op != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + ansiReset
+	}
+
+	// Keep unchanged lines only near a change
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		for j := max(i-diffContext, 0); j <= min(i+diffContext, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+
+	skipped := false
+	for i, line := range lines {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			if _, err := fmt.Fprintln(w, paint(ansiCyan, "  ...")); err != nil {
+				return true, err
+			}
+			skipped = false
+		}
+
+		text := string(line.op) + " " + line.text
+		switch line.op {
+		case '-':
+			text = paint(ansiRed, text)
+		case '+':
+			text = paint(ansiGreen, text)
+		}
+		if _, err := fmt.Fprintln(w, text); err != nil {
+			return true, err
+		}
+	}
+	if skipped {
+		if _, err := fmt.Fprintln(w, paint(ansiCyan, "  ...")); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// jsonLines re-indents a JSON document with sorted keys and splits it into lines
+func jsonLines(data []byte) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	formatted, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(formatted), "\n"), nil
+}
+
+// diffLines aligns a and b on their longest common subsequence of lines
+func diffLines(a, b []string) []diffLine {
+	// Lines shared at the start and end need no comparison table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]diffLine, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		result = append(result, diffLine{' ', text})
+	}
+	result = append(result, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		result = append(result, diffLine{' ', text})
+	}
+	return result
+}
+
+func diffMiddle(a, b []string) []diffLine {
+	result := make([]diffLine, 0, len(a)+len(b))
+	if len(a)*len(b) > maxDiffCells {
+		for _, text := range a {
+			result = append(result, diffLine{'-', text})
+		}
+		for _, text := range b {
+			result = append(result, diffLine{'+', text})
+		}
+		return result
+	}
+
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{'-', a[i]})
+			i++
+		default:
+			result = append(result, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, diffLine{'+', b[j]})
+	}
+	return result
+}
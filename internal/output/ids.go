@@ -0,0 +1,53 @@
+package output
+
+import (
+	"sort"
+	"strings"
+)
+
+// ShortIDLength is how many characters of an ID are shown in compact mode
+const ShortIDLength = 8
+
+// ShortIDs abbreviates each ID to its first ShortIDLength characters, like a
+// git short hash. IDs that would collide are lengthened until every shown
+// prefix is unique within ids, which matters for time-ordered UUIDv7s created
+// in the same minute.
+func ShortIDs(ids []string) []string {
+	// Only neighbours in sorted order can share the longest prefix
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	lengths := make(map[string]int, len(ids))
+	for i, id := range sorted {
+		length := ShortIDLength
+		if i > 0 && sorted[i-1] != id {
+			length = max(length, commonPrefix(id, sorted[i-1])+1)
+		}
+		if i+1 < len(sorted) && sorted[i+1] != id {
+			length = max(length, commonPrefix(id, sorted[i+1])+1)
+		}
+		lengths[id] = max(lengths[id], length)
+	}
+
+	short := make([]string, len(ids))
+	for i, id := range ids {
+		short[i] = id[:min(lengths[id], len(id))]
+	}
+	return short
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// IsIDPrefix reports whether s could abbreviate a UUID: at least four
+// characters, all hexadecimal digits or dashes
+func IsIDPrefix(s string) bool {
+	if len(s) < 4 {
+		return false
+	}
+	return strings.Trim(strings.ToLower(s), "0123456789abcdef-") == ""
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
-	"text/tabwriter"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +17,7 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatYAML  Format = "yaml"
+	FormatJSONL Format = "jsonl"
 )
 
 func ParseFormat(value string) Format {
@@ -25,24 +26,28 @@ func ParseFormat(value string) Format {
 		return FormatJSON
 	case string(FormatYAML):
 		return FormatYAML
+	case string(FormatJSONL):
+		return FormatJSONL
 	default:
 		return FormatTable
 	}
 }
 
+// PrintTable prints left-aligned columns to stdout
 func PrintTable(headers []string, rows [][]string) error {
-	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	if len(headers) > 0 {
-		fmt.Fprintln(tw, strings.Join(headers, "\t"))
-	}
-	for _, row := range rows {
-		fmt.Fprintln(tw, strings.Join(row, "\t"))
-	}
-	return tw.Flush()
+	return PrintColumns(os.Stdout, Columns(headers...), rows, 0)
 }
 
-func PrintJSON(w io.Writer, value interface{}) error {
-	data, err := json.MarshalIndent(value, "", "  ")
+// PrintJSON prints value as JSON, indented when pretty is set and on a
+// single line otherwise
+func PrintJSON(w io.Writer, value interface{}, pretty bool) error {
+	var data []byte
+	var err error
+	if pretty {
+		data, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		data, err = json.Marshal(value)
+	}
 	if err != nil {
 		return err
 	}
@@ -50,6 +55,17 @@ func PrintJSON(w io.Writer, value interface{}) error {
 	return err
 }
 
+// PrintJSONL prints each item as compact JSON on its own line
+func PrintJSONL[T any](w io.Writer, items []T) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func PrintYAML(w io.Writer, value interface{}) error {
 	data, err := yaml.Marshal(value)
 	if err != nil {
@@ -58,3 +74,40 @@ func PrintYAML(w io.Writer, value interface{}) error {
 	_, err = fmt.Fprintln(w, string(data))
 	return err
 }
+
+// PrintCanonicalYAML prints value as YAML with every mapping's keys sorted,
+// so the same value always produces byte-identical output
+func PrintCanonicalYAML(w io.Writer, value interface{}) error {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	sortYAMLKeys(&node)
+
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// sortYAMLKeys orders the keys of every mapping under node
+func sortYAMLKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i][0].Value < pairs[j][0].Value
+		})
+		node.Content = node.Content[:0]
+		for _, pair := range pairs {
+			node.Content = append(node.Content, pair[0], pair[1])
+		}
+	}
+	for _, child := range node.Content {
+		sortYAMLKeys(child)
+	}
+}
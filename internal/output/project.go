@@ -0,0 +1,54 @@
+package output
+
+import (
+	"encoding/json"
+
+	"echopoint-cli/internal/jsonpath"
+)
+
+// Project applies JSONPath expressions to value's JSON representation.
+// A single expression yields its matches directly (unwrapped when the path is
+// definite); several expressions yield an object keyed by expression.
+func Project(value interface{}, exprs []string) (interface{}, error) {
+	paths := make([]*jsonpath.Path, 0, len(exprs))
+	for _, expr := range exprs {
+		path, err := jsonpath.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 1 {
+		return projectPath(paths[0], doc), nil
+	}
+
+	result := make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		result[path.String()] = projectPath(path, doc)
+	}
+	return result, nil
+}
+
+func projectPath(path *jsonpath.Path, doc interface{}) interface{} {
+	matches := path.Eval(doc)
+	if path.Definite() {
+		if len(matches) == 0 {
+			return nil
+		}
+		return matches[0]
+	}
+	if matches == nil {
+		return []interface{}{}
+	}
+	return matches
+}
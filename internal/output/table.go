@@ -0,0 +1,136 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Align controls how cells are padded within a column
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// Column describes a table column
+type Column struct {
+	Header string
+	Align  Align
+}
+
+// Columns returns left-aligned columns with the given headers
+func Columns(headers ...string) []Column {
+	columns := make([]Column, len(headers))
+	for i, header := range headers {
+		columns[i] = Column{Header: header}
+	}
+	return columns
+}
+
+// columnGap is the padding between columns
+const columnGap = 2
+
+// minColumnWidth is the narrowest a column is truncated to by PrintColumns
+const minColumnWidth = 8
+
+// PrintColumns writes a table to w. With a positive width the widest columns
+// are truncated with an ellipsis until rows fit; columns are never truncated
+// below minColumnWidth, so very narrow widths can still overflow.
+func PrintColumns(w io.Writer, columns []Column, rows [][]string, width int) error {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	widths := make([]int, len(columns))
+	measure := func(row []string) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	measure(headers)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	if width > 0 {
+		shrinkColumns(widths, width)
+	}
+
+	var b strings.Builder
+	writeRow := func(row []string) {
+		var line strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteString(strings.Repeat(" ", columnGap))
+			}
+			cell = truncate(cell, widths[i])
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if i < len(columns) && columns[i].Align == AlignRight {
+				line.WriteString(pad + cell)
+			} else {
+				line.WriteString(cell + pad)
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	if len(columns) > 0 {
+		writeRow(headers)
+	}
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// shrinkColumns narrows the widest columns until they fit within width
+func shrinkColumns(widths []int, width int) {
+	total := columnGap * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > width {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
+// TerminalWidth returns the width of the terminal on stdout, or 0 when stdout
+// is not a terminal
+func TerminalWidth() int {
+	width, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeFormat controls how timestamps are rendered in table output
+type TimeFormat string
+
+const (
+	TimeFormatRFC3339  TimeFormat = "rfc3339"
+	TimeFormatRelative TimeFormat = "relative"
+)
+
+// ParseTimeFormat validates a --time-format value
+func ParseTimeFormat(value string) (TimeFormat, error) {
+	switch format := TimeFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case TimeFormatRFC3339, TimeFormatRelative:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid time format: %s (must be rfc3339 or relative)", value)
+	}
+}
+
+// FormatTime renders t in loc as RFC3339, or relative to now such as "2h ago"
+func FormatTime(t time.Time, format TimeFormat, loc *time.Location) string {
+	if format == TimeFormatRelative {
+		return relativeTime(t, time.Now())
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// relativeTime describes t relative to now in the largest whole unit
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	suffix := " ago"
+	if d < 0 {
+		d = -d
+		suffix = ""
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		amount = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d < 365*24*time.Hour:
+		amount = fmt.Sprintf("%dmo", int(d/(30*24*time.Hour)))
+	default:
+		amount = fmt.Sprintf("%dy", int(d/(365*24*time.Hour)))
+	}
+
+	if suffix == "" {
+		return "in " + amount
+	}
+	return amount + suffix
+}
@@ -0,0 +1,116 @@
+// Package redact masks the values of sensitive fields in JSON bodies before
+// they are written to debug logs and traces.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Mask replaces every redacted value
+const Mask = "***"
+
+// DefaultKeys are always redacted. A key matches when its name contains one
+// of them, ignoring case, so "token" also covers "access_token".
+var DefaultKeys = []string{
+	"password",
+	"secret",
+	"token",
+	"authorization",
+	"api_key",
+	"apikey",
+	"cookie",
+	"credential",
+}
+
+// Redactor masks the values of JSON object members whose names match its keys
+type Redactor struct {
+	keys []string
+}
+
+// New returns a Redactor for DefaultKeys plus extra. Blank entries in extra
+// are ignored.
+func New(extra ...string) *Redactor {
+	keys := make([]string, 0, len(DefaultKeys)+len(extra))
+	for _, key := range append(append([]string(nil), DefaultKeys...), extra...) {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return &Redactor{keys: keys}
+}
+
+// Keys returns the patterns r matches, lowercased
+func (r *Redactor) Keys() []string {
+	return append([]string(nil), r.keys...)
+}
+
+// Match reports whether a member named name is redacted
+func (r *Redactor) Match(name string) bool {
+	name = strings.ToLower(name)
+	for _, key := range r.keys {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Body returns body with matching members masked at any depth. Bodies that
+// are not JSON, or have nothing to mask, are returned unchanged; masked
+// bodies are re-encoded compactly.
+func (r *Redactor) Body(body []byte) []byte {
+	if r == nil || len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return body
+	}
+	if !r.mask(doc) {
+		return body
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return body
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+// String is Body for string bodies
+func (r *Redactor) String(body string) string {
+	return string(r.Body([]byte(body)))
+}
+
+// mask redacts matching members of value in place and reports whether any
+// were found
+func (r *Redactor) mask(value interface{}) bool {
+	masked := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, member := range v {
+			if member != nil && r.Match(name) {
+				v[name] = Mask
+				masked = true
+				continue
+			}
+			if r.mask(member) {
+				masked = true
+			}
+		}
+	case []interface{}:
+		for _, element := range v {
+			if r.mask(element) {
+				masked = true
+			}
+		}
+	}
+	return masked
+}
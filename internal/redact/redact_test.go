@@ -0,0 +1,124 @@
+package redact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	r := New(" Session ", "", "  ", "X-Internal")
+	want := append(append([]string(nil), DefaultKeys...), "session", "x-internal")
+	if got := r.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	r := New("pin")
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "password", want: true},
+		{name: "Password", want: true},
+		{name: "access_token", want: true},
+		{name: "X-API-KEY"},
+		{name: "api_key", want: true},
+		{name: "clientSecret", want: true},
+		{name: "set-cookie", want: true},
+		{name: "pin", want: true},
+		{name: "spinner", want: true},
+		{name: "username"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Match(tt.name); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		extra []string
+		body  string
+		want  string
+	}{
+		{
+			name: "top level",
+			body: `{"user": "alice", "password": "hunter2"}`,
+			want: `{"password":"***","user":"alice"}`,
+		},
+		{
+			name: "nested objects and arrays",
+			body: `{"accounts": [{"name": "a", "auth": {"access_token": "t1", "refresh_token": "t2"}}, {"name": "b", "secret": 42}]}`,
+			want: `{"accounts":[{"auth":{"access_token":"***","refresh_token":"***"},"name":"a"},{"name":"b","secret":"***"}]}`,
+		},
+		{
+			name: "whole object under a matching key",
+			body: `{"credentials": {"user": "alice", "pin": "1234"}}`,
+			want: `{"credentials":"***"}`,
+		},
+		{
+			name: "null is kept",
+			body: `{"token": null, "password": "x"}`,
+			want: `{"password":"***","token":null}`,
+		},
+		{
+			name:  "extra keys",
+			extra: []string{"ssn"},
+			body:  `{"SSN": "123-45-6789", "name": "alice"}`,
+			want:  `{"SSN":"***","name":"alice"}`,
+		},
+		{
+			name: "numbers and HTML survive re-encoding",
+			body: `{"id": 12345678901234567890, "ratio": 1.50, "html": "<b>&</b>", "token": "t"}`,
+			want: `{"html":"<b>&</b>","id":12345678901234567890,"ratio":1.50,"token":"***"}`,
+		},
+		{
+			name: "nothing to mask keeps formatting",
+			body: "{\n  \"user\": \"alice\"\n}",
+			want: "{\n  \"user\": \"alice\"\n}",
+		},
+		{
+			name: "keys only match names, not values",
+			body: `{"note": "my password is hunter2"}`,
+			want: `{"note": "my password is hunter2"}`,
+		},
+		{
+			name: "not JSON",
+			body: `password=hunter2`,
+			want: `password=hunter2`,
+		},
+		{
+			name: "several documents",
+			body: `{"password": "a"} {"password": "b"}`,
+			want: `{"password": "a"} {"password": "b"}`,
+		},
+		{
+			name: "top-level array",
+			body: `[{"token": "t"}, "token"]`,
+			want: `[{"token":"***"},"token"]`,
+		},
+		{name: "empty", body: ``, want: ``},
+		{name: "blank", body: "  \n", want: "  \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.extra...).String(tt.body); got != tt.want {
+				t.Errorf("String(%q) =\n%s\nwant\n%s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilRedactorKeepsBody(t *testing.T) {
+	var r *Redactor
+	body := []byte(`{"password": "hunter2"}`)
+	if got := r.Body(body); string(got) != string(body) {
+		t.Errorf("Body = %s, want it unchanged", got)
+	}
+}
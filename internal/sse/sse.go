@@ -0,0 +1,76 @@
+// Package sse reads Server-Sent Events streams.
+package sse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Event is a single dispatched event
+type Event struct {
+	// Type is the event field, or "message" when the event has none
+	Type string
+
+	// Data is the event's data lines joined with newlines
+	Data string
+}
+
+// Reader reads events from a stream
+type Reader struct {
+	scanner *bufio.Scanner
+}
+
+// NewReader returns a Reader that reads events from r
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &Reader{scanner: scanner}
+}
+
+// Next returns the next event. It returns io.EOF once the stream ends; an
+// event that is not terminated by a blank line is still returned.
+func (r *Reader) Next() (Event, error) {
+	var event Event
+	var data []string
+	pending := false
+
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if pending {
+				return finish(event, data), nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+			pending = true
+		case "data":
+			data = append(data, value)
+			pending = true
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return Event{}, err
+	}
+	if pending {
+		return finish(event, data), nil
+	}
+	return Event{}, io.EOF
+}
+
+func finish(event Event, data []string) Event {
+	if event.Type == "" {
+		event.Type = "message"
+	}
+	event.Data = strings.Join(data, "\n")
+	return event
+}
@@ -0,0 +1,61 @@
+// Package tap writes Test Anything Protocol (version 13) reports.
+package tap
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Result is one test point
+type Result struct {
+	Name string
+	OK   bool
+
+	// Diagnostics are written as a YAML block under failed points
+	Diagnostics map[string]string
+}
+
+// Write writes the version line, the plan, and a line per result to w
+func Write(w io.Writer, results []Result) error {
+	var b strings.Builder
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(results))
+
+	for i, result := range results {
+		status := "ok"
+		if !result.OK {
+			status = "not ok"
+		}
+		fmt.Fprintf(&b, "%s %d - %s\n", status, i+1, escape(result.Name))
+
+		if result.OK || len(result.Diagnostics) == 0 {
+			continue
+		}
+		b.WriteString("  ---\n")
+		for _, key := range sortedKeys(result.Diagnostics) {
+			fmt.Fprintf(&b, "  %s: %q\n", key, result.Diagnostics[key])
+		}
+		b.WriteString("  ...\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escape keeps a description from being read as a directive or a new line
+func escape(name string) string {
+	name = strings.ReplaceAll(name, "\\", "\\\\")
+	name = strings.ReplaceAll(name, "#", "\\#")
+	return strings.ReplaceAll(name, "\n", " ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
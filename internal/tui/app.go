@@ -3,9 +3,11 @@ package tui
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"echopoint-cli/internal/api"
 	"echopoint-cli/internal/client"
+	"echopoint-cli/internal/output"
 	"echopoint-cli/internal/tui/floweditor"
 
 	"os"
@@ -37,10 +39,11 @@ func (i item) FilterValue() string { return i.title }
 
 type flowItem struct {
 	flow api.Flow
+	id   string
 }
 
 func (f flowItem) Title() string       { return f.flow.Name }
-func (f flowItem) Description() string { return f.flow.Id.String() }
+func (f flowItem) Description() string { return f.id }
 func (f flowItem) FilterValue() string { return f.flow.Name }
 
 type Model struct {
@@ -56,9 +59,19 @@ type Model struct {
 	message      string
 	flowEditor   *floweditor.Editor
 	selectedFlow *api.Flow
+
+	// refreshInterval re-fetches the flow list periodically while it is
+	// shown; zero disables background refresh.
+	refreshInterval time.Duration
+	refreshing      bool
+
+	// compactIDs shows flow IDs shortened like git short hashes
+	compactIDs bool
 }
 
-func New(cli *client.Client) Model {
+// New creates the TUI model. A non-zero refreshInterval keeps the flow list
+// in sync with changes made elsewhere; compactIDs shortens the flow IDs shown.
+func New(cli *client.Client, refreshInterval time.Duration, compactIDs bool) Model {
 	items := []list.Item{
 		item{title: "Flows", desc: "Create and manage flows"},
 		item{title: "Collections", desc: "Manage collections"},
@@ -83,11 +96,13 @@ func New(cli *client.Client) Model {
 	descInput.Width = 50
 
 	return Model{
-		client:      cli,
-		currentView: viewMenu,
-		list:        l,
-		nameInput:   nameInput,
-		descInput:   descInput,
+		client:          cli,
+		currentView:     viewMenu,
+		list:            l,
+		nameInput:       nameInput,
+		descInput:       descInput,
+		refreshInterval: refreshInterval,
+		compactIDs:      compactIDs,
 	}
 }
 
@@ -101,6 +116,14 @@ type flowCreatedMsg struct {
 	err  error
 }
 
+type refreshTickMsg struct{}
+
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
 func loadFlows(cli *client.Client) tea.Cmd {
 	return func() tea.Msg {
 		limit := int32(100)
@@ -164,6 +187,23 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The editor receives every message while open, including the results
+	// of its own load and save commands
+	if m.currentView == viewFlowEditor && m.flowEditor != nil {
+		switch msg.(type) {
+		case refreshTickMsg, flowsLoadedMsg:
+		default:
+			if size, ok := msg.(tea.WindowSizeMsg); ok {
+				m.width = size.Width
+				m.height = size.Height
+				m.list.SetSize(size.Width, size.Height-2)
+			}
+			editor, cmd := m.flowEditor.Update(msg)
+			m.flowEditor = editor
+			return m, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch m.currentView {
@@ -173,12 +213,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateFlows(msg)
 		case viewFlowCreate:
 			return m.updateFlowCreate(msg)
-		case viewFlowEditor:
-			if m.flowEditor != nil {
-				editor, cmd := m.flowEditor.Update(msg)
-				m.flowEditor = editor
-				return m, cmd
-			}
 		}
 
 	case tea.WindowSizeMsg:
@@ -187,17 +221,59 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.SetSize(msg.Width, msg.Height-2)
 
 	case flowsLoadedMsg:
+		// A refresh may complete after the user has left the flow list
+		if m.currentView != viewFlows {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
+		m.err = nil
+
+		var selectedID string
+		if selected, ok := m.list.SelectedItem().(flowItem); ok {
+			selectedID = selected.flow.Id.String()
+		}
+
+		ids := make([]string, len(msg.flows))
+		for i, flow := range msg.flows {
+			ids[i] = flow.Id.String()
+		}
+		if m.compactIDs {
+			ids = output.ShortIDs(ids)
+		}
 		items := make([]list.Item, len(msg.flows))
 		for i, flow := range msg.flows {
-			items[i] = flowItem{flow: flow}
+			items[i] = flowItem{flow: flow, id: ids[i]}
 		}
-		m.list.SetItems(items)
+		cmd := m.list.SetItems(items)
 		m.list.Title = "Flows (press n to create, enter to edit, esc to go back)"
-		return m, nil
+
+		// Keep the cursor on the same flow when the list order changes
+		for i, flow := range msg.flows {
+			if flow.Id.String() == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+		return m, cmd
+
+	case refreshTickMsg:
+		switch m.currentView {
+		case viewFlows:
+			// Don't replace the items underneath an active filter
+			if m.list.SettingFilter() || m.list.IsFiltered() {
+				return m, refreshTick(m.refreshInterval)
+			}
+			return m, tea.Batch(loadFlows(m.client), refreshTick(m.refreshInterval))
+		case viewFlowCreate:
+			// The create form returns to the list, so keep the loop alive
+			return m, refreshTick(m.refreshInterval)
+		default:
+			m.refreshing = false
+			return m, nil
+		}
 
 	case flowCreatedMsg:
 		if msg.err != nil {
@@ -225,7 +301,8 @@ func (m Model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			case "Flows":
 				m.currentView = viewFlows
-				return m, loadFlows(m.client)
+				refresh := m.startRefresh()
+				return m, tea.Batch(loadFlows(m.client), refresh)
 			case "Collections":
 				m.currentView = viewCollections
 				m.message = "Collections view coming soon"
@@ -239,6 +316,16 @@ func (m Model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// startRefresh begins the background refresh loop unless it is disabled or
+// already running. It must be called on the model that Update returns.
+func (m *Model) startRefresh() tea.Cmd {
+	if m.refreshInterval <= 0 || m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return refreshTick(m.refreshInterval)
+}
+
 func (m Model) updateFlows(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q", "ctrl+c":
@@ -0,0 +1,291 @@
+package floweditor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"echopoint-cli/internal/api"
+	"echopoint-cli/internal/flowbuilder"
+
+	"github.com/google/uuid"
+)
+
+// Node positions are stored in flow metadata in the web editor's pixel
+// coordinates. These factors convert them to and from grid cells.
+const (
+	positionScaleX = 10
+	positionScaleY = 25
+)
+
+// nodePosition matches the element type of the metadata node_positions map
+type nodePosition = struct {
+	X *float32 `json:"x,omitempty"`
+	Y *float32 `json:"y,omitempty"`
+}
+
+// buildGraph converts the flow definition into a graph, placing nodes at their
+// saved positions and stacking any without one down the left-hand side
+func buildGraph(flow *api.Flow) (*FlowGraph, error) {
+	graph := NewFlowGraph(flow.Id, flow.Name)
+	if flow.Description != nil {
+		graph.Description = *flow.Description
+	}
+
+	var positions map[string]nodePosition
+	if flow.Metadata.NodePositions != nil {
+		positions = *flow.Metadata.NodePositions
+	}
+
+	nodeIDs := make(map[string]uuid.UUID, len(flow.FlowDefinition.Nodes))
+	for i, apiNode := range flow.FlowDefinition.Nodes {
+		value, err := decodeNode(apiNode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode node %d: %w", i, err)
+		}
+
+		x, y := 5, 2+i*5
+		var node *Node
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			node = graph.AddNode(NodeTypeRequest, n.DisplayName, x, y)
+			node.Key = n.Id
+			node.Data.URL = n.Data.Url
+			node.Data.Method = string(n.Data.Method)
+			if n.Data.Headers != nil {
+				node.Data.Headers = *n.Data.Headers
+			}
+			node.Data.Body = describeBody(n.Data.Body)
+			node.Disabled = n.Disabled != nil && *n.Disabled
+			setNodeDetails(node, n.Outputs, n.Assertions)
+		case api.DelayFlowNode:
+			node = graph.AddNode(NodeTypeDelay, n.DisplayName, x, y)
+			node.Key = n.Id
+			node.Data.Duration = n.Data.Duration
+			node.Disabled = n.Disabled != nil && *n.Disabled
+			setNodeDetails(node, n.Outputs, n.Assertions)
+		case api.BaseFlowNode:
+			// A type this editor does not know: shown and saved unchanged
+			node = graph.AddNode(NodeType(n.Type), n.DisplayName, x, y)
+			node.Key = n.Id
+			node.Disabled = n.Disabled != nil && *n.Disabled
+			setNodeDetails(node, n.Outputs, n.Assertions)
+		}
+
+		if pos, ok := positions[node.Key]; ok && pos.X != nil && pos.Y != nil {
+			node.X = int(*pos.X / positionScaleX)
+			node.Y = int(*pos.Y / positionScaleY)
+		}
+		nodeIDs[node.Key] = node.ID
+	}
+
+	for _, apiEdge := range flow.FlowDefinition.Edges {
+		from, fromOK := nodeIDs[apiEdge.Source]
+		to, toOK := nodeIDs[apiEdge.Target]
+		if !fromOK || !toOK {
+			continue
+		}
+		edge := graph.AddEdge(from, to, EdgeType(apiEdge.Type))
+		edge.Key = apiEdge.Id
+	}
+
+	return graph, nil
+}
+
+// decodeNode returns the typed value of a request or delay node, and the
+// fields shared by every node for any other type, so that nodes added to the
+// API after this editor was built are kept rather than dropped on save
+func decodeNode(apiNode api.FlowNode) (interface{}, error) {
+	discriminator, err := apiNode.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case string(NodeTypeRequest), string(NodeTypeDelay):
+		return apiNode.ValueByDiscriminator()
+	}
+
+	data, err := apiNode.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var base api.BaseFlowNode
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, err
+	}
+	if base.Id == "" {
+		return nil, fmt.Errorf("%s node has no id", discriminator)
+	}
+	return base, nil
+}
+
+// setNodeDetails records the counts and descriptions of a node's outputs and assertions
+func setNodeDetails(node *Node, outputs *[]api.Output, assertions *[]api.CompositeAssertion) {
+	if outputs != nil {
+		node.Outputs = len(*outputs)
+		for _, output := range *outputs {
+			node.OutputDetails = append(node.OutputDetails, describeOutput(output))
+		}
+	}
+	if assertions != nil {
+		node.Assertions = len(*assertions)
+		for _, assertion := range *assertions {
+			node.AssertionDetails = append(node.AssertionDetails, describeAssertion(assertion))
+		}
+	}
+}
+
+// describeOutput formats an output as "name ← extractor [path] [as type]"
+func describeOutput(output api.Output) string {
+	desc := fmt.Sprintf("%s ← %s", output.Name, output.Extractor.Type)
+	if output.Extractor.Path != nil {
+		desc += " " + *output.Extractor.Path
+	}
+	if output.Extractor.HeaderName != nil {
+		desc += " " + *output.Extractor.HeaderName
+	}
+	if output.Extractor.As != nil && *output.Extractor.As != api.OutputExtractorAsString {
+		desc += " as " + string(*output.Extractor.As)
+	}
+	return desc
+}
+
+// describeAssertion formats an assertion as "extractor [path] operator [value]"
+func describeAssertion(assertion api.CompositeAssertion) string {
+	desc := string(assertion.ExtractorType)
+	if path, ok := assertion.ExtractorData["path"]; ok {
+		desc += fmt.Sprintf(" %v", path)
+	}
+	desc += " " + string(assertion.OperatorType)
+	if value, ok := assertion.OperatorData["value"]; ok {
+		desc += fmt.Sprintf(" %v", value)
+	}
+	return desc
+}
+
+// describeBody renders a request body for display, keeping strings as-is
+func describeBody(body interface{}) string {
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Sprintf("%v", b)
+		}
+		return string(data)
+	}
+}
+
+// buildUpdateRequest converts the graph back into an update for flow. Nodes
+// that came from the flow keep their full definition, whatever their type;
+// nodes added in the editor get a minimal one. Positions are written to the flow metadata.
+func buildUpdateRequest(graph *FlowGraph, flow *api.Flow) (api.UpdateFlowRequest, error) {
+	existing := make(map[string]api.FlowNode, len(flow.FlowDefinition.Nodes))
+	for _, apiNode := range flow.FlowDefinition.Nodes {
+		value, err := decodeNode(apiNode)
+		if err != nil {
+			return api.UpdateFlowRequest{}, fmt.Errorf("failed to decode node: %w", err)
+		}
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			existing[n.Id] = apiNode
+		case api.DelayFlowNode:
+			existing[n.Id] = apiNode
+		case api.BaseFlowNode:
+			existing[n.Id] = apiNode
+		}
+	}
+
+	definition := flow.FlowDefinition
+	definition.Nodes = make([]api.FlowNode, 0, len(graph.Nodes))
+	definition.Edges = make([]api.FlowEdge, 0, len(graph.Edges))
+	positions := make(map[string]nodePosition, len(graph.Nodes))
+
+	for _, node := range graph.Nodes {
+		apiNode, ok := existing[node.Key]
+		if !ok {
+			var err error
+			apiNode, err = newAPINode(node)
+			if err != nil {
+				return api.UpdateFlowRequest{}, err
+			}
+		}
+		definition.Nodes = append(definition.Nodes, apiNode)
+
+		x := float32(node.X * positionScaleX)
+		y := float32(node.Y * positionScaleY)
+		positions[node.Key] = nodePosition{X: &x, Y: &y}
+	}
+
+	for _, edge := range graph.Edges {
+		from := graph.GetNode(edge.From)
+		to := graph.GetNode(edge.To)
+		if from == nil || to == nil {
+			continue
+		}
+		definition.Edges = append(definition.Edges, api.FlowEdge{
+			Id:     edge.Key,
+			Source: from.Key,
+			Target: to.Key,
+			Type:   api.FlowEdgeType(edge.Type),
+		})
+	}
+
+	definition, err := flowbuilder.Normalize(definition)
+	if err != nil {
+		return api.UpdateFlowRequest{}, err
+	}
+
+	metadata := &api.UpdateFlowRequest_Metadata{
+		NodePositions:        &positions,
+		AdditionalProperties: flow.Metadata.AdditionalProperties,
+	}
+
+	// Keep the positions chosen in the editor
+	autoLayout := false
+	return api.UpdateFlowRequest{
+		FlowDefinition: &definition,
+		Metadata:       metadata,
+		AutoLayout:     &autoLayout,
+	}, nil
+}
+
+// newAPINode builds a definition for a node created in the editor
+func newAPINode(node Node) (api.FlowNode, error) {
+	var apiNode api.FlowNode
+	var err error
+
+	switch node.Type {
+	case NodeTypeRequest:
+		method := node.Data.Method
+		if method == "" {
+			method = "GET"
+		}
+		err = apiNode.FromRequestFlowNode(api.RequestFlowNode{
+			Id:          node.Key,
+			Type:        string(NodeTypeRequest),
+			DisplayName: node.Name,
+			Data: api.RequestNodeData{
+				Method: api.RequestNodeDataMethod(method),
+				Url:    node.Data.URL,
+			},
+		})
+	case NodeTypeDelay:
+		duration := node.Data.Duration
+		if duration == 0 {
+			duration = 1000
+		}
+		err = apiNode.FromDelayFlowNode(api.DelayFlowNode{
+			Id:          node.Key,
+			Type:        string(NodeTypeDelay),
+			DisplayName: node.Name,
+			Data:        api.DelayNodeData{Duration: duration},
+		})
+	default:
+		err = fmt.Errorf("node type %s cannot be saved", node.Type)
+	}
+
+	return apiNode, err
+}
@@ -0,0 +1,78 @@
+package floweditor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildUpdateRequestKeepsUnknownNodes(t *testing.T) {
+	var flow api.Flow
+	flow.Id = uuid.New()
+	flow.Name = "mixed"
+	if err := json.Unmarshal([]byte(`{
+		"nodes": [
+			{"id": "req", "type": "request", "display_name": "Login", "data": {"method": "POST", "url": "https://example.com/login"}},
+			{"id": "js", "type": "script", "display_name": "Transform", "data": {"source": "return input"}, "disabled": true}
+		],
+		"edges": [
+			{"id": "e1", "source": "req", "target": "js", "type": "success"}
+		]
+	}`), &flow.FlowDefinition); err != nil {
+		t.Fatal(err)
+	}
+
+	graph, err := buildGraph(&flow)
+	if err != nil {
+		t.Fatalf("buildGraph: %v", err)
+	}
+	if len(graph.Nodes) != 2 || len(graph.Edges) != 1 {
+		t.Fatalf("graph has %d nodes and %d edges, want 2 and 1", len(graph.Nodes), len(graph.Edges))
+	}
+	script := graph.Nodes[1]
+	if script.Type != NodeType("script") || script.Name != "Transform" || !script.Disabled {
+		t.Errorf("script node = %+v, want type script, name Transform, disabled", script)
+	}
+
+	req, err := buildUpdateRequest(graph, &flow)
+	if err != nil {
+		t.Fatalf("buildUpdateRequest: %v", err)
+	}
+	if len(req.FlowDefinition.Nodes) != 2 {
+		t.Fatalf("update has %d nodes, want 2", len(req.FlowDefinition.Nodes))
+	}
+	if len(req.FlowDefinition.Edges) != 1 || req.FlowDefinition.Edges[0].Target != "js" {
+		t.Errorf("update edges = %+v, want the edge to js", req.FlowDefinition.Edges)
+	}
+
+	for _, node := range req.FlowDefinition.Nodes {
+		data, err := node.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if fields["id"] != "js" {
+			continue
+		}
+		source := fields["data"].(map[string]interface{})["source"]
+		if fields["type"] != "script" || source != "return input" {
+			t.Errorf("script node saved as %s, want it unchanged", data)
+		}
+	}
+}
+
+func TestBuildGraphRejectsNodeWithoutID(t *testing.T) {
+	var flow api.Flow
+	if err := json.Unmarshal([]byte(`{"nodes": [{"type": "script", "display_name": "No ID"}], "edges": []}`), &flow.FlowDefinition); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buildGraph(&flow); err == nil {
+		t.Error("buildGraph accepted a node without an id")
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
+
+	"echopoint-cli/internal/redact"
 )
 
 // DebugLevel represents the verbosity level of debug logging
@@ -22,13 +27,20 @@ const (
 	DebugLevelTrace
 )
 
-// Logger handles debug logging for the flow editor
+// DefaultMaxBodyLog is how many bytes of a request or response body are
+// logged unless ECHOPOINT_DEBUG_MAX_BODY says otherwise
+const DefaultMaxBodyLog = 4096
+
+// Logger handles debug logging for the flow editor. The level is read
+// without locking on every log call, so it is atomic; the remaining fields
+// are guarded by mu.
 type Logger struct {
-	level   DebugLevel
-	file    *os.File
-	mu      sync.Mutex
-	enabled bool
-	logPath string
+	level    atomic.Int32
+	file     *os.File
+	mu       sync.Mutex
+	logPath  string
+	maxBody  int
+	redactor *redact.Redactor
 }
 
 var (
@@ -40,24 +52,32 @@ var (
 func GetLogger() *Logger {
 	once.Do(func() {
 		globalLogger = &Logger{
-			level:   DebugLevelOff,
-			enabled: false,
+			maxBody:  DefaultMaxBodyLog,
+			redactor: redact.New(),
 		}
 	})
 	return globalLogger
 }
 
-// InitLogger initializes the logger with a specific level and log file
+// InitLogger initializes the logger with a specific level and log file. It
+// may be called again to reconfigure the logger, which closes the previous
+// log file; concurrent log calls are safe throughout.
 func InitLogger(level DebugLevel, logPath string) error {
 	logger := GetLogger()
 	logger.mu.Lock()
 	defer logger.mu.Unlock()
 
-	logger.level = level
-	logger.enabled = level > DebugLevelOff
+	if logger.file != nil {
+		logger.log("LOGGER", "Debug logging reconfigured")
+		logger.file.Close()
+		logger.file = nil
+	}
+
+	logger.level.Store(int32(level))
 	logger.logPath = logPath
+	logger.maxBody = MaxBodyLogFromEnv()
 
-	if !logger.enabled {
+	if level <= DebugLevelOff {
 		return nil
 	}
 
@@ -81,26 +101,61 @@ func InitLogger(level DebugLevel, logPath string) error {
 	return nil
 }
 
-// Close closes the log file
+// Close writes a final entry and closes the log file. It is safe to call more
+// than once; later entries are dropped.
 func (l *Logger) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.file != nil {
-		l.log("LOGGER", "Debug logging stopped")
-		return l.file.Close()
+	if l.file == nil {
+		return nil
+	}
+	l.log("LOGGER", "Debug logging stopped")
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// MaxBodyLogFromEnv returns the body cap set by ECHOPOINT_DEBUG_MAX_BODY, in bytes.
+// Zero logs bodies in full; unset or invalid values use DefaultMaxBodyLog.
+func MaxBodyLogFromEnv() int {
+	if value := os.Getenv("ECHOPOINT_DEBUG_MAX_BODY"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			return n
+		}
 	}
-	return nil
+	return DefaultMaxBodyLog
+}
+
+// TruncateBody cuts body to at most max bytes, backing off to a character
+// boundary, and notes how much was dropped. A max of zero or less keeps the
+// whole body.
+func TruncateBody(body string, max int) string {
+	if max <= 0 || len(body) <= max {
+		return body
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...(truncated %d bytes)", body[:cut], len(body)-cut)
+}
+
+// SetRedactor sets how sensitive fields in logged bodies are masked
+func (l *Logger) SetRedactor(r *redact.Redactor) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redactor = r
 }
 
 // IsEnabled returns true if logging is enabled
 func (l *Logger) IsEnabled() bool {
-	return l.enabled
+	return l.GetLevel() > DebugLevelOff
 }
 
 // GetLevel returns the current debug level
 func (l *Logger) GetLevel() DebugLevel {
-	return l.level
+	return DebugLevel(l.level.Load())
 }
 
 // log writes a log entry
@@ -120,7 +175,8 @@ func (l *Logger) log(level string, message string) {
 
 // shouldLog returns true if the given level should be logged
 func (l *Logger) shouldLog(level DebugLevel) bool {
-	return l.enabled && level <= l.level
+	current := l.GetLevel()
+	return current > DebugLevelOff && level <= current
 }
 
 // Error logs an error message
@@ -185,12 +241,12 @@ func (l *Logger) LogRequest(method, url string, headers map[string]string, body
 	for k, v := range headers {
 		sb.WriteString(fmt.Sprintf("  %s: %s\n", k, v))
 	}
-	if body != "" {
-		sb.WriteString(fmt.Sprintf("Body: %s\n", body))
-	}
 
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if body != "" {
+		sb.WriteString(fmt.Sprintf("Body: %s\n", TruncateBody(l.redactor.String(body), l.maxBody)))
+	}
 	l.log("REQUEST", sb.String())
 }
 
@@ -200,10 +256,9 @@ func (l *Logger) LogResponse(statusCode int, status string, body string, duratio
 		return
 	}
 
-	msg := fmt.Sprintf("RESPONSE: %d %s (took %v)\nBody: %s", statusCode, status, duration, body)
-
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	msg := fmt.Sprintf("RESPONSE: %d %s (took %v)\nBody: %s", statusCode, status, duration, TruncateBody(l.redactor.String(body), l.maxBody))
 	l.log("RESPONSE", msg)
 }
 
@@ -0,0 +1,211 @@
+package floweditor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"echopoint-cli/internal/redact"
+)
+
+// initTestLogger points the global logger at a file in a temporary directory
+// and turns it off again when the test ends
+func initTestLogger(t *testing.T, level DebugLevel) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "debug.log")
+	if err := InitLogger(level, path); err != nil {
+		t.Fatalf("InitLogger: %v", err)
+	}
+	t.Cleanup(func() { _ = InitLogger(DebugLevelOff, "") })
+	return path
+}
+
+// readLog returns everything written to the log file at path
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestTruncateBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		max  int
+		want string
+	}{
+		{name: "shorter than max", body: "abc", max: 4, want: "abc"},
+		{name: "exactly max", body: "abcd", max: 4, want: "abcd"},
+		{name: "one byte over", body: "abcde", max: 4, want: "abcd...(truncated 1 bytes)"},
+		{name: "far over", body: strings.Repeat("x", 100), max: 10, want: "xxxxxxxxxx...(truncated 90 bytes)"},
+		{name: "zero keeps all", body: "abcdef", max: 0, want: "abcdef"},
+		{name: "negative keeps all", body: "abcdef", max: -1, want: "abcdef"},
+		{name: "empty", body: "", max: 4, want: ""},
+		// "é" is two bytes, so a cut inside it backs off to before it
+		{name: "inside multibyte rune", body: "aé", max: 2, want: "a...(truncated 2 bytes)"},
+		{name: "after multibyte rune", body: "aéb", max: 3, want: "aé...(truncated 1 bytes)"},
+		{name: "inside first rune", body: "日本", max: 2, want: "...(truncated 6 bytes)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateBody(tt.body, tt.max); got != tt.want {
+				t.Errorf("TruncateBody(%q, %d) = %q, want %q", tt.body, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxBodyLogFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "", want: DefaultMaxBodyLog},
+		{value: "1024", want: 1024},
+		{value: "0", want: 0},
+		{value: "-5", want: DefaultMaxBodyLog},
+		{value: "1k", want: DefaultMaxBodyLog},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("ECHOPOINT_DEBUG_MAX_BODY", tt.value)
+			if got := MaxBodyLogFromEnv(); got != tt.want {
+				t.Errorf("MaxBodyLogFromEnv = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogBodiesAreTruncated(t *testing.T) {
+	t.Setenv("ECHOPOINT_DEBUG_MAX_BODY", "8")
+	path := initTestLogger(t, DebugLevelDebug)
+
+	logger := GetLogger()
+	logger.LogRequest("POST", "https://example.com/flows", nil, "request-body-0123456789")
+	logger.LogResponse(200, "200 OK", "response-body-0123456789", time.Millisecond)
+	logger.LogResponse(200, "200 OK", "short", time.Millisecond)
+
+	log := readLog(t, path)
+	for _, want := range []string{
+		"Body: request-...(truncated 15 bytes)",
+		"Body: response...(truncated 16 bytes)",
+		"Body: short\n",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log does not contain %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "0123456789") {
+		t.Errorf("log contains the untruncated body:\n%s", log)
+	}
+}
+
+func TestLogBodiesAreRedacted(t *testing.T) {
+	path := initTestLogger(t, DebugLevelDebug)
+
+	logger := GetLogger()
+	logger.SetRedactor(redact.New("pin"))
+	t.Cleanup(func() { logger.SetRedactor(redact.New()) })
+
+	logger.LogRequest("POST", "https://example.com/login", nil, `{"user": "alice", "password": "hunter2"}`)
+	logger.LogResponse(200, "200 OK", `{"token": "abc123", "pin": "9876"}`, time.Millisecond)
+
+	log := readLog(t, path)
+	for _, secret := range []string{"hunter2", "abc123", "9876"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("log contains %q:\n%s", secret, log)
+		}
+	}
+	for _, want := range []string{`Body: {"password":"***","user":"alice"}`, `Body: {"pin":"***","token":"***"}`} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log does not contain %q:\n%s", want, log)
+		}
+	}
+}
+
+// TestLoggerReconfigureWhileLogging is meant for go test -race: log calls
+// from several goroutines must not race with InitLogger changing the level
+// and file underneath them
+func TestLoggerReconfigureWhileLogging(t *testing.T) {
+	dir := t.TempDir()
+	initTestLogger(t, DebugLevelInfo)
+	logger := GetLogger()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				logger.Info("worker %d", worker)
+				logger.Trace("worker %d trace", worker)
+				logger.LogRequest("GET", "https://example.com", map[string]string{"Accept": "*/*"}, `{"token": "t"}`)
+				logger.LogResponse(200, "200 OK", `{}`, time.Millisecond)
+				_ = logger.IsEnabled()
+			}
+		}(i)
+	}
+
+	levels := []DebugLevel{DebugLevelTrace, DebugLevelOff, DebugLevelError, DebugLevelDebug}
+	for i := 0; i < 50; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("debug-%d.log", i%3))
+		if err := InitLogger(levels[i%len(levels)], path); err != nil {
+			t.Errorf("InitLogger: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestInitLoggerClosesPreviousFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		level DebugLevel
+	}{
+		{name: "new path", level: DebugLevelDebug},
+		{name: "turned off", level: DebugLevelOff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := initTestLogger(t, DebugLevelInfo)
+			second := filepath.Join(t.TempDir(), "second.log")
+			logger := GetLogger()
+			previous := logger.file
+
+			if err := InitLogger(tt.level, second); err != nil {
+				t.Fatalf("InitLogger: %v", err)
+			}
+			if _, err := previous.WriteString("late\n"); !errors.Is(err, os.ErrClosed) {
+				t.Errorf("writing to the first log file = %v, want %v", err, os.ErrClosed)
+			}
+			logger.Info("after reconfigure")
+
+			if log := readLog(t, first); !strings.Contains(log, "Debug logging reconfigured") || strings.Contains(log, "after reconfigure") {
+				t.Errorf("first log should end at the reconfigure entry:\n%s", log)
+			}
+			if tt.level == DebugLevelOff {
+				if logger.file != nil {
+					t.Error("logger kept a file open while off")
+				}
+				return
+			}
+			if log := readLog(t, second); !strings.Contains(log, "after reconfigure") {
+				t.Errorf("second log does not contain later entries:\n%s", log)
+			}
+		})
+	}
+}
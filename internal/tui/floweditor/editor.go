@@ -19,6 +19,7 @@ import (
 type Editor struct {
 	client   *client.Client
 	flowID   uuid.UUID
+	flow     *api.Flow // last version loaded from or saved to the API
 	graph    *FlowGraph
 	mode     EditorMode
 	viewport viewport.Model
@@ -36,6 +37,18 @@ type Editor struct {
 
 	// Dirty flag for unsaved changes
 	dirty bool
+
+	// Quit confirmation state: confirmingQuit is armed by the first quit
+	// key while there are unsaved changes, quitAfterSave by choosing to
+	// save from that prompt.
+	confirmingQuit bool
+	quitAfterSave  bool
+
+	// showPanel toggles the detail panel for the selected node
+	showPanel bool
+
+	// pendingSave holds the changes shown for confirmation before saving
+	pendingSave *changeSummary
 }
 
 // EditorConfig contains configuration for creating a new editor
@@ -103,7 +116,8 @@ type flowLoadedMsg struct {
 
 // flowSavedMsg is sent when a flow is saved to the API
 type flowSavedMsg struct {
-	err error
+	flow *api.Flow
+	err  error
 }
 
 // LoadFlow loads a flow from the API
@@ -132,11 +146,35 @@ func (e *Editor) LoadFlow() tea.Cmd {
 	}
 }
 
-// SaveFlow saves the current flow to the API
+// SaveFlow saves the current graph, including node positions, to the API
 func (e *Editor) SaveFlow() tea.Cmd {
+	logger := GetLogger()
+
+	if e.flow == nil {
+		return func() tea.Msg {
+			return flowSavedMsg{err: fmt.Errorf("flow has not been loaded")}
+		}
+	}
+
+	// Build the request now so the command doesn't read the graph concurrently
+	req, err := buildUpdateRequest(e.graph, e.flow)
+	if err != nil {
+		return func() tea.Msg {
+			return flowSavedMsg{err: err}
+		}
+	}
+
+	logger.Info("Saving flow to API: %s", e.flowID.String())
+
 	return func() tea.Msg {
-		e.dirty = false
-		return flowSavedMsg{}
+		resp, err := e.client.API().UpdateFlowWithResponse(context.Background(), e.flowID, req)
+		if err != nil {
+			return flowSavedMsg{err: fmt.Errorf("failed to save flow: %w", err)}
+		}
+		if resp.JSON200 == nil {
+			return flowSavedMsg{err: fmt.Errorf("failed to save flow (status %d)", resp.StatusCode())}
+		}
+		return flowSavedMsg{flow: resp.JSON200}
 	}
 }
 
@@ -154,6 +192,12 @@ func (e *Editor) Update(msg tea.Msg) (*Editor, tea.Cmd) {
 		logger.LogKey(msg.String(), e.mode)
 		return e.handleKey(msg)
 
+	case tea.MouseMsg:
+		if e.pendingSave == nil && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			e.handleClick(msg.X, msg.Y)
+			return e, nil
+		}
+
 	case tea.WindowSizeMsg:
 		logger.Debug("Window resized to %dx%d", msg.Width, msg.Height)
 		e.width = msg.Width
@@ -175,10 +219,16 @@ func (e *Editor) Update(msg tea.Msg) (*Editor, tea.Cmd) {
 		if msg.err != nil {
 			logger.Error("Failed to save flow: %v", msg.err)
 			e.err = msg.err
+			e.quitAfterSave = false
 			return e, nil
 		}
 		logger.Info("Flow saved successfully")
+		e.flow = msg.flow
+		e.dirty = false
 		e.message = "Flow saved successfully"
+		if e.quitAfterSave {
+			return e, tea.Quit
+		}
 	}
 
 	var cmd tea.Cmd
@@ -189,6 +239,34 @@ func (e *Editor) Update(msg tea.Msg) (*Editor, tea.Cmd) {
 
 // handleKey handles keyboard input
 func (e *Editor) handleKey(msg tea.KeyMsg) (*Editor, tea.Cmd) {
+	// Any key other than the ones answering the prompt cancels a pending quit
+	confirmingQuit := e.confirmingQuit
+	e.confirmingQuit = false
+
+	if e.pendingSave != nil {
+		switch msg.String() {
+		case "enter", "y":
+			e.pendingSave = nil
+			return e, e.SaveFlow()
+		case "esc", "n":
+			e.pendingSave = nil
+			e.message = "Save cancelled"
+		}
+		return e, nil
+	}
+
+	if confirmingQuit {
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return e, tea.Quit
+		case "s":
+			e.quitAfterSave = true
+			e.message = "Saving before quit..."
+			return e, e.SaveFlow()
+		}
+		e.message = ""
+	}
+
 	switch e.mode {
 	case ModeView, ModeSelect:
 		return e.handleNavigationKey(msg)
@@ -203,14 +281,22 @@ func (e *Editor) handleNavigationKey(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 	switch msg.String() {
 	case "q", "ctrl+c":
 		if e.dirty {
-			e.message = "Unsaved changes! Press Q again to quit without saving"
-			e.dirty = false
+			e.confirmingQuit = true
+			e.message = "Unsaved changes! Press q again to discard them, or s to save and quit"
 			return e, nil
 		}
 		return e, tea.Quit
 
 	case "s":
-		return e, e.SaveFlow()
+		if e.flow == nil {
+			return e, e.SaveFlow()
+		}
+		if !e.dirty {
+			e.message = "No changes to save"
+			return e, nil
+		}
+		summary := summarizeChanges(e.graph, e.flow)
+		e.pendingSave = &summary
 
 	case "r":
 		return e, e.LoadFlow()
@@ -265,6 +351,12 @@ func (e *Editor) handleNavigationKey(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 			e.dirty = true
 		}
 
+	case "i":
+		e.showPanel = !e.showPanel
+		if e.showPanel && !e.panelFits() {
+			e.message = "Terminal too narrow for the detail panel"
+		}
+
 	case "?":
 		e.showHelp()
 	}
@@ -307,6 +399,28 @@ func (e *Editor) handleConnectKey(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 	return e, nil
 }
 
+// handleClick selects the node under screen position (x, y). The graph is
+// drawn from the top-left of the screen and only scrolls vertically, so the
+// grid cell is the screen cell shifted by the viewport's vertical offset.
+func (e *Editor) handleClick(x, y int) {
+	if y >= e.viewport.Height || x >= e.viewport.Width {
+		return // status bar or detail panel
+	}
+
+	node := e.graph.NodeAt(x, y+e.viewport.YOffset)
+	if node == nil {
+		if e.mode != ModeConnect {
+			e.graph.ClearSelection()
+			e.selectedNodeID = nil
+		}
+		return
+	}
+
+	e.graph.SelectNode(node.ID)
+	e.selectedNodeID = &node.ID
+	GetLogger().LogNode("SELECTED", node)
+}
+
 // selectNextNode cycles through nodes
 func (e *Editor) selectNextNode() {
 	logger := GetLogger()
@@ -359,25 +473,24 @@ func (e *Editor) moveSelectedNode(direction string) {
 
 // showHelp displays help message
 func (e *Editor) showHelp() {
-	e.message = "?:Help | n:New | c:Connect | x:Delete | arrows:Move | s:Save | q:Quit"
+	e.message = "?:Help | n:New | c:Connect | x:Delete | i:Inspect | arrows:Move | s:Save | q:Quit"
 }
 
 // populateGraphFromFlow converts API flow to graph
 func (e *Editor) populateGraphFromFlow(flow *api.Flow) {
-	e.graph.ID = flow.Id
-	e.graph.Name = flow.Name
-	if flow.Description != nil {
-		e.graph.Description = *flow.Description
+	graph, err := buildGraph(flow)
+	if err != nil {
+		GetLogger().Error("Failed to build graph: %v", err)
+		e.err = err
+		return
 	}
 
-	// Clear existing nodes and edges
-	e.graph.Nodes = make([]Node, 0)
-	e.graph.Edges = make([]Edge, 0)
-
-	// TODO: Parse flow.Definition and populate nodes/edges
-	// For now, add a start and end node as placeholders
-	e.graph.AddNode(NodeTypeStart, "Start", 5, 5)
-	e.graph.AddNode(NodeTypeEnd, "End", 5, 15)
+	e.flow = flow
+	e.graph = graph
+	e.selectedNodeID = nil
+	e.connectSourceID = nil
+	e.mode = ModeView
+	e.dirty = false
 }
 
 // View renders the editor
@@ -391,6 +504,22 @@ func (e *Editor) View() string {
 
 	statusBar := e.renderStatusBar()
 
+	if e.pendingSave != nil {
+		box := e.pendingSave.render(e.width)
+		return lipgloss.Place(e.width, e.viewport.Height, lipgloss.Center, lipgloss.Center, box) + "\n" + statusBar
+	}
+
+	if e.showPanel && e.panelFits() {
+		var node *Node
+		if e.selectedNodeID != nil {
+			node = e.graph.GetNode(*e.selectedNodeID)
+		}
+		e.viewport.Width = e.width - detailPanelWidth
+		panel := renderDetailPanel(node, e.viewport.Height)
+		return lipgloss.JoinHorizontal(lipgloss.Top, e.viewport.View(), panel) + "\n" + statusBar
+	}
+
+	e.viewport.Width = e.width
 	return e.viewport.View() + "\n" + statusBar
 }
 
@@ -438,22 +567,26 @@ func (e *Editor) renderNode(grid [][]rune, node *Node) {
 	width := node.Width
 	height := node.Height
 
-	// Draw box
+	// Draw box, dashed for disabled nodes
+	horizontal, vertical := '─', '│'
+	if node.Disabled {
+		horizontal, vertical = '╌', '╎'
+	}
 	for i := range width {
 		if y >= 0 && y < len(grid) && x+i >= 0 && x+i < len(grid[0]) {
-			grid[y][x+i] = '─'
+			grid[y][x+i] = horizontal
 		}
 		if y+height-1 >= 0 && y+height-1 < len(grid) && x+i >= 0 && x+i < len(grid[0]) {
-			grid[y+height-1][x+i] = '─'
+			grid[y+height-1][x+i] = horizontal
 		}
 	}
 
 	for i := range height {
 		if y+i >= 0 && y+i < len(grid) && x >= 0 && x < len(grid[0]) {
-			grid[y+i][x] = '│'
+			grid[y+i][x] = vertical
 		}
 		if y+i >= 0 && y+i < len(grid) && x+width-1 >= 0 && x+width-1 < len(grid[0]) {
-			grid[y+i][x+width-1] = '│'
+			grid[y+i][x+width-1] = vertical
 		}
 	}
 
@@ -524,6 +657,12 @@ func (e *Editor) renderStatusBar() string {
 		status += " [modified]"
 	}
 
+	if e.selectedNodeID != nil {
+		if node := e.graph.GetNode(*e.selectedNodeID); node != nil {
+			status += " | " + nodeSummary(node)
+		}
+	}
+
 	if e.message != "" {
 		status += " | " + e.message
 	}
@@ -534,3 +673,28 @@ func (e *Editor) renderStatusBar() string {
 
 	return style.Render(status)
 }
+
+// nodeSummary describes a node's type, short ID, and output and assertion counts
+func nodeSummary(node *Node) string {
+	// Node IDs are UUIDv7, whose leading digits are a timestamp shared by
+	// nodes created together, so show the random tail instead
+	id := node.Key
+	if len(id) > 8 {
+		id = "…" + id[len(id)-8:]
+	}
+	summary := fmt.Sprintf("%s %s · %s · %s",
+		NodeTypeDisplay(node.Type), id,
+		countLabel(node.Outputs, "output"), countLabel(node.Assertions, "assertion"))
+	if node.Disabled {
+		summary += " · disabled"
+	}
+	return summary
+}
+
+// countLabel formats n with singular or plural noun
+func countLabel(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
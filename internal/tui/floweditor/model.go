@@ -25,6 +25,7 @@ const (
 // Node represents a node in the flow graph
 type Node struct {
 	ID         uuid.UUID
+	Key        string // ID of the node in the flow definition
 	Type       NodeType
 	Name       string
 	X, Y       int // Position in the grid
@@ -33,7 +34,12 @@ type Node struct {
 	Data       NodeData
 	Assertions int // Count of assertions (for display)
 	Outputs    int // Count of outputs (for display)
+	Disabled   bool
 	Selected   bool
+
+	// One-line descriptions of each output and assertion (for display)
+	OutputDetails    []string
+	AssertionDetails []string
 }
 
 // NodeData contains type-specific node configuration
@@ -51,6 +57,7 @@ type NodeData struct {
 // Edge represents a connection between two nodes
 type Edge struct {
 	ID       uuid.UUID
+	Key      string // ID of the edge in the flow definition
 	From     uuid.UUID
 	To       uuid.UUID
 	Type     EdgeType
@@ -104,8 +111,10 @@ func NewFlowGraph(id uuid.UUID, name string) *FlowGraph {
 
 // AddNode adds a new node to the graph
 func (g *FlowGraph) AddNode(nodeType NodeType, name string, x, y int) *Node {
+	id := uuid.New()
 	node := Node{
-		ID:     uuid.New(),
+		ID:     id,
+		Key:    id.String(),
 		Type:   nodeType,
 		Name:   name,
 		X:      x,
@@ -127,8 +136,10 @@ func (g *FlowGraph) AddNode(nodeType NodeType, name string, x, y int) *Node {
 
 // AddEdge adds a new edge between two nodes
 func (g *FlowGraph) AddEdge(from, to uuid.UUID, edgeType EdgeType) *Edge {
+	id := uuid.New()
 	edge := Edge{
-		ID:   uuid.New(),
+		ID:   id,
+		Key:  id.String(),
 		From: from,
 		To:   to,
 		Type: edgeType,
@@ -229,6 +240,18 @@ func (g *FlowGraph) GetSelectedNode() *Node {
 	return nil
 }
 
+// NodeAt returns the node whose box contains grid cell (x, y). Nodes are drawn
+// in order, so the last one containing the cell is the one on top.
+func (g *FlowGraph) NodeAt(x, y int) *Node {
+	for i := len(g.Nodes) - 1; i >= 0; i-- {
+		node := &g.Nodes[i]
+		if x >= node.X && x < node.X+node.Width && y >= node.Y && y < node.Y+node.Height {
+			return node
+		}
+	}
+	return nil
+}
+
 // MoveNode moves a node to a new position
 func (g *FlowGraph) MoveNode(id uuid.UUID, x, y int) {
 	node := g.GetNode(id)
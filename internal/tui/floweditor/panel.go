@@ -0,0 +1,88 @@
+package floweditor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// detailPanelWidth is the width of the inspect panel including its border
+	detailPanelWidth = 42
+
+	// minGraphWidth is the narrowest graph area kept beside the panel; below
+	// it the panel is hidden
+	minGraphWidth = 40
+)
+
+// panelFits reports whether the terminal is wide enough for the detail panel
+func (e *Editor) panelFits() bool {
+	return e.width >= detailPanelWidth+minGraphWidth
+}
+
+// renderDetailPanel renders the full details of node for the inspect panel
+func renderDetailPanel(node *Node, height int) string {
+	// Width and Height exclude the border, which takes one cell on each side
+	style := lipgloss.NewStyle().
+		Width(detailPanelWidth-2).
+		Height(max(height-2, 1)).
+		MaxHeight(max(height, 3)).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1)
+
+	if node == nil {
+		return style.Render("No node selected.\n\nPress tab to select a node.")
+	}
+
+	title := lipgloss.NewStyle().Bold(true)
+	section := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	var b strings.Builder
+	b.WriteString(title.Render(node.Name) + "\n")
+	b.WriteString(NodeTypeDisplay(node.Type) + "\n")
+	if node.Disabled {
+		b.WriteString("Disabled (skipped when the flow runs)\n")
+	}
+	b.WriteString(section.Render(node.Key) + "\n")
+
+	switch node.Type {
+	case NodeTypeRequest:
+		b.WriteString("\n" + section.Render("Request") + "\n")
+		fmt.Fprintf(&b, "%s %s\n", node.Data.Method, node.Data.URL)
+		if len(node.Data.Headers) > 0 {
+			b.WriteString("\n" + section.Render("Headers") + "\n")
+			names := make([]string, 0, len(node.Data.Headers))
+			for name := range node.Data.Headers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintf(&b, "%s: %s\n", name, node.Data.Headers[name])
+			}
+		}
+		if node.Data.Body != "" {
+			b.WriteString("\n" + section.Render("Body") + "\n")
+			b.WriteString(node.Data.Body + "\n")
+		}
+	case NodeTypeDelay:
+		b.WriteString("\n" + section.Render("Delay") + "\n")
+		fmt.Fprintf(&b, "%d ms\n", node.Data.Duration)
+	default:
+		b.WriteString("\nThis node type is not supported by the editor; it is saved unchanged.\n")
+	}
+
+	b.WriteString("\n" + section.Render(fmt.Sprintf("Outputs (%d)", node.Outputs)) + "\n")
+	for _, output := range node.OutputDetails {
+		b.WriteString("• " + output + "\n")
+	}
+
+	b.WriteString("\n" + section.Render(fmt.Sprintf("Assertions (%d)", node.Assertions)) + "\n")
+	for _, assertion := range node.AssertionDetails {
+		b.WriteString("• " + assertion + "\n")
+	}
+
+	return style.Render(strings.TrimRight(b.String(), "\n"))
+}
@@ -0,0 +1,154 @@
+package floweditor
+
+import (
+	"fmt"
+	"strings"
+
+	"echopoint-cli/internal/api"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// changeSummary lists what a save would change compared to the saved flow
+type changeSummary struct {
+	AddedNodes   []string
+	RemovedNodes []string
+	MovedNodes   int
+	AddedEdges   []string
+	RemovedEdges []string
+}
+
+// summarizeChanges compares the graph with the flow it was loaded from
+func summarizeChanges(graph *FlowGraph, flow *api.Flow) changeSummary {
+	var summary changeSummary
+
+	saved := make(map[string]string, len(flow.FlowDefinition.Nodes))
+	for _, apiNode := range flow.FlowDefinition.Nodes {
+		value, err := apiNode.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			saved[n.Id] = n.DisplayName
+		case api.DelayFlowNode:
+			saved[n.Id] = n.DisplayName
+		}
+	}
+
+	var positions map[string]nodePosition
+	if flow.Metadata.NodePositions != nil {
+		positions = *flow.Metadata.NodePositions
+	}
+
+	names := make(map[string]string, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		names[node.Key] = node.Name
+		if _, ok := saved[node.Key]; !ok {
+			summary.AddedNodes = append(summary.AddedNodes, node.Name)
+			continue
+		}
+		if pos, ok := positions[node.Key]; ok && pos.X != nil && pos.Y != nil {
+			if node.X != int(*pos.X/positionScaleX) || node.Y != int(*pos.Y/positionScaleY) {
+				summary.MovedNodes++
+			}
+		}
+	}
+	for _, apiNode := range flow.FlowDefinition.Nodes {
+		value, err := apiNode.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		var id, name string
+		switch n := value.(type) {
+		case api.RequestFlowNode:
+			id, name = n.Id, n.DisplayName
+		case api.DelayFlowNode:
+			id, name = n.Id, n.DisplayName
+		default:
+			continue
+		}
+		if _, ok := names[id]; !ok {
+			summary.RemovedNodes = append(summary.RemovedNodes, name)
+		}
+	}
+
+	current := make(map[string]bool, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		current[edge.Key] = true
+	}
+	for _, edge := range graph.Edges {
+		if !flowHasEdge(flow, edge.Key) {
+			from, to := graph.GetNode(edge.From), graph.GetNode(edge.To)
+			if from != nil && to != nil {
+				summary.AddedEdges = append(summary.AddedEdges, fmt.Sprintf("%s → %s (%s)", from.Name, to.Name, edge.Type))
+			}
+		}
+	}
+	for _, edge := range flow.FlowDefinition.Edges {
+		if !current[edge.Id] {
+			summary.RemovedEdges = append(summary.RemovedEdges, fmt.Sprintf("%s → %s (%s)", nodeName(saved, edge.Source), nodeName(saved, edge.Target), edge.Type))
+		}
+	}
+
+	return summary
+}
+
+// flowHasEdge reports whether the saved flow has an edge with key
+func flowHasEdge(flow *api.Flow, key string) bool {
+	for _, edge := range flow.FlowDefinition.Edges {
+		if edge.Id == key {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeName returns the display name of a saved node, falling back to its ID
+func nodeName(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
+// Empty reports whether the summary has no changes to save
+func (s changeSummary) Empty() bool {
+	return len(s.AddedNodes) == 0 && len(s.RemovedNodes) == 0 && s.MovedNodes == 0 &&
+		len(s.AddedEdges) == 0 && len(s.RemovedEdges) == 0
+}
+
+// render draws the summary as a confirmation box
+func (s changeSummary) render(width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Save these changes?") + "\n")
+
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", title, len(items))
+		for _, item := range items {
+			b.WriteString("  • " + item + "\n")
+		}
+	}
+	section("Nodes added", s.AddedNodes)
+	section("Nodes removed", s.RemovedNodes)
+	if s.MovedNodes > 0 {
+		fmt.Fprintf(&b, "\nNodes moved (%d)\n", s.MovedNodes)
+	}
+	section("Edges added", s.AddedEdges)
+	section("Edges removed", s.RemovedEdges)
+	if s.Empty() {
+		b.WriteString("\nNo structural changes; node positions will be saved.\n")
+	}
+
+	b.WriteString("\nenter/y: save • esc/n: cancel")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("205")).
+		Padding(1, 2).
+		MaxWidth(width).
+		Render(b.String())
+}